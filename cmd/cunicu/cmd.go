@@ -12,10 +12,15 @@ import (
 	_ "cunicu.li/cunicu/pkg/daemon/feature/hooks"
 	_ "cunicu.li/cunicu/pkg/daemon/feature/hsync"
 	_ "cunicu.li/cunicu/pkg/daemon/feature/pdisc"
+	_ "cunicu.li/cunicu/pkg/daemon/feature/pske"
 	_ "cunicu.li/cunicu/pkg/daemon/feature/rtsync"
 
 	// Signaling backends.
+	_ "cunicu.li/cunicu/pkg/signaling/file"
 	_ "cunicu.li/cunicu/pkg/signaling/grpc"
 	_ "cunicu.li/cunicu/pkg/signaling/inprocess"
 	_ "cunicu.li/cunicu/pkg/signaling/mcast"
+	_ "cunicu.li/cunicu/pkg/signaling/mqtt"
+	_ "cunicu.li/cunicu/pkg/signaling/nats"
+	_ "cunicu.li/cunicu/pkg/signaling/ws"
 )
@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+	rpcproto "cunicu.li/cunicu/pkg/proto/rpc"
+)
+
+type communitySignOptions struct {
+	caKey    string
+	caCert   string
+	hostname string
+	roles    []string
+	validity time.Duration
+	ca       bool
+}
+
+type communityRevokeOptions struct {
+	caKey  string
+	caCert string
+	reason string
+	intf   string
+}
+
+//nolint:gochecknoglobals
+var communityCmd = &cobra.Command{
+	Use:   "community",
+	Short: "Manage certificates for community membership",
+	Long: `The community sub-command manages certificate authorities (CAs) and certificates which attest the membership of peers in a community.
+
+If the community_ca setting is configured, peer discovery only adds peers which carry a valid certificate chain issued by one of the configured CAs.
+Each peer provides its own certificate chain via the community_certificate setting.`,
+	Args: cobra.NoArgs,
+}
+
+func init() { //nolint:gochecknoinits
+	opts := &communitySignOptions{}
+
+	issueCmd := &cobra.Command{
+		Use:   "issue CA-KEY-FILE",
+		Short: "Issue a new community certificate authority",
+		Long: `Generates a new Ed25519 key pair for a community certificate authority (CA).
+
+The private key is written to CA-KEY-FILE and must be kept secret.
+The public key is printed to standard output and must be added to the community_ca setting of all peers.`,
+		Example: `$ cunicu community issue ca.key
+XxFr6bZ1Jjlpl4mVzQr3oRsPOsnwbA1I3CymGCfzTGY=`,
+		Run:  communityIssue,
+		Args: cobra.ExactArgs(1),
+	}
+
+	signCmd := &cobra.Command{
+		Use:   "sign PUBLIC-KEY",
+		Short: "Sign the public key of a community member",
+		Long: `Issues a certificate for the WireGuard public key of a community member and prints the certificate chain in PEM format to standard output.
+
+With --ca, the certificate is issued for the Ed25519 public key of an intermediate CA instead which can sign certificates on its own.
+The certificate chain of an intermediate CA must be passed via --ca-cert when using it for signing.`,
+		Example: `$ cunicu community sign --ca-key ca.key --hostname my-node --role server coNsGPwVPdpahc8U+dbbWGzTAdCd6+1BvPIYg10wDCI= > my-node.crt`,
+		Run: func(cmd *cobra.Command, args []string) {
+			communitySign(cmd, args, opts)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	pf := signCmd.PersistentFlags()
+	pf.StringVarP(&opts.caKey, "ca-key", "k", "", "`file` containing the private key of the issuing CA")
+	pf.StringVarP(&opts.caCert, "ca-cert", "c", "", "`file` containing the certificate chain of an intermediate issuing CA")
+	pf.StringVarP(&opts.hostname, "hostname", "H", "", "hostname which the member is allowed to advertise")
+	pf.StringSliceVarP(&opts.roles, "role", "r", nil, "role of the member which certifies a tag of the form \"key=value\" or \"key\"")
+	pf.DurationVarP(&opts.validity, "validity", "V", 365*24*time.Hour, "validity period of the certificate")
+	pf.BoolVar(&opts.ca, "ca", false, "issue a certificate for an intermediate CA")
+
+	if err := signCmd.MarkPersistentFlagRequired("ca-key"); err != nil {
+		panic(err)
+	}
+
+	revokeOpts := &communityRevokeOptions{}
+
+	revokeCmd := &cobra.Command{
+		Use:   "revoke PUBLIC-KEY",
+		Short: "Revoke the community membership of a peer",
+		Long: `Signs a revocation for the WireGuard public key of a community member and passes it to the daemon.
+
+The daemon removes the revoked peer, persists the revocation and distributes it to all other community members via peer discovery.
+Those will also remove the peer and reject its future peer descriptions.`,
+		Example: `$ cunicu community revoke --ca-key ca.key --reason "stolen laptop" coNsGPwVPdpahc8U+dbbWGzTAdCd6+1BvPIYg10wDCI=`,
+		Run: func(cmd *cobra.Command, args []string) {
+			communityRevoke(cmd, args, revokeOpts)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	pf = revokeCmd.PersistentFlags()
+	pf.StringVarP(&revokeOpts.caKey, "ca-key", "k", "", "`file` containing the private key of the issuing CA")
+	pf.StringVarP(&revokeOpts.caCert, "ca-cert", "c", "", "`file` containing the certificate chain of an intermediate issuing CA")
+	pf.StringVarP(&revokeOpts.reason, "reason", "R", "", "reason for the revocation")
+	pf.StringVarP(&revokeOpts.intf, "interface", "i", "", "`name` of the interface (default all interfaces)")
+
+	if err := revokeCmd.MarkPersistentFlagRequired("ca-key"); err != nil {
+		panic(err)
+	}
+
+	communityCmd.AddCommand(issueCmd)
+	communityCmd.AddCommand(signCmd)
+	addClientCommand(communityCmd, revokeCmd)
+
+	rootCmd.AddCommand(communityCmd)
+}
+
+func communityIssue(_ *cobra.Command, args []string) {
+	pk, sk, err := pdiscproto.GenerateCAKey()
+	if err != nil {
+		logger.Fatal("Failed to generate CA key", zap.Error(err))
+	}
+
+	buf, err := pdiscproto.MarshalCAKeyPEM(sk)
+	if err != nil {
+		logger.Fatal("Failed to marshal CA key", zap.Error(err))
+	}
+
+	f, err := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		logger.Fatal("Failed to create CA key file", zap.Error(err))
+	}
+	defer f.Close()
+
+	if _, err := f.Write(buf); err != nil {
+		logger.Fatal("Failed to write CA key file", zap.Error(err))
+	}
+
+	fmt.Fprintln(stdout, pk)
+}
+
+func communitySign(_ *cobra.Command, args []string, opts *communitySignOptions) {
+	pk, err := crypto.ParseKey(args[0])
+	if err != nil {
+		logger.Fatal("Invalid public key", zap.Error(err))
+	}
+
+	sk, err := loadCAKey(opts.caKey)
+	if err != nil {
+		logger.Fatal("Failed to load CA key", zap.Error(err))
+	}
+
+	chain := []*pdiscproto.Certificate{}
+	if opts.caCert != "" {
+		if chain, err = pdiscproto.LoadCertificates(opts.caCert); err != nil {
+			logger.Fatal("Failed to load CA certificate", zap.Error(err))
+		}
+
+		if caPK, ok := sk.Public().(ed25519.PublicKey); !ok || !caPK.Equal(ed25519.PublicKey(chain[0].PublicKey)) {
+			logger.Fatal("CA certificate does not match CA key")
+		}
+	}
+
+	cert := pdiscproto.NewCertificate(pk.Bytes(), opts.validity)
+	cert.Ca = opts.ca
+	cert.Hostname = opts.hostname
+	cert.Roles = opts.roles
+
+	if err := cert.Sign(sk); err != nil {
+		logger.Fatal("Failed to sign certificate", zap.Error(err))
+	}
+
+	buf, err := pdiscproto.MarshalCertificatesPEM(append([]*pdiscproto.Certificate{cert}, chain...))
+	if err != nil {
+		logger.Fatal("Failed to marshal certificate", zap.Error(err))
+	}
+
+	if _, err := stdout.Write(buf); err != nil {
+		logger.Fatal("Failed to write to stdout", zap.Error(err))
+	}
+}
+
+func communityRevoke(_ *cobra.Command, args []string, opts *communityRevokeOptions) {
+	pk, err := crypto.ParseKey(args[0])
+	if err != nil {
+		logger.Fatal("Invalid public key", zap.Error(err))
+	}
+
+	sk, err := loadCAKey(opts.caKey)
+	if err != nil {
+		logger.Fatal("Failed to load CA key", zap.Error(err))
+	}
+
+	r := pdiscproto.NewRevocation(pk.Bytes(), opts.reason)
+
+	if opts.caCert != "" {
+		if r.Certificates, err = pdiscproto.LoadCertificates(opts.caCert); err != nil {
+			logger.Fatal("Failed to load CA certificate", zap.Error(err))
+		}
+	}
+
+	if err := r.Sign(sk); err != nil {
+		logger.Fatal("Failed to sign revocation", zap.Error(err))
+	}
+
+	if _, err := rpcClient.RevokePeer(context.Background(), &rpcproto.RevokePeerParams{
+		Intf:       opts.intf,
+		Revocation: r,
+	}); err != nil {
+		logger.Fatal("Failed to revoke peer", zap.Error(err))
+	}
+}
+
+func loadCAKey(fn string) (ed25519.PrivateKey, error) {
+	buf, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdiscproto.ParseCAKeyPEM(buf)
+}
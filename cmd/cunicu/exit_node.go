@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	rpcproto "cunicu.li/cunicu/pkg/proto/rpc"
+)
+
+type exitNodeOptions struct {
+	intf string
+}
+
+func init() { //nolint:gochecknoinits
+	opts := &exitNodeOptions{}
+
+	exitNodeCmd := &cobra.Command{
+		Use:   "exit-node",
+		Short: "Select a peer for routing default route traffic",
+		Long: `The exit-node sub-command selects one of the discovered peers which advertise themselves as exit node via the exit_node setting.
+
+All traffic which is not covered by a more specific route is then routed via the selected peer.`,
+		Args: cobra.NoArgs,
+	}
+
+	useCmd := &cobra.Command{
+		Use:   "use PEER",
+		Short: "Route default route traffic via a peer",
+		Long: `Selects the exit node by its hostname or public key.
+
+The selection overrides the use_exit_node setting until the daemon is restarted.`,
+		Example: `$ cunicu exit-node use my-exit-node`,
+		Run: func(_ *cobra.Command, args []string) {
+			useExitNode(args[0], opts)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	disableCmd := &cobra.Command{
+		Use:   "disable",
+		Short: "Stop routing default route traffic via an exit node",
+		Run: func(_ *cobra.Command, _ []string) {
+			useExitNode("", opts)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	for _, cmd := range []*cobra.Command{useCmd, disableCmd} {
+		cmd.PersistentFlags().StringVarP(&opts.intf, "interface", "i", "", "`name` of the interface (default all interfaces)")
+
+		addClientCommand(exitNodeCmd, cmd)
+	}
+
+	rootCmd.AddCommand(exitNodeCmd)
+}
+
+func useExitNode(peer string, opts *exitNodeOptions) {
+	if _, err := rpcClient.UseExitNode(context.Background(), &rpcproto.UseExitNodeParams{
+		Intf: opts.intf,
+		Peer: peer,
+	}); err != nil {
+		logger.Fatal("Failed to select exit node", zap.Error(err))
+	}
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	rpcproto "cunicu.li/cunicu/pkg/proto/rpc"
+)
+
+type peerOptions struct {
+	intf            string
+	remove          bool
+	enableWhitelist bool
+}
+
+func init() { //nolint:gochecknoinits
+	opts := &peerOptions{}
+
+	peerCmd := &cobra.Command{
+		Use:   "peer",
+		Short: "Manage which discovered peers are accepted",
+		Long: `The peer sub-command modifies the whitelist and blacklist of the peer discovery at runtime.
+
+Peers are selected by one of:
+ - their public key
+ - a prefix in CIDR notation which overlaps with one of the AllowedIPs claimed by the peer
+ - a glob(7) pattern which is matched against the hostname of the peer
+
+Changes are persisted in the runtime configuration of the daemon.`,
+		Args: cobra.NoArgs,
+	}
+
+	allowCmd := &cobra.Command{
+		Use:   "allow SELECTOR",
+		Short: "Add a peer selector to the whitelist",
+		Long: `Adds a peer selector to the whitelist and removes it from the blacklist.
+
+Once the whitelist is not empty, only peers which are matched by one of its selectors are accepted.
+Hence, adding the first selector to an empty whitelist requires the '--enable-whitelist' option.`,
+		Example: `$ cunicu peer allow --enable-whitelist "*.office.example.com"
+$ cunicu peer allow 10.237.0.0/16`,
+		Run: func(_ *cobra.Command, args []string) {
+			updatePeerFilter(args[0], true, opts)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	denyCmd := &cobra.Command{
+		Use:   "deny SELECTOR",
+		Short: "Add a peer selector to the blacklist",
+		Long: `Adds a peer selector to the blacklist and removes it from the whitelist.
+
+Already discovered peers which are matched by the selector are removed immediately.`,
+		Example: `$ cunicu peer deny "guest-*"
+$ cunicu peer deny --remove "guest-*"`,
+		Run: func(_ *cobra.Command, args []string) {
+			updatePeerFilter(args[0], false, opts)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	for _, cmd := range []*cobra.Command{allowCmd, denyCmd} {
+		pf := cmd.PersistentFlags()
+		pf.StringVarP(&opts.intf, "interface", "i", "", "`name` of the interface (default all interfaces)")
+		pf.BoolVarP(&opts.remove, "remove", "r", false, "Remove the selector from the list instead of adding it")
+
+		addClientCommand(peerCmd, cmd)
+	}
+
+	allowCmd.PersistentFlags().BoolVarP(&opts.enableWhitelist, "enable-whitelist", "w", false, "Permit adding the first selector to an empty whitelist which rejects all other peers")
+
+	rootCmd.AddCommand(peerCmd)
+}
+
+func updatePeerFilter(selector string, allow bool, opts *peerOptions) {
+	params := &rpcproto.PeerFilterParams{
+		Intf:            opts.intf,
+		Selector:        selector,
+		Remove:          opts.remove,
+		EnableWhitelist: opts.enableWhitelist,
+	}
+
+	var err error
+	if allow {
+		_, err = rpcClient.AllowPeer(context.Background(), params)
+	} else {
+		_, err = rpcClient.DenyPeer(context.Background(), params)
+	}
+
+	if err != nil {
+		logger.Fatal("Failed to update peer filter", zap.Error(err))
+	}
+}
@@ -5,29 +5,46 @@ package main
 
 import (
 	"net"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"cunicu.li/cunicu/pkg/crypto"
 	osx "cunicu.li/cunicu/pkg/os"
+	"cunicu.li/cunicu/pkg/relay"
 	grpcx "cunicu.li/cunicu/pkg/signaling/grpc"
+	"cunicu.li/cunicu/pkg/tty"
 )
 
 type relayOptions struct {
 	listenAddress string
 	secure        bool
+
+	// Embedded TURN server
+	turnListenAddress string
+	turnRelayAddress  net.IP
+	turnSecret        string
+	turnTTL           time.Duration
+	turnRealm         string
+	turnUserQuota     int
+	turnTotalQuota    int
+	turnBandwidth     int
 }
 
 func init() { //nolint:gochecknoinits
 	opts := &relayOptions{}
 	cmd := &cobra.Command{
-		Use:   "relay URL...",
+		Use:   "relay [URL...]",
 		Short: "Start relay API server",
 		Long: `This command starts a gRPC server providing cunicu agents with a list of available STUN and TURN servers.
 
-**Note:** Currently this command does not run a TURN server itself. But relies on an external server like Coturn.
+Optionally, the command also runs an embedded TURN server by passing the '--turn-listen' flag.
+The embedded server only accepts the short-lived credentials issued by the relay API server and advertises itself to the agents.
+Alternatively, an external server like Coturn can be used.
 
 With this feature you can distribute a list of available STUN/TURN servers easily to a fleet of agents.
 It also allows to issue short-lived HMAC-SHA1 credentials based the proposed TURN REST API and thereby static long term credentials.
@@ -43,21 +60,35 @@ The command expects a list of STUN or TURN URLs according to RFC7065/RFC7064 wit
 - Static TURN credentials can be provided by the URIs user info
   - Example: turn:user1:pass1@server.com
 `,
-		Example: `$ cunicu relay turn:server.com?secret=rest-api-secret&ttl=1h`,
+		Example: `$ cunicu relay turn:server.com?secret=rest-api-secret&ttl=1h
+$ cunicu relay --turn-listen :3478 --turn-relay-ip 198.51.100.1 stun:stun.cunicu.li`,
 		Run: func(cmd *cobra.Command, args []string) {
-			relay(cmd, args, opts)
+			runRelay(cmd, args, opts)
 		},
-		Args: cobra.MinimumNArgs(1),
+		Args: cobra.ArbitraryArgs,
 	}
 
 	pf := cmd.PersistentFlags()
 	pf.StringVarP(&opts.listenAddress, "listen", "L", ":8080", "listen address")
 	pf.BoolVarP(&opts.secure, "secure", "S", false, "listen with TLS")
 
+	pf.StringVar(&opts.turnListenAddress, "turn-listen", "", "UDP `address` of the embedded TURN server (default disabled)")
+	pf.IPVar(&opts.turnRelayAddress, "turn-relay-ip", nil, "Public `IP` address of the embedded TURN server (default listen address)")
+	pf.StringVar(&opts.turnSecret, "turn-secret", "", "Shared `secret` for issuing credentials of the embedded TURN server (default random)")
+	pf.DurationVar(&opts.turnTTL, "turn-ttl", grpcx.DefaultRelayTTL, "Lifetime of credentials issued for the embedded TURN server")
+	pf.StringVar(&opts.turnRealm, "turn-realm", relay.DefaultRealm, "Realm of the embedded TURN server")
+	pf.IntVar(&opts.turnUserQuota, "turn-user-quota", 0, "Maximum number of concurrent allocations per peer (default unlimited)")
+	pf.IntVar(&opts.turnTotalQuota, "turn-total-quota", 0, "Maximum number of concurrent allocations in total (default unlimited)")
+	pf.IntVar(&opts.turnBandwidth, "turn-bandwidth", 0, "Maximum bandwidth per allocation in `bytes` per second (default unlimited)")
+
 	rootCmd.AddCommand(cmd)
 }
 
-func relay(_ *cobra.Command, args []string, opts *relayOptions) {
+func runRelay(_ *cobra.Command, args []string, opts *relayOptions) {
+	if len(args) == 0 && opts.turnListenAddress == "" {
+		logger.Fatal("Either relay URLs or the embedded TURN server must be configured")
+	}
+
 	l, err := net.Listen("tcp", opts.listenAddress)
 	if err != nil {
 		logger.Fatal("Failed to listen", zap.Error(err))
@@ -74,6 +105,13 @@ func relay(_ *cobra.Command, args []string, opts *relayOptions) {
 		logger.Fatal("Failed to parse relays", zap.Error(err))
 	}
 
+	if opts.turnListenAddress != "" {
+		turnSvr, info := startTURNServer(opts)
+		defer turnSvr.Close()
+
+		relays = append(relays, info)
+	}
+
 	svr, err := grpcx.NewRelayAPIServer(relays, svrOpts...)
 	if err != nil {
 		logger.Fatal("Failed to start gRPC server", zap.Error(err))
@@ -97,3 +135,49 @@ func relay(_ *cobra.Command, args []string, opts *relayOptions) {
 
 	logger.Info("Gracefully stopped gRPC relay API server")
 }
+
+// startTURNServer starts the embedded TURN server and returns
+// the relay information which is advertised to the agents.
+func startTURNServer(opts *relayOptions) (*relay.Server, grpcx.RelayInfo) {
+	secret := opts.turnSecret
+	if secret == "" {
+		var err error
+		if secret, err = crypto.GetRandomString(32, tty.RunesAlphaNumeric); err != nil {
+			logger.Fatal("Failed to generate secret", zap.Error(err))
+		}
+	}
+
+	svr, err := relay.NewServer(relay.Config{
+		ListenAddress: opts.turnListenAddress,
+		RelayAddress:  opts.turnRelayAddress,
+		Relay: grpcx.RelayInfo{
+			Realm:  opts.turnRealm,
+			Secret: secret,
+			TTL:    opts.turnTTL,
+		},
+		UserQuota:  opts.turnUserQuota,
+		TotalQuota: opts.turnTotalQuota,
+		Bandwidth:  opts.turnBandwidth,
+	})
+	if err != nil {
+		logger.Fatal("Failed to start TURN server", zap.Error(err))
+	}
+
+	host := opts.turnRelayAddress
+	if host == nil {
+		host = svr.LocalAddr().(*net.UDPAddr).IP //nolint:forcetypeassert
+	}
+
+	port := svr.LocalAddr().(*net.UDPAddr).Port //nolint:forcetypeassert
+
+	info, err := grpcx.NewRelayInfo("turn:" + net.JoinHostPort(host.String(), strconv.Itoa(port)) + "?transport=udp")
+	if err != nil {
+		logger.Fatal("Failed to parse relay URL", zap.Error(err))
+	}
+
+	info.Realm = opts.turnRealm
+	info.Secret = secret
+	info.TTL = opts.turnTTL
+
+	return svr, info
+}
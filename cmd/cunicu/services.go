@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/config"
+	coreproto "cunicu.li/cunicu/pkg/proto/core"
+	rpcproto "cunicu.li/cunicu/pkg/proto/rpc"
+)
+
+type servicesOptions struct {
+	service string
+	domain  string
+	indent  bool
+	format  config.OutputFormat
+}
+
+// serviceTargetGroup is a target group in the format of Prometheus' file-based service discovery.
+// See: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#file_sd_config
+type serviceTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+func init() { //nolint:gochecknoinits
+	opts := &servicesOptions{
+		format: config.OutputFormatHuman,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "services [interface-name]",
+		Short: "List services offered by peers",
+		Long: `Lists the services which are advertised by peers via their peer descriptions.
+
+The JSON output is compatible with the file-based service discovery of Prometheus.`,
+		Example: `$ cunicu services --service _prometheus._tcp --format json > /etc/prometheus/targets/cunicu.json`,
+		Run: func(_ *cobra.Command, args []string) {
+			services(args, opts)
+		},
+		Args:              cobra.RangeArgs(0, 1),
+		ValidArgsFunction: interfaceValidArgs,
+	}
+
+	pf := cmd.PersistentFlags()
+	pf.StringVarP(&opts.service, "service", "S", "", "Only list services with this `name`, e.g. _ssh._tcp")
+	pf.StringVarP(&opts.domain, "domain", "D", "", "`domain` which is appended to the hostnames of the peers")
+	pf.VarP(&opts.format, "format", "f", "Output `format` (one of: human, json)")
+	pf.BoolVarP(&opts.indent, "indent", "i", true, "Format and indent JSON output")
+
+	if err := cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"human", "json"}, cobra.ShellCompDirectiveNoFileComp)); err != nil {
+		panic(err)
+	}
+
+	addClientCommand(rootCmd, cmd)
+}
+
+func services(args []string, opts *servicesOptions) {
+	p := &rpcproto.GetStatusParams{}
+
+	if len(args) > 0 {
+		p.Interface = args[0]
+	}
+
+	sts, err := rpcClient.GetStatus(context.Background(), p)
+	if err != nil {
+		logger.Fatal("Failed to retrieve status from daemon", zap.Error(err))
+	}
+
+	tgs := []serviceTargetGroup{}
+
+	for _, i := range sts.Interfaces {
+		for _, cp := range i.Peers {
+			host := serviceHost(cp, opts.domain)
+			if host == "" {
+				continue
+			}
+
+			for _, s := range cp.Services {
+				if opts.service != "" && s.Name != opts.service {
+					continue
+				}
+
+				tgs = append(tgs, serviceTargetGroup{
+					Targets: []string{
+						net.JoinHostPort(host, strconv.Itoa(int(s.Port))),
+					},
+					Labels: map[string]string{
+						"__meta_cunicu_service":    s.Name,
+						"__meta_cunicu_interface":  i.Name,
+						"__meta_cunicu_peer":       cp.Name,
+						"__meta_cunicu_public_key": base64.StdEncoding.EncodeToString(cp.PublicKey),
+						"__meta_cunicu_priority":   strconv.Itoa(int(s.Priority)),
+						"__meta_cunicu_weight":     strconv.Itoa(int(s.Weight)),
+					},
+				})
+			}
+		}
+	}
+
+	switch opts.format {
+	case config.OutputFormatJSON:
+		var (
+			buf []byte
+			err error
+		)
+
+		if opts.indent {
+			buf, err = json.MarshalIndent(tgs, "", "  ")
+		} else {
+			buf, err = json.Marshal(tgs)
+		}
+
+		if err != nil {
+			logger.Fatal("Failed to marshal", zap.Error(err))
+		}
+
+		if _, err = fmt.Fprintln(stdout, string(buf)); err != nil {
+			logger.Fatal("Failed to write to stdout", zap.Error(err))
+		}
+
+	case config.OutputFormatHuman:
+		for _, tg := range tgs {
+			if _, err := fmt.Fprintf(stdout, "%s %s (interface %s, peer %s)\n",
+				tg.Labels["__meta_cunicu_service"],
+				strings.Join(tg.Targets, ", "),
+				tg.Labels["__meta_cunicu_interface"],
+				tg.Labels["__meta_cunicu_public_key"]); err != nil {
+				logger.Fatal("Failed to write to stdout", zap.Error(err))
+			}
+		}
+
+	case config.OutputFormatLogger:
+	}
+}
+
+// serviceHost returns the hostname of the peer or its first host address
+// if the peer does not advertise a hostname.
+func serviceHost(cp *coreproto.Peer, domain string) string {
+	if cp.Name != "" {
+		if domain != "" {
+			return cp.Name + "." + strings.TrimPrefix(domain, ".")
+		}
+
+		return cp.Name
+	}
+
+	for _, aip := range cp.AllowedIps {
+		if ip, ipn, err := net.ParseCIDR(aip); err == nil {
+			if ones, bits := ipn.Mask.Size(); ones == bits {
+				return ip.String()
+			}
+		}
+	}
+
+	return ""
+}
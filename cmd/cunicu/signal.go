@@ -4,29 +4,70 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net"
+	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
 	osx "cunicu.li/cunicu/pkg/os"
 	grpcx "cunicu.li/cunicu/pkg/signaling/grpc"
+	"cunicu.li/cunicu/pkg/signaling/ws"
 )
 
 type signalOptions struct {
-	listenAddress string
-	secure        bool
+	listenAddress   string
+	wsListenAddress string
+	secure          bool
+	tlsCert         string
+	tlsKey          string
+	peers           []string
+	peerSRVs        []string
+
+	server grpcx.ServerConfig
 }
 
 func init() { //nolint:gochecknoinits
 	opts := &signalOptions{
 		secure: false,
+		server: grpcx.ServerConfig{
+			RetainCount:   grpcx.DefaultRetainCount,
+			RetainTTL:     grpcx.DefaultRetainTTL,
+			RetainSenders: grpcx.DefaultRetainSenders,
+		},
 	}
 	cmd := &cobra.Command{
 		Use:   "signal",
 		Short: "Start gRPC signaling server",
+		Long: `This command starts a gRPC signaling server which relays signaling envelopes between cunicu agents.
+
+Multiple signaling servers can be federated by forwarding published envelopes to each other.
+Other servers can either be configured statically by their gRPC URLs or discovered via DNS SRV records:
+
+- Static peers are provided by the '--peer' option
+  - Example: --peer grpc://signal-eu.example.com:8080?insecure=true
+
+- SRV records are provided by the '--peer-srv' option. The host part is the name of the SRV record.
+  - Example: --peer-srv grpc://_cunicu-signal._tcp.example.com?insecure=true
+
+Each server should be federated with all other servers as forwarded envelopes are not forwarded again.
+Servers recognize forwarded envelopes by a secret shared by all servers of the federation which is provided by the '--federation-secret' option.
+
+Clients behind HTTP proxies which break gRPC streams can use the WebSocket backend instead.
+The '--ws-listen' option starts an additional HTTP endpoint for WebSocket and long-polling clients.
+It shares all envelopes with the gRPC clients of this server.
+`,
+		Example: `$ cunicu signal --peer grpc://signal-us.example.com:8080
+$ cunicu signal --ws-listen :8081
+$ cunicu signal --secure --tls-cert signal.crt --tls-key signal.key --ws-listen :443`,
 		Run: func(cmd *cobra.Command, args []string) {
 			signal(cmd, args, opts)
 		},
@@ -35,7 +76,16 @@ func init() { //nolint:gochecknoinits
 
 	pf := cmd.PersistentFlags()
 	pf.StringVarP(&opts.listenAddress, "listen", "L", ":8080", "listen address")
+	pf.StringVar(&opts.wsListenAddress, "ws-listen", "", "listen address for WebSocket and HTTP long-polling clients (disabled if empty)")
 	pf.BoolVarP(&opts.secure, "secure", "S", false, "listen with TLS")
+	pf.StringVar(&opts.tlsCert, "tls-cert", "", "`file` containing the TLS certificate chain used with --secure")
+	pf.StringVar(&opts.tlsKey, "tls-key", "", "`file` containing the TLS private key used with --secure")
+	pf.IntVar(&opts.server.RetainCount, "retain", opts.server.RetainCount, "number of envelopes retained per sender and recipient and replayed to new subscribers (0 disables retention)")
+	pf.DurationVar(&opts.server.RetainTTL, "retain-ttl", opts.server.RetainTTL, "duration after which retained envelopes expire")
+	pf.IntVar(&opts.server.RetainSenders, "retain-senders", opts.server.RetainSenders, "maximum number of senders per recipient whose envelopes are retained (0 disables the limit)")
+	pf.StringSliceVarP(&opts.peers, "peer", "p", nil, "gRPC `URL` of another signaling server to federate with")
+	pf.StringVar(&opts.server.FederationSecret, "federation-secret", "", "secret shared by all federated servers to authenticate forwarded envelopes")
+	pf.StringSliceVar(&opts.peerSRVs, "peer-srv", nil, "gRPC `URL` whose host name is resolved via DNS SRV records to discover other signaling servers to federate with")
 
 	rootCmd.AddCommand(cmd)
 }
@@ -46,18 +96,79 @@ func signal(_ *cobra.Command, _ []string, opts *signalOptions) {
 		logger.Fatal("Failed to listen", zap.Error(err))
 	}
 
-	// Disable TLS
 	svrOpts := []grpc.ServerOption{}
-	if !opts.secure {
+	if opts.secure {
+		if opts.tlsCert == "" || opts.tlsKey == "" {
+			logger.Fatal("Listening with TLS requires --tls-cert and --tls-key")
+		}
+
+		creds, err := credentials.NewServerTLSFromFile(opts.tlsCert, opts.tlsKey)
+		if err != nil {
+			logger.Fatal("Failed to load TLS certificate", zap.Error(err))
+		}
+
+		svrOpts = append(svrOpts, grpc.Creds(creds))
+	} else {
 		svrOpts = append(svrOpts, grpc.Creds(insecure.NewCredentials()))
 	}
 
-	svr := grpcx.NewSignalingServer(svrOpts...)
+	if opts.server.Peers, err = parseURLs(opts.peers); err != nil {
+		logger.Fatal("Failed to parse peer URLs", zap.Error(err))
+	}
+
+	if opts.server.PeerSRVs, err = parseURLs(opts.peerSRVs); err != nil {
+		logger.Fatal("Failed to parse peer SRV URLs", zap.Error(err))
+	}
+
+	svr, err := grpcx.NewSignalingServer(&opts.server, svrOpts...)
+	if err != nil {
+		logger.Fatal("Failed to create gRPC server", zap.Error(err))
+	}
+
+	var (
+		wsSvr     *http.Server
+		wsHandler *ws.Handler
+	)
+
+	if opts.wsListenAddress != "" {
+		wsHandler = ws.NewHandler(svr)
+		wsSvr = &http.Server{
+			Addr:              opts.wsListenAddress,
+			Handler:           wsHandler,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go func() {
+			logger.Info("Starting WebSocket signaling server", zap.String("address", opts.wsListenAddress), zap.Bool("secure", opts.secure))
+
+			var err error
+			if opts.secure {
+				err = wsSvr.ListenAndServeTLS(opts.tlsCert, opts.tlsKey)
+			} else {
+				err = wsSvr.ListenAndServe()
+			}
+
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Fatal("Failed to start WebSocket server", zap.Error(err))
+			}
+		}()
+	}
 
 	go func() {
 		for sig := range osx.SetupSignals() {
 			logger.Debug("Received signal", zap.Any("signal", sig))
 
+			if wsSvr != nil {
+				// Close the handler first as Shutdown() does not wait for hijacked WebSocket connections
+				if err := wsHandler.Close(); err != nil {
+					logger.Error("Failed to close WebSocket handler", zap.Error(err))
+				}
+
+				if err := wsSvr.Shutdown(context.Background()); err != nil {
+					logger.Error("Failed to close WebSocket server", zap.Error(err))
+				}
+			}
+
 			if err := svr.Close(); err != nil {
 				logger.Error("Failed to close server", zap.Error(err))
 			}
@@ -72,3 +183,18 @@ func signal(_ *cobra.Command, _ []string, opts *signalOptions) {
 
 	logger.Info("Gracefully stopped gRPC signaling server")
 }
+
+func parseURLs(strs []string) ([]url.URL, error) {
+	urls := []url.URL{}
+
+	for _, str := range strs {
+		u, err := url.Parse(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL %s: %w", str, err)
+		}
+
+		urls = append(urls, *u)
+	}
+
+	return urls, nil
+}
@@ -112,6 +112,7 @@ func (c *InterfaceSettings) AgentConfig(ctx context.Context, peer *crypto.Key) (
 		PortMax:            uint16(c.ICE.PortRange.Max), //nolint:gosec
 		CandidateTypes:     c.ICE.CandidateTypes,
 		NetworkTypes:       c.ICE.NetworkTypes,
+		DisableActiveTCP:   !c.ICE.TCP.Enabled,
 	}
 
 	cfg.InterfaceFilter = func(name string) bool {
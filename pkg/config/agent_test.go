@@ -189,6 +189,19 @@ var _ = Describe("Agent config", func() {
 		})
 	})
 
+	DescribeTable("enables active ICE-TCP candidates only if ICE-TCP is enabled",
+		func(cfgStr string, disabled bool) {
+			cfg, err := parseRaw(cfgStr)
+			Expect(err).To(Succeed())
+
+			aCfg, err := cfg.DefaultInterfaceSettings.AgentConfig(context.Background(), &pk)
+			Expect(err).To(Succeed())
+			Expect(aCfg.DisableActiveTCP).To(Equal(disabled))
+		},
+		Entry("default", "ice: { urls: [] }", true),
+		Entry("enabled", "ice: { urls: [], tcp: { enabled: true } }", false),
+	)
+
 	It("can parse multiple backend URLs when passed as individual command line arguments", func() {
 		cfg, err := parseArgs(
 			"--backend", "grpc://server1",
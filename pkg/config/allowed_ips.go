@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"net"
+	"slices"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	netx "cunicu.li/cunicu/pkg/net"
+)
+
+// AllowedIPsRuleSettings permits peers whose tags match the Match selector
+// and whose public key is listed in PublicKeys to claim AllowedIPs within the listed prefixes.
+// An empty list of public keys matches all peers.
+type AllowedIPsRuleSettings struct {
+	Match      map[string]string `koanf:"match,omitempty"`
+	PublicKeys []crypto.Key      `koanf:"public_keys,omitempty"`
+	Prefixes   []net.IPNet       `koanf:"prefixes"`
+}
+
+// AllowedIPsPolicy is a list of rules which decide which AllowedIPs a discovered peer may claim.
+//
+// A prefix is permitted if it is covered by one of the prefixes of any rule
+// which applies to the remote peer.
+// Selectors use the same syntax as the topology policy.
+// If no rule is configured, all prefixes are permitted.
+//
+// Tags are chosen by the remote peers themselves unless they are certified
+// by a community certificate. Rules for untrusted communities should hence match on public keys.
+type AllowedIPsPolicy []AllowedIPsRuleSettings
+
+// Permits returns true if the peer pk carrying the tags theirs may claim the prefix pfx.
+// References in the match selectors are resolved against our own tags ours.
+func (p AllowedIPsPolicy) Permits(pfx net.IPNet, pk crypto.Key, theirs, ours map[string]string) bool {
+	if len(p) == 0 {
+		return true
+	}
+
+	for _, r := range p {
+		if len(r.PublicKeys) > 0 && !slices.Contains(r.PublicKeys, pk) {
+			continue
+		}
+
+		if !matchTags(r.Match, theirs, ours) {
+			continue
+		}
+
+		if slices.ContainsFunc(r.Prefixes, func(allowed net.IPNet) bool {
+			return netx.ContainsNet(&allowed, &pfx)
+		}) {
+			return true
+		}
+	}
+
+	return false
+}
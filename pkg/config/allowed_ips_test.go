@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+
+	"cunicu.li/cunicu/pkg/config"
+	"cunicu.li/cunicu/pkg/crypto"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AllowedIPs policy", func() {
+	mustParseCIDR := func(s string) net.IPNet {
+		_, n, err := net.ParseCIDR(s)
+		Expect(err).To(Succeed())
+
+		return *n
+	}
+
+	mustParseKey := func(s string) crypto.Key {
+		k, err := crypto.ParseKey(s)
+		Expect(err).To(Succeed())
+
+		return k
+	}
+
+	pkGateway := mustParseKey("coNsGPwVPdpahc8U+dbbWGzTAdCd6+1BvPIYg10wDCI=")
+	pkOther := mustParseKey("AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=")
+
+	policy := config.AllowedIPsPolicy{
+		{
+			Prefixes: []net.IPNet{
+				mustParseCIDR("10.237.0.0/16"),
+				mustParseCIDR("fc2f:9a4d::/32"),
+			},
+		},
+		{
+			Match: map[string]string{"role": "gateway"},
+			Prefixes: []net.IPNet{
+				mustParseCIDR("192.168.0.0/16"),
+			},
+		},
+		{
+			Match: map[string]string{"site": "$site"},
+			Prefixes: []net.IPNet{
+				mustParseCIDR("172.16.0.0/12"),
+			},
+		},
+		{
+			PublicKeys: []crypto.Key{pkGateway},
+			Prefixes: []net.IPNet{
+				mustParseCIDR("0.0.0.0/0"),
+			},
+		},
+	}
+
+	ours := map[string]string{"site": "berlin"}
+
+	It("permits all prefixes without rules", func() {
+		Expect(config.AllowedIPsPolicy{}.Permits(mustParseCIDR("0.0.0.0/0"), pkOther, nil, nil)).To(BeTrue())
+	})
+
+	DescribeTable("permits",
+		func(pfx string, theirs map[string]string, permitted bool) {
+			Expect(policy.Permits(mustParseCIDR(pfx), pkOther, theirs, ours)).To(Equal(permitted))
+		},
+		Entry("host address of community", "10.237.1.2/32", nil, true),
+		Entry("IPv6 host address of community", "fc2f:9a4d::1/128", nil, true),
+		Entry("whole community prefix", "10.237.0.0/16", nil, true),
+		Entry("prefix larger than community", "10.0.0.0/8", nil, false),
+		Entry("default route", "0.0.0.0/0", nil, false),
+		Entry("IPv6 default route", "::/0", nil, false),
+		Entry("LAN of gateway", "192.168.1.0/24", map[string]string{"role": "gateway"}, true),
+		Entry("LAN of non-gateway", "192.168.1.0/24", map[string]string{"role": "spoke"}, false),
+		Entry("prefix of same site", "172.16.1.0/24", map[string]string{"site": "berlin"}, true),
+		Entry("prefix of other site", "172.16.1.0/24", map[string]string{"site": "paris"}, false),
+	)
+
+	It("permits prefixes to listed public keys", func() {
+		Expect(policy.Permits(mustParseCIDR("0.0.0.0/0"), pkGateway, nil, ours)).To(BeTrue())
+		Expect(policy.Permits(mustParseCIDR("10.237.1.2/32"), pkGateway, nil, ours)).To(BeTrue())
+	})
+
+	It("can be loaded from a configuration file", func() {
+		fn := filepath.Join(GinkgoT().TempDir(), "cunicu.yaml")
+		err := os.WriteFile(fn, []byte(`---
+allowed_ips_policy:
+- prefixes:
+  - 10.237.0.0/16
+  - fc2f:9a4d::/32
+- match:
+    role: gateway
+  prefixes:
+  - 192.168.0.0/16
+- match:
+    site: $site
+  prefixes:
+  - 172.16.0.0/12
+- public_keys:
+  - coNsGPwVPdpahc8U+dbbWGzTAdCd6+1BvPIYg10wDCI=
+  prefixes:
+  - 0.0.0.0/0
+`), 0o600)
+		Expect(err).To(Succeed())
+
+		cfg, err := parseArgs("--config", fn)
+		Expect(err).To(Succeed())
+
+		loaded := cfg.DefaultInterfaceSettings.AllowedIPsPolicy
+		Expect(loaded).To(HaveLen(len(policy)))
+
+		for i, r := range policy {
+			Expect(loaded[i].Match).To(Equal(r.Match))
+			Expect(loaded[i].PublicKeys).To(Equal(r.PublicKeys))
+			Expect(loaded[i].Prefixes).To(HaveLen(len(r.Prefixes)))
+
+			for j, pfx := range r.Prefixes {
+				Expect(loaded[i].Prefixes[j].String()).To(Equal(pfx.String()))
+			}
+		}
+	})
+})
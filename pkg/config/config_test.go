@@ -393,6 +393,39 @@ var _ = Context("config", func() {
 			Expect(cfg.DefaultInterfaceSettings.ListenPortRange).To(Equal(orig), "Failed update has changed settings")
 		})
 
+		It("fails to enable key rotation with a static private key", func() {
+			cfg, err := parseArgs()
+			Expect(err).To(Succeed())
+
+			_, err = cfg.Update(map[string]any{
+				"private_key":           "GMHOtIxfUrGmncORJ5ssBXYw2HmTx2WhGAaZRm4ZOUk=",
+				"key_rotation_interval": "24h",
+			})
+			Expect(err).To(MatchError("invalid settings: key rotation can not be used with a static private key"))
+		})
+
+		It("fails to enable key rotation with community certificates", func() {
+			cfg, err := parseArgs()
+			Expect(err).To(Succeed())
+
+			_, err = cfg.Update(map[string]any{
+				"community_certificate": "/etc/cunicu/community.crt",
+				"key_rotation_interval": "24h",
+			})
+			Expect(err).To(MatchError("invalid settings: key rotation can not be used with community certificates"))
+		})
+
+		It("fails to set a zero NAT discovery interval", func() {
+			cfg, err := parseArgs()
+			Expect(err).To(Succeed())
+
+			_, err = cfg.Update(map[string]any{
+				"ice.nat_discovery":          true,
+				"ice.nat_discovery_interval": "0s",
+			})
+			Expect(err).To(MatchError("invalid settings: NAT discovery interval must be positive"))
+		})
+
 		It("can save runtime settings", func() {
 			cfg, err := parseArgs()
 			Expect(err).To(Succeed())
@@ -22,6 +22,9 @@ const (
 	// Ephemeral Port Range (RFC6056 Sect. 2.1).
 	EphemeralPortMin = (1 << 15) + (1 << 14)
 	EphemeralPortMax = (1 << 16) - 1
+
+	DefaultPeerRelayListen = ":3479"
+	DefaultICETCPPort      = wg.DefaultPort
 )
 
 //nolint:gochecknoglobals
@@ -61,6 +64,10 @@ var (
 			WatchRoutes:       true,
 
 			PortForwarding: true,
+			PortMapping: PortMappingSettings{
+				Protocols: []string{"pcp", "nat-pmp", "upnp"},
+				Lifetime:  2 * time.Hour,
+			},
 
 			ICE: ICESettings{
 				URLs:                DefaultICEURLs,
@@ -76,6 +83,18 @@ var (
 					Min: EphemeralPortMin,
 					Max: EphemeralPortMax,
 				},
+				TCP: ICETCPSettings{
+					Port:            DefaultICETCPPort,
+					ServerReflexive: true,
+				},
+				NATDiscovery:         true,
+				NATDiscoveryInterval: 10 * time.Minute,
+				PeerRelay: PeerRelaySettings{
+					Listen:     DefaultPeerRelayListen,
+					UserQuota:  4,
+					TotalQuota: 32,
+					Bandwidth:  1 << 20,
+				},
 				CandidateTypes: []ice.CandidateType{
 					ice.CandidateTypeHost,
 					ice.CandidateTypeServerReflexive,
@@ -90,8 +109,15 @@ var (
 				},
 			},
 
+			PeerTTL:      5 * time.Minute,
+			PeerTTLGrace: 1 * time.Minute,
+
 			RoutingTable: DefaultRouteTable,
 
+			ServicesFile: DefaultServicesFile,
+
+			RekeyInterval: 10 * time.Minute,
+
 			ListenPortRange: &PortRangeSettings{
 				Min: wg.DefaultPort,
 				Max: EphemeralPortMax,
@@ -5,7 +5,13 @@
 
 package config
 
-const DefaultSocketPath = "cunicu.sock"
+const (
+	DefaultSocketPath   = "cunicu.sock"
+	DefaultServicesFile = "services.zone"
+)
 
 //nolint:gochecknoglobals
 var RuntimeConfigFile = "runtime.yaml"
+
+//nolint:gochecknoglobals
+var RevocationsFile = "revocations.pem"
@@ -5,7 +5,13 @@
 
 package config
 
-const DefaultSocketPath = "/run/cunicu.sock"
+const (
+	DefaultSocketPath   = "/run/cunicu.sock"
+	DefaultServicesFile = "/var/lib/cunicu/services.zone"
+)
 
 //nolint:gochecknoglobals
 var RuntimeConfigFile = "/var/lib/cunicu/runtime.yaml"
+
+//nolint:gochecknoglobals
+var RevocationsFile = "/var/lib/cunicu/revocations.pem"
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
+
+	"cunicu.li/cunicu/pkg/crypto"
+)
+
+var errInvalidPeerSelector = errors.New("invalid peer selector")
+
+// PeerSelector matches peers either by their public key,
+// a glob(7) pattern for their advertised hostname or
+// an address prefix overlapping with one of their AllowedIPs.
+type PeerSelector struct {
+	PublicKey crypto.Key
+	Prefix    *net.IPNet
+	Hostname  string
+}
+
+// ParsePeerSelector parses a public key, a prefix in CIDR notation or a hostname pattern.
+func ParsePeerSelector(str string) (PeerSelector, error) {
+	if pk, err := crypto.ParseKey(str); err == nil {
+		return PeerSelector{PublicKey: pk}, nil
+	}
+
+	if _, pfx, err := net.ParseCIDR(str); err == nil {
+		return PeerSelector{Prefix: pfx}, nil
+	}
+
+	if str == "" {
+		return PeerSelector{}, fmt.Errorf("%w: empty", errInvalidPeerSelector)
+	}
+
+	if _, err := filepath.Match(str, ""); err != nil {
+		return PeerSelector{}, fmt.Errorf("%w: %w", errInvalidPeerSelector, err)
+	}
+
+	return PeerSelector{Hostname: str}, nil
+}
+
+func (s PeerSelector) String() string {
+	switch {
+	case s.PublicKey.IsSet():
+		return s.PublicKey.String()
+	case s.Prefix != nil:
+		return s.Prefix.String()
+	default:
+		return s.Hostname
+	}
+}
+
+func (s PeerSelector) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *PeerSelector) UnmarshalText(text []byte) (err error) {
+	*s, err = ParsePeerSelector(string(text))
+
+	return err
+}
+
+// Matches returns true if the peer is selected.
+func (s PeerSelector) Matches(pk crypto.Key, hostname string, allowedIPs []net.IPNet) bool {
+	switch {
+	case s.PublicKey.IsSet():
+		return s.PublicKey == pk
+
+	case s.Prefix != nil:
+		return slices.ContainsFunc(allowedIPs, func(aip net.IPNet) bool {
+			return s.Prefix.Contains(aip.IP) || aip.Contains(s.Prefix.IP)
+		})
+
+	default:
+		matched, err := filepath.Match(s.Hostname, hostname)
+
+		return err == nil && matched && hostname != ""
+	}
+}
+
+// PeerSelectors is a list of peer selectors.
+type PeerSelectors []PeerSelector
+
+// Matches returns true if any of the selectors matches the peer.
+func (ss PeerSelectors) Matches(pk crypto.Key, hostname string, allowedIPs []net.IPNet) bool {
+	return slices.ContainsFunc(ss, func(s PeerSelector) bool {
+		return s.Matches(pk, hostname, allowedIPs)
+	})
+}
+
+// Strings returns the textual representations of the selectors.
+func (ss PeerSelectors) Strings() []string {
+	strs := []string{}
+	for _, s := range ss {
+		strs = append(strs, s.String())
+	}
+
+	return strs
+}
+
+// Add returns the selectors including s.
+func (ss PeerSelectors) Add(s PeerSelector) PeerSelectors {
+	if slices.ContainsFunc(ss, func(t PeerSelector) bool {
+		return t.String() == s.String()
+	}) {
+		return ss
+	}
+
+	return append(slices.Clone(ss), s)
+}
+
+// Remove returns the selectors excluding s.
+func (ss PeerSelectors) Remove(s PeerSelector) PeerSelectors {
+	return slices.DeleteFunc(slices.Clone(ss), func(t PeerSelector) bool {
+		return t.String() == s.String()
+	})
+}
+
+// PeerFilter decides which peers are accepted based on a whitelist and blacklist.
+type PeerFilter struct {
+	Whitelist PeerSelectors
+	Blacklist PeerSelectors
+}
+
+// Accepts checks whether a peer is accepted by the whitelist and blacklist.
+// Blacklisted peers are always rejected.
+// If a whitelist is configured, only whitelisted peers are accepted.
+func (f PeerFilter) Accepts(pk crypto.Key, hostname string, allowedIPs []net.IPNet) bool {
+	if f.Blacklist.Matches(pk, hostname, allowedIPs) {
+		return false
+	}
+
+	return len(f.Whitelist) == 0 || f.Whitelist.Matches(pk, hostname, allowedIPs)
+}
+
+// PeerFilter returns the whitelist and blacklist of the interface.
+func (c *InterfaceSettings) PeerFilter() PeerFilter {
+	return PeerFilter{
+		Whitelist: c.Whitelist,
+		Blacklist: c.Blacklist,
+	}
+}
+
+// Allow returns a filter which accepts peers matched by the selector.
+func (f PeerFilter) Allow(s PeerSelector) PeerFilter {
+	return PeerFilter{
+		Whitelist: f.Whitelist.Add(s),
+		Blacklist: f.Blacklist.Remove(s),
+	}
+}
+
+// Deny returns a filter which rejects peers matched by the selector.
+func (f PeerFilter) Deny(s PeerSelector) PeerFilter {
+	return PeerFilter{
+		Whitelist: f.Whitelist.Remove(s),
+		Blacklist: f.Blacklist.Add(s),
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"net"
+	"path/filepath"
+
+	"cunicu.li/cunicu/pkg/config"
+	"cunicu.li/cunicu/pkg/crypto"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("peer filter", func() {
+	var pk crypto.Key
+
+	mustParse := func(str string) config.PeerSelector {
+		s, err := config.ParsePeerSelector(str)
+		Expect(err).To(Succeed())
+
+		return s
+	}
+
+	allowedIPs := []net.IPNet{
+		{IP: net.ParseIP("10.1.2.3"), Mask: net.CIDRMask(32, 32)},
+		{IP: net.ParseIP("192.168.0.0"), Mask: net.CIDRMask(24, 32)},
+	}
+
+	BeforeEach(func() {
+		sk, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		pk = sk.PublicKey()
+	})
+
+	It("parses public keys", func() {
+		s := mustParse(pk.String())
+		Expect(s.PublicKey).To(Equal(pk))
+		Expect(s.String()).To(Equal(pk.String()))
+		Expect(s.Matches(pk, "", nil)).To(BeTrue())
+		Expect(s.Matches(crypto.Key{}, "", nil)).To(BeFalse())
+	})
+
+	DescribeTable("matches prefixes",
+		func(pfx string, matches bool) {
+			s := mustParse(pfx)
+			Expect(s.Prefix).NotTo(BeNil())
+			Expect(s.Matches(pk, "", allowedIPs)).To(Equal(matches))
+		},
+		Entry("containing", "10.0.0.0/8", true),
+		Entry("contained", "192.168.0.128/25", true),
+		Entry("disjoint", "172.16.0.0/12", false),
+		Entry("other family", "fc2f::/16", false),
+	)
+
+	DescribeTable("matches hostname patterns",
+		func(pattern, hostname string, matches bool) {
+			s := mustParse(pattern)
+			Expect(s.Hostname).To(Equal(pattern))
+			Expect(s.Matches(pk, hostname, nil)).To(Equal(matches))
+		},
+		Entry("literal", "node-1", "node-1", true),
+		Entry("glob", "node-*", "node-1", true),
+		Entry("character class", "node-[0-9]", "node-a", false),
+		Entry("missing hostname", "*", "", false),
+	)
+
+	It("rejects invalid patterns", func() {
+		_, err := config.ParsePeerSelector("node-[")
+		Expect(err).To(MatchError(HavePrefix("invalid peer selector")))
+	})
+
+	It("rejects blacklisted peers", func() {
+		f := config.PeerFilter{}
+		Expect(f.Accepts(pk, "node-1", allowedIPs)).To(BeTrue())
+
+		f = f.Deny(mustParse("node-*"))
+		Expect(f.Accepts(pk, "node-1", allowedIPs)).To(BeFalse())
+		Expect(f.Accepts(pk, "other", allowedIPs)).To(BeTrue())
+	})
+
+	It("only accepts whitelisted peers if a whitelist is configured", func() {
+		f := config.PeerFilter{}.Allow(mustParse("10.0.0.0/8"))
+		Expect(f.Accepts(pk, "", allowedIPs)).To(BeTrue())
+		Expect(f.Accepts(pk, "", nil)).To(BeFalse())
+	})
+
+	It("moves selectors between the lists", func() {
+		s := mustParse("node-*")
+
+		f := config.PeerFilter{}.Allow(s).Allow(s)
+		Expect(f.Whitelist).To(HaveLen(1))
+		Expect(f.Blacklist).To(BeEmpty())
+
+		f = f.Deny(s)
+		Expect(f.Whitelist).To(BeEmpty())
+		Expect(f.Blacklist).To(HaveLen(1))
+	})
+
+	It("can be updated at runtime", func() {
+		config.RuntimeConfigFile = filepath.Join(GinkgoT().TempDir(), "cunicu.runtime.yaml")
+
+		cfg, err := parseArgs()
+		Expect(err).To(Succeed())
+
+		_, err = cfg.Update(map[string]any{
+			"whitelist":                []string{"10.0.0.0/8"},
+			"interfaces.wg0.blacklist": []string{pk.String(), "node-*"},
+		})
+		Expect(err).To(Succeed())
+
+		Expect(cfg.DefaultInterfaceSettings.Whitelist.Strings()).To(Equal([]string{"10.0.0.0/8"}))
+
+		f := cfg.InterfaceSettings("wg0").PeerFilter()
+		Expect(f.Whitelist.Strings()).To(Equal([]string{"10.0.0.0/8"}))
+		Expect(f.Blacklist.Strings()).To(Equal([]string{pk.String(), "node-*"}))
+	})
+})
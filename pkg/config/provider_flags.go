@@ -59,11 +59,12 @@ func (c *Config) flagProvider() koanf.Provider {
 	// Map flags from the flags to Koanf settings
 	flagMap := map[string]string{
 		// Feature flags
-		"discover-peers":     "discover_peers",
-		"discover-endpoints": "discover_endpoints",
-		"sync-config":        "sync_config",
-		"sync-hosts":         "sync_hosts",
-		"sync-routes":        "sync_routes",
+		"discover-peers":           "discover_peers",
+		"discover-endpoints":       "discover_endpoints",
+		"establish-preshared-keys": "establish_preshared_keys",
+		"sync-config":              "sync_config",
+		"sync-hosts":               "sync_hosts",
+		"sync-routes":              "sync_routes",
 
 		"backend":        "backends",
 		"watch-interval": "watch_interval",
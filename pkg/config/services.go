@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+)
+
+// serviceNameRegex matches DNS SRV service names like "_ssh._tcp".
+// See RFC 6335 Section 5.1 for the syntax of service names.
+var serviceNameRegex = regexp.MustCompile(`^_[a-z0-9]([a-z0-9-]{0,13}[a-z0-9])?\._(tcp|udp)$`)
+
+// ServiceSettings describes a service offered by this peer which is advertised via peer discovery.
+type ServiceSettings struct {
+	Name     string `koanf:"name"`
+	Port     int    `koanf:"port"`
+	Priority int    `koanf:"priority,omitempty"`
+	Weight   int    `koanf:"weight,omitempty"`
+}
+
+// Check validates the service against the constraints of DNS SRV records.
+func (s *ServiceSettings) Check() error {
+	if !serviceNameRegex.MatchString(s.Name) {
+		return fmt.Errorf("%w: invalid service name '%s'", errInvalidSettings, s.Name)
+	}
+
+	if s.Port < 1 || s.Port > math.MaxUint16 {
+		return fmt.Errorf("%w: invalid port %d of service '%s'", errInvalidSettings, s.Port, s.Name)
+	}
+
+	if s.Priority < 0 || s.Priority > math.MaxUint16 || s.Weight < 0 || s.Weight > math.MaxUint16 {
+		return fmt.Errorf("%w: priority and weight of service '%s' must be between 0 and %d", errInvalidSettings, s.Name, math.MaxUint16)
+	}
+
+	return nil
+}
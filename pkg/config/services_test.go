@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"cunicu.li/cunicu/pkg/config"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("services", func() {
+	DescribeTable("check",
+		func(s config.ServiceSettings, valid bool) {
+			if valid {
+				Expect(s.Check()).To(Succeed())
+			} else {
+				Expect(s.Check()).To(MatchError(HavePrefix("invalid settings")))
+			}
+		},
+		Entry("ssh", config.ServiceSettings{Name: "_ssh._tcp", Port: 22}, true),
+		Entry("prometheus", config.ServiceSettings{Name: "_prometheus._tcp", Port: 9100, Priority: 10, Weight: 5}, true),
+		Entry("udp", config.ServiceSettings{Name: "_sip._udp", Port: 5060}, true),
+		Entry("missing underscore", config.ServiceSettings{Name: "ssh._tcp", Port: 22}, false),
+		Entry("invalid protocol", config.ServiceSettings{Name: "_ssh._sctp", Port: 22}, false),
+		Entry("name too long", config.ServiceSettings{Name: "_averylongservicename._tcp", Port: 22}, false),
+		Entry("missing port", config.ServiceSettings{Name: "_ssh._tcp"}, false),
+		Entry("port out of range", config.ServiceSettings{Name: "_ssh._tcp", Port: 65536}, false),
+		Entry("negative priority", config.ServiceSettings{Name: "_ssh._tcp", Port: 22, Priority: -1}, false),
+	)
+
+	It("can be loaded from a configuration file", func() {
+		fn := filepath.Join(GinkgoT().TempDir(), "cunicu.yaml")
+		err := os.WriteFile(fn, []byte(`---
+services:
+- name: _ssh._tcp
+  port: 22
+- name: _prometheus._tcp
+  port: 9100
+  priority: 10
+`), 0o600)
+		Expect(err).To(Succeed())
+
+		cfg, err := parseArgs("--config", fn)
+		Expect(err).To(Succeed())
+		Expect(cfg.DefaultInterfaceSettings.Services).To(Equal([]config.ServiceSettings{
+			{Name: "_ssh._tcp", Port: 22},
+			{Name: "_prometheus._tcp", Port: 9100, Priority: 10},
+		}))
+	})
+
+	It("rejects invalid services", func() {
+		config.RuntimeConfigFile = filepath.Join(GinkgoT().TempDir(), "cunicu.runtime.yaml")
+
+		cfg, err := parseArgs()
+		Expect(err).To(Succeed())
+
+		_, err = cfg.Update(map[string]any{
+			"services": []map[string]any{
+				{"name": "ssh", "port": 22},
+			},
+		})
+		Expect(err).To(MatchError("invalid settings: invalid service name 'ssh'"))
+	})
+})
@@ -22,6 +22,38 @@ type PortRangeSettings struct {
 	Max int `koanf:"max,omitempty"`
 }
 
+// PeerRelaySettings configures a TURN server which relays traffic for other members of the community.
+type PeerRelaySettings struct {
+	Enabled bool   `koanf:"enabled,omitempty"`
+	Listen  string `koanf:"listen,omitempty"`
+
+	// Public IP address which is advertised to other peers
+	Address net.IP `koanf:"address,omitempty"`
+
+	UserQuota  int `koanf:"user_quota,omitempty"`
+	TotalQuota int `koanf:"total_quota,omitempty"`
+	Bandwidth  int `koanf:"bandwidth,omitempty"`
+}
+
+// PortMappingSettings configures the mapping of the host UDP mux port by the local router.
+type PortMappingSettings struct {
+	Enabled   bool          `koanf:"enabled,omitempty"`
+	Protocols []string      `koanf:"protocols,omitempty"`
+	Gateway   net.IP        `koanf:"gateway,omitempty"`
+	Lifetime  time.Duration `koanf:"lifetime,omitempty"`
+}
+
+// ICETCPSettings configures direct connectivity over TCP via ICE-TCP candidates (RFC 6544).
+type ICETCPSettings struct {
+	Enabled bool `koanf:"enabled,omitempty"`
+
+	// Port of the listener which accepts connections for passive candidates
+	Port int `koanf:"port,omitempty"`
+
+	// ServerReflexive enables the discovery of passive server reflexive candidates via STUN over TCP
+	ServerReflexive bool `koanf:"server_reflexive,omitempty"`
+}
+
 type ICESettings struct {
 	URLs           []url.URL           `koanf:"urls,omitempty"`
 	CandidateTypes []ice.CandidateType `koanf:"candidate_types,omitempty"`
@@ -31,8 +63,16 @@ type ICESettings struct {
 	RelayTCP *bool `koanf:"relay_tcp,omitempty"`
 	RelayTLS *bool `koanf:"relay_tls,omitempty"`
 
+	TCP ICETCPSettings `koanf:"tcp,omitempty"`
+
 	PortRange PortRangeSettings `koanf:"port_range,omitempty"`
 
+	PeerRelay PeerRelaySettings `koanf:"peer_relay,omitempty"`
+
+	// NATDiscovery enables the classification of the NAT behavior via RFC 5780 STUN tests
+	NATDiscovery         bool          `koanf:"nat_discovery,omitempty"`
+	NATDiscoveryInterval time.Duration `koanf:"nat_discovery_interval,omitempty"`
+
 	Lite               bool `koanf:"lite,omitempty"`
 	MDNS               bool `koanf:"mdns,omitempty"`
 	MaxBindingRequests int  `koanf:"max_binding_requests,omitempty"`
@@ -122,7 +162,8 @@ type InterfaceSettings struct {
 	HostName string `koanf:"hostname,omitempty"`
 	Domain   string `koanf:"domain,omitempty"`
 
-	ExtraHosts map[string][]net.IPAddr `koanf:"extra_hosts,omitempty"`
+	ExtraHosts   map[string][]net.IPAddr `koanf:"extra_hosts,omitempty"`
+	ServicesFile string                  `koanf:"services_file,omitempty"`
 
 	MTU       int          `koanf:"mtu,omitempty"`
 	DNS       []net.IPAddr `koanf:"dns,omitempty"`
@@ -131,34 +172,51 @@ type InterfaceSettings struct {
 	Networks  []net.IPNet  `koanf:"networks,omitempty"`
 
 	// Peer discovery
-	Community crypto.KeyPassphrase `koanf:"community,omitempty"`
-	Whitelist []crypto.Key         `koanf:"whitelist,omitempty"`
-	Blacklist []crypto.Key         `koanf:"blacklist,omitempty"`
+	Community            crypto.KeyPassphrase `koanf:"community,omitempty"`
+	CommunityCA          []crypto.Key         `koanf:"community_ca,omitempty"`
+	CommunityCertificate string               `koanf:"community_certificate,omitempty"`
+	Whitelist            PeerSelectors        `koanf:"whitelist,omitempty"`
+	Blacklist            PeerSelectors        `koanf:"blacklist,omitempty"`
+	PeerTTL              time.Duration        `koanf:"peer_ttl,omitempty"`
+	PeerTTLGrace         time.Duration        `koanf:"peer_ttl_grace,omitempty"`
+	Tags                 map[string]string    `koanf:"tags,omitempty"`
+	Topology             Topology             `koanf:"topology,omitempty"`
+	AllowedIPsPolicy     AllowedIPsPolicy     `koanf:"allowed_ips_policy,omitempty"`
+	MultiHopRouting      bool                 `koanf:"multi_hop_routing,omitempty"`
+	ExitNode             bool                 `koanf:"exit_node,omitempty"`
+	UseExitNode          string               `koanf:"use_exit_node,omitempty"`
+	Services             []ServiceSettings    `koanf:"services,omitempty"`
 
 	// Endpoint discovery
-	ICE            ICESettings `koanf:"ice,omitempty"`
-	PortForwarding bool        `koanf:"port_forwarding,omitempty"`
+	ICE            ICESettings         `koanf:"ice,omitempty"`
+	PortForwarding bool                `koanf:"port_forwarding,omitempty"`
+	PortMapping    PortMappingSettings `koanf:"port_mapping,omitempty"`
 
 	// Route sync
 	RoutingTable int `koanf:"routing_table,omitempty"`
 
+	// Pre-shared key establishment
+	RekeyInterval time.Duration `koanf:"rekey_interval,omitempty"`
+
 	// Hooks
 	Hooks []HookSetting `koanf:"hooks,omitempty"`
 
 	// WireGuard
-	UserSpace       bool                    `koanf:"userspace,omitempty"`
-	PrivateKey      crypto.Key              `koanf:"private_key,omitempty"`
-	ListenPort      *int                    `koanf:"listen_port,omitempty"`
-	ListenPortRange *PortRangeSettings      `koanf:"listen_port_range,omitempty"`
-	FirewallMark    int                     `koanf:"fwmark,omitempty"`
-	Peers           map[string]PeerSettings `koanf:"peers,omitempty"`
+	UserSpace           bool                    `koanf:"userspace,omitempty"`
+	PrivateKey          crypto.Key              `koanf:"private_key,omitempty"`
+	KeyRotationInterval time.Duration           `koanf:"key_rotation_interval,omitempty"`
+	ListenPort          *int                    `koanf:"listen_port,omitempty"`
+	ListenPortRange     *PortRangeSettings      `koanf:"listen_port_range,omitempty"`
+	FirewallMark        int                     `koanf:"fwmark,omitempty"`
+	Peers               map[string]PeerSettings `koanf:"peers,omitempty"`
 
 	// Feature flags
-	DiscoverEndpoints bool `koanf:"discover_endpoints,omitempty"`
-	DiscoverPeers     bool `koanf:"discover_peers,omitempty"`
-	SyncConfig        bool `koanf:"sync_config,omitempty"`
-	SyncRoutes        bool `koanf:"sync_routes,omitempty"`
-	SyncHosts         bool `koanf:"sync_hosts,omitempty"`
+	DiscoverEndpoints      bool `koanf:"discover_endpoints,omitempty"`
+	DiscoverPeers          bool `koanf:"discover_peers,omitempty"`
+	EstablishPresharedKeys bool `koanf:"establish_preshared_keys,omitempty"`
+	SyncConfig             bool `koanf:"sync_config,omitempty"`
+	SyncRoutes             bool `koanf:"sync_routes,omitempty"`
+	SyncHosts              bool `koanf:"sync_hosts,omitempty"`
 
 	WatchConfig bool `koanf:"watch_config,omitempty"`
 	WatchRoutes bool `koanf:"watch_routes,omitempty"`
@@ -201,5 +259,31 @@ func (c *InterfaceSettings) Check() error {
 		)
 	}
 
+	if c.KeyRotationInterval > 0 && c.PrivateKey.IsSet() {
+		return fmt.Errorf("%w: key rotation can not be used with a static private key", errInvalidSettings)
+	}
+
+	if c.KeyRotationInterval > 0 && (len(c.CommunityCA) > 0 || c.CommunityCertificate != "") {
+		return fmt.Errorf("%w: key rotation can not be used with community certificates", errInvalidSettings)
+	}
+
+	if err := c.ICE.Check(); err != nil {
+		return err
+	}
+
+	for _, s := range c.Services {
+		if err := s.Check(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ICESettings) Check() error {
+	if c.NATDiscovery && c.NATDiscoveryInterval <= 0 {
+		return fmt.Errorf("%w: NAT discovery interval must be positive", errInvalidSettings)
+	}
+
 	return nil
 }
@@ -37,6 +37,13 @@ func (s *runtimeSource) Config() *koanf.Koanf {
 	return s.Koanf
 }
 
+// Order returns the interface sections of the runtime configuration.
+// In contrast to the file provider, the order also includes sections
+// which have been added by Update() but not yet been loaded from disk.
+func (s *runtimeSource) Order() []string {
+	return s.Koanf.MapKeys("interfaces")
+}
+
 func (s *runtimeSource) Load() error {
 	parser := yaml.Parser()
 	if err := s.Koanf.Load(s.LocalFileProvider, parser); err != nil {
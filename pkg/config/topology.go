@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import "strings"
+
+// TopologyRuleSettings describes which peers are accepted by peer discovery.
+// The rule applies to us if our own tags match the Match selector.
+type TopologyRuleSettings struct {
+	Match  map[string]string   `koanf:"match,omitempty"`
+	Accept []map[string]string `koanf:"accept,omitempty"`
+}
+
+// Topology is an ordered list of rules which decide which discovered peers are accepted.
+//
+// The first rule whose match selector matches our own tags applies.
+// A peer is accepted if its tags match any of the accept selectors of this rule.
+// If no rule applies, all peers are accepted.
+//
+// Selector values can be:
+//   - a literal value which must be equal to the value of the tag
+//   - "*" which matches any value as long as the tag is present
+//   - "$name" which must be equal to the value of our own tag "name"
+type Topology []TopologyRuleSettings
+
+// Accepts returns true if a peer carrying the tags theirs is accepted by a node carrying the tags ours.
+func (t Topology) Accepts(ours, theirs map[string]string) bool {
+	for _, r := range t {
+		if !matchTags(r.Match, ours, ours) {
+			continue
+		}
+
+		for _, sel := range r.Accept {
+			if matchTags(sel, theirs, ours) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// matchTags checks if the selector sel matches the tags.
+// References to tags of our own are resolved against ours.
+func matchTags(sel, tags, ours map[string]string) bool {
+	for name, want := range sel {
+		have, ok := tags[name]
+		if !ok {
+			return false
+		}
+
+		switch {
+		case want == "*":
+		case strings.HasPrefix(want, "$"):
+			if ref, ok := ours[want[1:]]; !ok || ref != have {
+				return false
+			}
+		case want != have:
+			return false
+		}
+	}
+
+	return true
+}
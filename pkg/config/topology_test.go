@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"cunicu.li/cunicu/pkg/config"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("topology", func() {
+	hubAndSpoke := config.Topology{
+		{
+			Match:  map[string]string{"role": "spoke"},
+			Accept: []map[string]string{{"role": "hub"}},
+		},
+	}
+
+	perSite := config.Topology{
+		{
+			Match: map[string]string{"site": "*"},
+			Accept: []map[string]string{
+				{"site": "$site"},
+				{"role": "gateway"},
+			},
+		},
+	}
+
+	It("accepts all peers without rules", func() {
+		Expect(config.Topology{}.Accepts(nil, nil)).To(BeTrue())
+	})
+
+	DescribeTable("hub-and-spoke",
+		func(ours, theirs map[string]string, accepted bool) {
+			Expect(hubAndSpoke.Accepts(ours, theirs)).To(Equal(accepted))
+		},
+		Entry("spoke accepts hub", map[string]string{"role": "spoke"}, map[string]string{"role": "hub"}, true),
+		Entry("spoke rejects spoke", map[string]string{"role": "spoke"}, map[string]string{"role": "spoke"}, false),
+		Entry("spoke rejects untagged peer", map[string]string{"role": "spoke"}, nil, false),
+		Entry("hub accepts spoke", map[string]string{"role": "hub"}, map[string]string{"role": "spoke"}, true),
+	)
+
+	DescribeTable("partial mesh per site",
+		func(ours, theirs map[string]string, accepted bool) {
+			Expect(perSite.Accepts(ours, theirs)).To(Equal(accepted))
+		},
+		Entry("same site", map[string]string{"site": "berlin"}, map[string]string{"site": "berlin"}, true),
+		Entry("other site", map[string]string{"site": "berlin"}, map[string]string{"site": "paris"}, false),
+		Entry("gateway of other site", map[string]string{"site": "berlin"}, map[string]string{"site": "paris", "role": "gateway"}, true),
+		Entry("node without site", nil, map[string]string{"site": "paris"}, true),
+	)
+
+	It("can be loaded from a configuration file", func() {
+		fn := filepath.Join(GinkgoT().TempDir(), "cunicu.yaml")
+		err := os.WriteFile(fn, []byte(`---
+tags:
+  role: spoke
+
+topology:
+- match:
+    role: spoke
+  accept:
+  - role: hub
+`), 0o600)
+		Expect(err).To(Succeed())
+
+		cfg, err := parseArgs("--config", fn)
+		Expect(err).To(Succeed())
+
+		icfg := cfg.DefaultInterfaceSettings
+		Expect(icfg.Tags).To(Equal(map[string]string{"role": "spoke"}))
+		Expect(icfg.Topology).To(Equal(hubAndSpoke))
+	})
+})
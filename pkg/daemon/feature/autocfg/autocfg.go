@@ -10,6 +10,7 @@ import (
 	"math"
 	"net"
 	"syscall"
+	"time"
 
 	"cunicu.li/cunicu/pkg/crypto"
 	"cunicu.li/cunicu/pkg/daemon"
@@ -28,12 +29,15 @@ var Get = daemon.RegisterFeature(New, 10) //nolint:gochecknoglobals
 type Interface struct {
 	*daemon.Interface
 
+	stop chan struct{}
+
 	logger *log.Logger
 }
 
 func New(i *daemon.Interface) (*Interface, error) {
 	a := &Interface{
 		Interface: i,
+		stop:      make(chan struct{}),
 		logger:    log.Global.Named("autocfg").With(zap.String("intf", i.Name())),
 	}
 
@@ -76,13 +80,54 @@ func (i *Interface) Start() error {
 		i.logger.Error("Failed to bring link up", zap.Error(err))
 	}
 
+	if ri := i.Settings.KeyRotationInterval; ri > 0 {
+		go i.rotatePrivateKeys(ri)
+	}
+
 	return nil
 }
 
 func (i *Interface) Close() error {
+	close(i.stop)
+
 	return nil
 }
 
+func (i *Interface) rotatePrivateKeys(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-i.stop:
+			return
+
+		case <-ticker.C:
+			if err := i.RotatePrivateKey(); err != nil {
+				i.logger.Error("Failed to rotate private key", zap.Error(err))
+			}
+		}
+	}
+}
+
+// RotatePrivateKey replaces the private key of the interface by a newly generated one.
+// The other features migrate to the new key in their handlers for the modified interface.
+// E.g. peer discovery announces the new public key to the remote peers.
+func (i *Interface) RotatePrivateKey() error {
+	sk, err := crypto.GeneratePrivateKey()
+	if err != nil {
+		return fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	i.logger.Info("Rotating private key",
+		zap.Any("old", i.PublicKey()),
+		zap.Any("new", sk.PublicKey()))
+
+	return i.ConfigureDevice(wgtypes.Config{
+		PrivateKey: (*wgtypes.Key)(&sk),
+	})
+}
+
 // ConfigureWireGuard configures the WireGuard device using the configuration provided by the user.
 // Missing settings such as a private key or listen port are automatically generated/allocated.
 func (i *Interface) ConfigureWireGuard() error {
@@ -16,20 +16,22 @@ import (
 
 func (i *Interface) OnInterfaceModified(ci *daemon.Interface, old *wg.Interface, mod daemon.InterfaceModifier) {
 	// Update addresses in case the interface key has changed
+	// The new addresses are added before the old ones get removed
+	// so that the interface remains addressable throughout a key rotation.
 	if mod&daemon.InterfaceModifiedPrivateKey != 0 {
-		if oldSk := crypto.Key(old.PrivateKey); oldSk.IsSet() {
-			oldPk := oldSk.PublicKey()
-			if err := i.RemoveAddresses(oldPk); err != nil {
-				i.logger.Error("Failed to remove old addresses", zap.Error(err))
-			}
-		}
-
 		if newSk := ci.PrivateKey(); newSk.IsSet() {
 			newPk := newSk.PublicKey()
 			if err := i.AddAddresses(newPk); err != nil {
 				i.logger.Error("Failed to add new addresses", zap.Error(err))
 			}
 		}
+
+		if oldSk := crypto.Key(old.PrivateKey); oldSk.IsSet() {
+			oldPk := oldSk.PublicKey()
+			if err := i.RemoveAddresses(oldPk); err != nil {
+				i.logger.Error("Failed to remove old addresses", zap.Error(err))
+			}
+		}
 	}
 }
 
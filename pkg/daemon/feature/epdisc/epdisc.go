@@ -5,9 +5,11 @@
 package epdisc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
+	"sync"
 
 	"github.com/pion/ice/v4"
 	"github.com/pion/stun/v3"
@@ -15,8 +17,11 @@ import (
 
 	"cunicu.li/cunicu/pkg/crypto"
 	"cunicu.li/cunicu/pkg/daemon"
+	icex "cunicu.li/cunicu/pkg/ice"
 	"cunicu.li/cunicu/pkg/log"
+	"cunicu.li/cunicu/pkg/portmap"
 	epdiscproto "cunicu.li/cunicu/pkg/proto/feature/epdisc"
+	"cunicu.li/cunicu/pkg/relay"
 )
 
 var Get = daemon.RegisterFeature(New, 50) //nolint:gochecknoglobals
@@ -39,7 +44,39 @@ type Interface struct {
 	muxPort      int
 	muxSrflxPort int
 
-	Peers map[*daemon.Peer]*Peer
+	// tcpMux accepts ICE-TCP connections for passive candidates
+	tcpMux     ice.TCPMux
+	tcpMuxPort int
+
+	// Server reflexive and local address of the ICE-TCP listener
+	tcpMapped *net.TCPAddr
+	tcpLocal  *net.TCPAddr
+	tcpMu     sync.RWMutex
+
+	// Result of the latest NAT behavior discovery
+	natBehavior       *icex.NATDiscovery
+	natBehaviorStatus *epdiscproto.NATDiscovery
+	natMu             sync.RWMutex
+
+	// mapper maintains a port mapping of the host UDP mux by the local router
+	mapper *portmap.Mapper
+
+	// relay is a TURN server which we offer to other community members
+	relay    *relay.Server
+	relayURL string
+
+	// peerRelays are the relays offered by other community members
+	peerRelays   map[crypto.Key]*stun.URI
+	peerRelaysMu sync.RWMutex
+
+	// peerAddrs are the addresses of the remote candidates of our peers
+	peerAddrs   map[crypto.Key]map[string]struct{}
+	peerAddrsMu sync.RWMutex
+
+	Peers   map[*daemon.Peer]*Peer
+	peersMu sync.RWMutex
+
+	stop context.CancelFunc
 
 	logger *log.Logger
 }
@@ -53,6 +90,9 @@ func New(di *daemon.Interface) (*Interface, error) {
 		Interface: di,
 		Peers:     map[*daemon.Peer]*Peer{},
 
+		peerRelays: map[crypto.Key]*stun.URI{},
+		peerAddrs:  map[crypto.Key]map[string]struct{}{},
+
 		logger: log.Global.Named("epdisc").With(zap.String("intf", di.Name())),
 	}
 
@@ -73,6 +113,20 @@ func New(di *daemon.Interface) (*Interface, error) {
 		}
 	}
 
+	if i.Settings.ICE.TCP.Enabled && i.Settings.ICE.HasCandidateType(ice.CandidateTypeHost) &&
+		(i.Settings.ICE.HasNetworkType(ice.NetworkTypeTCP4) || i.Settings.ICE.HasNetworkType(ice.NetworkTypeTCP6)) {
+		if err := i.setupTCPMux(); err != nil {
+			return nil, fmt.Errorf("failed to setup TCP mux: %w", err)
+		}
+	}
+
+	// Request a port mapping for the host UDP mux from the local router
+	if i.Settings.PortMapping.Enabled && i.mux != nil {
+		if err := i.setupPortMapping(); err != nil {
+			return nil, fmt.Errorf("failed to setup port mapping: %w", err)
+		}
+	}
+
 	// Setup Netfilter port forwarding for non-userspace devices
 	if i.Settings.PortForwarding && !i.IsUserspace() {
 		if err := i.setupNAT(); err != nil {
@@ -80,10 +134,27 @@ func New(di *daemon.Interface) (*Interface, error) {
 		}
 	}
 
+	if i.Settings.ICE.PeerRelay.Enabled {
+		if err := i.setupPeerRelay(); err != nil {
+			return nil, fmt.Errorf("failed to setup peer relay: %w", err)
+		}
+	}
+
 	return i, nil
 }
 
 func (i *Interface) Start() error {
+	var ctx context.Context
+	ctx, i.stop = context.WithCancel(context.Background())
+
+	if i.Settings.ICE.NATDiscovery {
+		go i.runNATDiscovery(ctx)
+	}
+
+	if i.tcpMux != nil && i.Settings.ICE.TCP.ServerReflexive {
+		go i.runTCPDiscovery(ctx)
+	}
+
 	i.logger.Info("Started endpoint discovery")
 
 	return nil
@@ -92,12 +163,28 @@ func (i *Interface) Start() error {
 func (i *Interface) Close() error {
 	i.Bind().RemoveOpenHandler(i)
 
+	if i.stop != nil {
+		i.stop()
+	}
+
 	for _, p := range i.Peers {
 		if err := p.Close(); err != nil {
 			return fmt.Errorf("failed to close peer '%s': %w", p, err)
 		}
 	}
 
+	if i.mapper != nil {
+		if err := i.mapper.Close(); err != nil {
+			i.logger.Warn("Failed to delete port mapping", zap.Error(err))
+		}
+	}
+
+	if i.relay != nil {
+		if err := i.relay.Close(); err != nil {
+			return fmt.Errorf("failed to stop peer relay: %w", err)
+		}
+	}
+
 	if i.nat != nil {
 		if err := i.nat.Close(); err != nil {
 			return fmt.Errorf("failed to de-initialize NAT: %w", err)
@@ -116,6 +203,12 @@ func (i *Interface) Close() error {
 		}
 	}
 
+	if i.tcpMux != nil {
+		if err := i.tcpMux.Close(); err != nil {
+			return fmt.Errorf("failed to do-initialize TCP mux: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -130,6 +223,20 @@ func (i *Interface) Marshal() *epdiscproto.Interface {
 		is.MuxSrflxPort = uint32(i.muxSrflxPort) //nolint:gosec
 	}
 
+	if i.tcpMux != nil {
+		is.TcpMuxPort = uint32(i.tcpMuxPort) //nolint:gosec
+
+		i.tcpMu.RLock()
+		if i.tcpMapped != nil {
+			is.TcpMappedAddress = i.tcpMapped.String()
+		}
+		i.tcpMu.RUnlock()
+	}
+
+	i.natMu.RLock()
+	is.NatDiscovery = i.natBehaviorStatus
+	i.natMu.RUnlock()
+
 	if i.nat == nil {
 		is.NatType = epdiscproto.NATType_NONE
 	} else {
@@ -4,21 +4,39 @@
 package epdisc
 
 import (
+	"context"
 	"net"
 
 	"go.uber.org/zap"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
+	"cunicu.li/cunicu/pkg/crypto"
 	"cunicu.li/cunicu/pkg/daemon"
 	"cunicu.li/cunicu/pkg/wg"
 )
 
-func (i *Interface) OnInterfaceModified(_ *daemon.Interface, _ *wg.Interface, m daemon.InterfaceModifier) {
+func (i *Interface) OnInterfaceModified(ci *daemon.Interface, old *wg.Interface, m daemon.InterfaceModifier) {
 	if m.Is(daemon.InterfaceModifiedListenPort) && i.nat != nil {
 		if err := i.updateNATRules(); err != nil {
 			i.logger.Error("Failed to update NAT rules", zap.Error(err))
 		}
 	}
+
+	// Migrate subscriptions to the new key and restart the sessions
+	// as the remote peers will re-create their sessions for our new key.
+	if skOld := crypto.Key(old.PrivateKey); m.Is(daemon.InterfaceModifiedPrivateKey) && skOld.IsSet() && ci.PrivateKey().IsSet() {
+		for _, p := range i.Peers {
+			if err := p.Resubscribe(context.Background(), skOld); err != nil {
+				p.logger.Error("Failed to update subscription", zap.Error(err))
+
+				continue
+			}
+
+			if err := p.Restart(); err != nil {
+				p.logger.Debug("Failed to restart session", zap.Error(err))
+			}
+		}
+	}
 }
 
 func (i *Interface) OnPeerAdded(cp *daemon.Peer) {
@@ -29,10 +47,15 @@ func (i *Interface) OnPeerAdded(cp *daemon.Peer) {
 		return
 	}
 
+	i.peersMu.Lock()
 	i.Peers[cp] = p
+	i.peersMu.Unlock()
 }
 
 func (i *Interface) OnPeerRemoved(cp *daemon.Peer) {
+	i.SetPeerRelay(cp.PublicKey(), "")
+	i.resetPeerAddresses(cp.PublicKey())
+
 	p, ok := i.Peers[cp]
 	if !ok {
 		return
@@ -42,7 +65,9 @@ func (i *Interface) OnPeerRemoved(cp *daemon.Peer) {
 		i.logger.Error("Failed to de-initialize ICE peer", zap.Error(err))
 	}
 
+	i.peersMu.Lock()
 	delete(i.Peers, cp)
+	i.peersMu.Unlock()
 }
 
 func (i *Interface) OnPeerModified(cp *daemon.Peer, _ *wgtypes.Peer, m daemon.PeerModifier, _, _ []net.IPNet) {
@@ -26,7 +26,7 @@ func (i *Interface) setupUDPMux() error {
 	i.muxPort = wg.DefaultPort + rand.Intn(config.EphemeralPortMax-wg.DefaultPort+1) //nolint:gosec
 
 	listen := func(ip net.IP) (net.PacketConn, error) {
-		udpConn, err := net.ListenUDP("udp", &net.UDPAddr{
+		udpConn, err := netx.ListenUDP("udp", &net.UDPAddr{
 			IP:   ip,
 			Port: i.muxPort,
 		})
@@ -81,7 +81,7 @@ func (i *Interface) setupUDPMux() error {
 }
 
 func (i *Interface) setupUniversalUDPMux() error {
-	udpConn, err := net.ListenUDP("udp", nil)
+	udpConn, err := netx.ListenUDP("udp", nil)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package epdisc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pion/ice/v4"
+	"github.com/pion/stun/v3"
+	"go.uber.org/zap"
+
+	icex "cunicu.li/cunicu/pkg/ice"
+	netx "cunicu.li/cunicu/pkg/net"
+	"cunicu.li/cunicu/pkg/proto"
+	epdiscproto "cunicu.li/cunicu/pkg/proto/feature/epdisc"
+)
+
+var errNoNATDiscoveryServer = errors.New("none of the STUN servers supports NAT behavior discovery")
+
+// runNATDiscovery periodically classifies the behavior of the NAT in front of us.
+func (i *Interface) runNATDiscovery(ctx context.Context) {
+	ticker := time.NewTicker(i.Settings.ICE.NATDiscoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := i.discoverNATBehavior(ctx); err != nil && ctx.Err() == nil {
+			i.logger.Debug("Failed to discover NAT behavior", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// discoverNATBehavior performs the tests of RFC 5780 against the configured STUN servers
+// until one of them supports NAT behavior discovery.
+func (i *Interface) discoverNATBehavior(ctx context.Context) error {
+	pk := i.PublicKey()
+
+	uris, err := i.Settings.AgentURLs(ctx, &pk)
+	if err != nil {
+		return fmt.Errorf("failed to gather STUN servers: %w", err)
+	}
+
+	for _, u := range uris {
+		if u.Scheme != stun.SchemeTypeSTUN || u.Proto != stun.ProtoTypeUDP {
+			continue
+		}
+
+		server, err := net.ResolveUDPAddr("udp4", net.JoinHostPort(u.Host, strconv.Itoa(u.Port)))
+		if err != nil {
+			continue
+		}
+
+		d, err := icex.DiscoverNATBehavior(ctx, server, 0)
+		if err != nil {
+			i.logger.Debug("Failed to discover NAT behavior", zap.String("server", u.String()), zap.Error(err))
+
+			continue
+		}
+
+		i.natMu.Lock()
+		changed := i.natBehavior == nil || netx.CmpUDPAddr(i.natBehavior.MappedAddress, d.MappedAddress) != 0 ||
+			i.natBehavior.Mapping != d.Mapping || i.natBehavior.Filtering != d.Filtering
+		i.natBehavior = d
+		i.natBehaviorStatus = &epdiscproto.NATDiscovery{
+			BehindNat:     d.BehindNAT(),
+			Mapping:       epdiscproto.NATBehavior(d.Mapping),   //nolint:gosec
+			Filtering:     epdiscproto.NATBehavior(d.Filtering), //nolint:gosec
+			MappedAddress: d.MappedAddress.String(),
+			Server:        u.String(),
+			Timestamp:     proto.Time(time.Now()),
+		}
+		i.natMu.Unlock()
+
+		if changed {
+			i.logger.Info("Discovered NAT behavior",
+				zap.Bool("behind_nat", d.BehindNAT()),
+				zap.Stringer("mapping", d.Mapping),
+				zap.Stringer("filtering", d.Filtering),
+				zap.Stringer("mapped_address", d.MappedAddress))
+		}
+
+		return nil
+	}
+
+	return errNoNATDiscoveryServer
+}
+
+// filterCandidateTypes skips the gathering of server reflexive candidates if they are pointless for the discovered NAT behavior.
+// Without a NAT they equal our host candidates. Behind a NAT with address and port-dependent mapping
+// remote peers can not reach the mapping which has been allocated for the STUN server.
+func (i *Interface) filterCandidateTypes(cts []ice.CandidateType) []ice.CandidateType {
+	i.natMu.RLock()
+	d := i.natBehavior
+	i.natMu.RUnlock()
+
+	if d == nil || (d.BehindNAT() && d.Mapping != icex.NATBehaviorAddressAndPortDependent) {
+		return cts
+	}
+
+	// Default candidate types of pion/ice
+	if len(cts) == 0 {
+		cts = []ice.CandidateType{
+			ice.CandidateTypeHost,
+			ice.CandidateTypeServerReflexive,
+			ice.CandidateTypeRelay,
+		}
+	}
+
+	filtered := slices.DeleteFunc(slices.Clone(cts), func(ct ice.CandidateType) bool {
+		return ct == ice.CandidateTypeServerReflexive
+	})
+
+	// An empty list would enable all candidate types
+	if len(filtered) == 0 {
+		return cts
+	}
+
+	return filtered
+}
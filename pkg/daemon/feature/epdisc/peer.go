@@ -21,6 +21,7 @@ import (
 	"cunicu.li/cunicu/pkg/crypto"
 	"cunicu.li/cunicu/pkg/daemon"
 	"cunicu.li/cunicu/pkg/log"
+	netx "cunicu.li/cunicu/pkg/net"
 	proto "cunicu.li/cunicu/pkg/proto"
 	coreproto "cunicu.li/cunicu/pkg/proto/core"
 	epdiscproto "cunicu.li/cunicu/pkg/proto/feature/epdisc"
@@ -47,6 +48,13 @@ type Peer struct {
 	endpoint *net.UDPAddr
 	restarts atomic.Uint32
 
+	// gathered is set once the current agent has completed its candidate gathering
+	gathered atomic.Bool
+
+	// mappingOffered is set once the port mapping of the local router has been sent
+	// as a candidate for the current agent
+	mappingOffered atomic.Bool
+
 	remoteCredentials *epdiscproto.Credentials
 	localCredentials  *epdiscproto.Credentials
 
@@ -323,6 +331,9 @@ func (p *Peer) createAgent() error {
 
 	p.logger.Info("Creating new agent")
 
+	p.gathered.Store(false)
+	p.mappingOffered.Store(false)
+
 	// Prepare ICE agent configuration
 	pk := p.Interface.PublicKey()
 
@@ -337,12 +348,27 @@ func (p *Peer) createAgent() error {
 		return origFilter(name) && p.Interface.Daemon.InterfaceByName(name) == nil
 	}
 
+	// Skip candidate types which are pointless for the discovered NAT behavior
+	acfg.CandidateTypes = p.Interface.filterCandidateTypes(acfg.CandidateTypes)
+
+	// Relays offered by other community members are used like TURN servers
+	if p.Interface.usePeerRelays() {
+		acfg.Urls = append(acfg.Urls, p.Interface.peerRelayURLs(p.PublicKey())...)
+	}
+
 	acfg.UDPMux = p.Interface.mux
 	acfg.UDPMuxSrflx = p.Interface.muxSrflx
+	acfg.TCPMux = p.Interface.tcpMux
 	acfg.LoggerFactory = log.NewPionLoggerFactory(p.logger)
 
+	// Sockets of the agent, e.g. for TURN allocations, must bypass the default route via an exit node
+	if acfg.Net, err = netx.NewNet(); err != nil {
+		return fmt.Errorf("failed to create network: %w", err)
+	}
+
 	p.localCredentials = epdiscproto.NewCredentials()
 	p.remoteCredentials = nil
+	p.Interface.resetPeerAddresses(p.PublicKey())
 
 	acfg.LocalUfrag = p.localCredentials.Ufrag
 	acfg.LocalPwd = p.localCredentials.Pwd
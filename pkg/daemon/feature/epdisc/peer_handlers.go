@@ -4,6 +4,8 @@
 package epdisc
 
 import (
+	"net"
+
 	"github.com/pion/ice/v4"
 	"go.uber.org/zap"
 
@@ -138,6 +140,14 @@ func (p *Peer) onRemoteCandidate(c *epdiscproto.Candidate) {
 
 	logger.Debug("Added remote candidate to agent")
 
+	if i := p.Interface; i.relay != nil {
+		i.addPeerAddress(p.PublicKey(), net.ParseIP(ic.Address()))
+
+		if r := ic.RelatedAddress(); r != nil {
+			i.addPeerAddress(p.PublicKey(), net.ParseIP(r.Address))
+		}
+	}
+
 	// Connect if this has been the first remote candidate
 	if _, ok := p.connectionState.SetIf(ConnectionStateConnecting, ConnectionStateGatheringRemote); ok {
 		go p.connect(p.remoteCredentials.Ufrag, p.remoteCredentials.Pwd)
@@ -152,6 +162,28 @@ func (p *Peer) onLocalCandidate(c ice.Candidate) {
 	if c == nil {
 		p.logger.Info("Candidate gathering completed")
 
+		p.gathered.Store(true)
+
+		// Offer the port mapping of the local router as an additional candidate
+		if mc, err := p.Interface.mappedCandidate(); err != nil {
+			p.logger.Error("Failed to create candidate for port mapping", zap.Error(err))
+		} else if mc != nil {
+			if err := p.sendCandidate(mc); err != nil {
+				p.logger.Error("Failed to send candidate", zap.Error(err))
+			} else {
+				p.mappingOffered.Store(true)
+			}
+		}
+
+		// Offer the mapped address of the ICE-TCP listener as an additional candidate
+		if tc, err := p.Interface.tcpMappedCandidate(); err != nil {
+			p.logger.Error("Failed to create candidate for ICE-TCP listener", zap.Error(err))
+		} else if tc != nil {
+			if err := p.sendCandidate(tc); err != nil {
+				p.logger.Error("Failed to send candidate", zap.Error(err))
+			}
+		}
+
 		return
 	}
 
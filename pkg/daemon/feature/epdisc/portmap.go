@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package epdisc
+
+import (
+	"net"
+
+	"github.com/pion/ice/v4"
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/portmap"
+)
+
+// setupPortMapping requests a mapping of the host UDP mux port from the local router.
+func (i *Interface) setupPortMapping() error {
+	cfg := i.Settings.PortMapping
+
+	gateway := cfg.Gateway
+	if gateway == nil {
+		var err error
+		if gateway, err = portmap.DefaultGateway(); err != nil {
+			i.logger.Debug("Failed to find default gateway. Only using UPnP for port mapping", zap.Error(err))
+		}
+	}
+
+	clients := []portmap.Client{}
+
+	for _, name := range cfg.Protocols {
+		p, err := portmap.ParseProtocol(name)
+		if err != nil {
+			return err
+		}
+
+		// PCP and NAT-PMP requests are sent to the gateway
+		if p != portmap.ProtocolUPnP && gateway == nil {
+			continue
+		}
+
+		c, err := portmap.NewClient(p, &net.UDPAddr{
+			IP:   gateway,
+			Port: portmap.DefaultPort,
+		})
+		if err != nil {
+			return err
+		}
+
+		clients = append(clients, c)
+	}
+
+	i.mapper = portmap.NewMapper(portmap.Config{
+		Port:      i.muxPort,
+		Clients:   clients,
+		Lifetime:  cfg.Lifetime,
+		OnMapping: i.onMapping,
+		Logger:    i.logger.Named("portmap"),
+	})
+
+	return nil
+}
+
+// mappedCandidate returns a server reflexive candidate for the port mapping of the host UDP mux.
+// It returns nil if the router has not granted a mapping.
+func (i *Interface) mappedCandidate() (ice.Candidate, error) {
+	if i.mapper == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	if len(i.Settings.ICE.CandidateTypes) > 0 && !i.Settings.ICE.HasCandidateType(ice.CandidateTypeServerReflexive) {
+		return nil, nil //nolint:nilnil
+	}
+
+	m := i.mapper.Mapping()
+	if m == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	return newMappedCandidate(m)
+}
+
+// onMapping is invoked by the mapper whenever the port mapping has been created, changed or lost.
+//
+// A new mapping is offered to all peers which have already completed their candidate gathering.
+// Sessions which have been offered a previous mapping are restarted as the remote agents
+// would otherwise keep checking the stale candidate.
+func (i *Interface) onMapping(m *portmap.Mapping) {
+	if len(i.Settings.ICE.CandidateTypes) > 0 && !i.Settings.ICE.HasCandidateType(ice.CandidateTypeServerReflexive) {
+		return
+	}
+
+	i.peersMu.RLock()
+	defer i.peersMu.RUnlock()
+
+	for _, p := range i.Peers {
+		// Peers which are still gathering will offer the mapping once completed
+		if !p.gathered.Load() {
+			continue
+		}
+
+		if p.mappingOffered.Load() {
+			if err := p.Restart(); err != nil {
+				p.logger.Debug("Failed to restart session", zap.Error(err))
+			}
+
+			continue
+		}
+
+		if m == nil {
+			continue
+		}
+
+		mc, err := newMappedCandidate(m)
+		if err != nil {
+			p.logger.Error("Failed to create candidate for port mapping", zap.Error(err))
+
+			continue
+		}
+
+		if err := p.sendCandidate(mc); err != nil {
+			p.logger.Error("Failed to send candidate", zap.Error(err))
+
+			continue
+		}
+
+		p.mappingOffered.Store(true)
+	}
+}
+
+func newMappedCandidate(m *portmap.Mapping) (ice.Candidate, error) {
+	return ice.NewCandidateServerReflexive(&ice.CandidateServerReflexiveConfig{
+		Network:   "udp",
+		Address:   m.External.IP.String(),
+		Port:      m.External.Port,
+		Component: ice.ComponentRTP,
+		RelAddr:   m.Internal.IP.String(),
+		RelPort:   m.Internal.Port,
+	})
+}
@@ -22,7 +22,12 @@ var errCandidatePairCanNotBeUsedWithNAT = errors.New("candidate pair can not be
 
 // CandidatePairCanBeNATted checks if a given candidate pair
 // can be used with kernel-space port address translation / natting.
+// ICE-TCP pairs require the framing of the userspace proxies.
 func CandidatePairCanBeNATted(cp *ice.CandidatePair) bool {
+	if !cp.Local.NetworkType().IsUDP() || !cp.Remote.NetworkType().IsUDP() {
+		return false
+	}
+
 	return cp.Local.Type() == ice.CandidateTypeHost || cp.Local.Type() == ice.CandidateTypeServerReflexive
 }
 
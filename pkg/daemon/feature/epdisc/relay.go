@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package epdisc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pion/ice/v4"
+	"github.com/pion/stun/v3"
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/relay"
+	grpcx "cunicu.li/cunicu/pkg/signaling/grpc"
+)
+
+// PeerRelayTTL is the lifetime of the credentials which we use
+// for allocations at the relays offered by other community members.
+const PeerRelayTTL = 24 * time.Hour
+
+var (
+	errMissingCommunity = errors.New("peer relaying requires a community")
+	errNoPublicAddress  = errors.New("no public address found")
+	errInvalidPeerRelay = errors.New("invalid peer relay")
+)
+
+// setupPeerRelay starts a TURN server which relays traffic for other members of the community.
+func (i *Interface) setupPeerRelay() error {
+	cfg := i.Settings.ICE.PeerRelay
+
+	community := crypto.Key(i.Settings.Community)
+	if !community.IsSet() {
+		return errMissingCommunity
+	}
+
+	addr := cfg.Address
+	if addr == nil {
+		host, _, err := net.SplitHostPort(cfg.Listen)
+		if err != nil {
+			return fmt.Errorf("invalid listen address: %w", err)
+		}
+
+		if addr = net.ParseIP(host); addr == nil || addr.IsUnspecified() {
+			if addr, err = i.publicAddress(); err != nil {
+				return err
+			}
+		}
+	}
+
+	svr, err := relay.NewServer(relay.Config{
+		ListenAddress: cfg.Listen,
+		RelayAddress:  addr,
+		Relay: grpcx.RelayInfo{
+			Secret: relay.CommunitySecret(community),
+		},
+		UserQuota:  cfg.UserQuota,
+		TotalQuota: cfg.TotalQuota,
+		Bandwidth:  cfg.Bandwidth,
+
+		// Every member can issue credentials for arbitrary users from the community secret
+		QuotaByAddress: true,
+
+		// Only relay traffic to other community members
+		PermissionHandler: func(_ net.Addr, peerIP net.IP) bool {
+			return i.isPeerAddress(peerIP)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	laddr, ok := svr.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		svr.Close()
+
+		return fmt.Errorf("%w: %s", errInvalidPeerRelay, svr.LocalAddr())
+	}
+
+	i.relay = svr
+	i.relayURL = fmt.Sprintf("turn:%s?transport=udp", net.JoinHostPort(addr.String(), strconv.Itoa(laddr.Port)))
+
+	i.logger.Info("Offering peer relay to community", zap.String("url", i.relayURL))
+
+	return nil
+}
+
+// addPeerAddress remembers an address at which a peer is reachable.
+// The peer relay only grants permissions for these addresses.
+func (i *Interface) addPeerAddress(pk crypto.Key, ip net.IP) {
+	if ip == nil {
+		return
+	}
+
+	i.peerAddrsMu.Lock()
+	defer i.peerAddrsMu.Unlock()
+
+	addrs, ok := i.peerAddrs[pk]
+	if !ok {
+		addrs = map[string]struct{}{}
+		i.peerAddrs[pk] = addrs
+	}
+
+	addrs[ip.String()] = struct{}{}
+}
+
+// resetPeerAddresses forgets the addresses of a peer.
+func (i *Interface) resetPeerAddresses(pk crypto.Key) {
+	i.peerAddrsMu.Lock()
+	defer i.peerAddrsMu.Unlock()
+
+	delete(i.peerAddrs, pk)
+}
+
+// isPeerAddress checks if ip belongs to any of the peers by the candidates which they have sent us.
+func (i *Interface) isPeerAddress(ip net.IP) bool {
+	i.peerAddrsMu.RLock()
+	defer i.peerAddrsMu.RUnlock()
+
+	for _, addrs := range i.peerAddrs {
+		if _, ok := addrs[ip.String()]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// publicAddress returns the first global unicast address of the host which is not private.
+func (i *Interface) publicAddress() (net.IP, error) {
+	intfs, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interfaces: %w", err)
+	}
+
+	for _, intf := range intfs {
+		// Skip WireGuard interfaces
+		if i.Daemon.InterfaceByName(intf.Name) != nil {
+			continue
+		}
+
+		addrs, err := intf.Addrs()
+		if err != nil {
+			continue
+		}
+
+		for _, addr := range addrs {
+			if ipn, ok := addr.(*net.IPNet); ok && ipn.IP.IsGlobalUnicast() && !ipn.IP.IsPrivate() {
+				return ipn.IP, nil
+			}
+		}
+	}
+
+	return nil, errNoPublicAddress
+}
+
+// RelayURL returns the TURN URL of the relay which we offer to other community members.
+// It is empty if peer relaying is disabled.
+func (i *Interface) RelayURL() string {
+	return i.relayURL
+}
+
+// SetPeerRelay updates the relay offered by a peer.
+// An empty URL removes the relay.
+func (i *Interface) SetPeerRelay(pk crypto.Key, u string) {
+	i.peerRelaysMu.Lock()
+	defer i.peerRelaysMu.Unlock()
+
+	if u == "" {
+		delete(i.peerRelays, pk)
+
+		return
+	}
+
+	uri, err := stun.ParseURI(u)
+	if err != nil || uri.Scheme != stun.SchemeTypeTURN || uri.Proto != stun.ProtoTypeUDP {
+		i.logger.Warn("Ignoring invalid peer relay", zap.Any("peer", pk), zap.String("url", u))
+
+		delete(i.peerRelays, pk)
+
+		return
+	}
+
+	i.peerRelays[pk] = uri
+}
+
+// usePeerRelays checks if relays offered by other community members can be used.
+func (i *Interface) usePeerRelays() bool {
+	s := &i.Settings.ICE
+
+	if len(s.CandidateTypes) > 0 && !s.HasCandidateType(ice.CandidateTypeRelay) {
+		return false
+	}
+
+	// Peer relays are only reachable via plain UDP
+	if (s.RelayTCP != nil && *s.RelayTCP) || (s.RelayTLS != nil && *s.RelayTLS) {
+		return false
+	}
+
+	return crypto.Key(i.Settings.Community).IsSet()
+}
+
+// peerRelayURLs returns the relays offered by community members other than ourself
+// and the peer pk alongside credentials for allocating relayed transport addresses.
+func (i *Interface) peerRelayURLs(pk crypto.Key) []*stun.URI {
+	r := grpcx.RelayInfo{
+		Secret: relay.CommunitySecret(crypto.Key(i.Settings.Community)),
+		TTL:    PeerRelayTTL,
+	}
+
+	user, pass, _ := r.GetCredentials(i.PublicKey().String())
+
+	i.peerRelaysMu.RLock()
+	defer i.peerRelaysMu.RUnlock()
+
+	uris := []*stun.URI{}
+
+	for rpk, uri := range i.peerRelays {
+		if rpk == pk || rpk == i.PublicKey() {
+			continue
+		}
+
+		u := *uri
+		u.Username = user
+		u.Password = pass
+
+		uris = append(uris, &u)
+	}
+
+	return uris
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package epdisc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pion/ice/v4"
+	"github.com/pion/stun/v3"
+	"go.uber.org/zap"
+
+	icex "cunicu.li/cunicu/pkg/ice"
+	"cunicu.li/cunicu/pkg/log"
+)
+
+const (
+	// Interval at which the server reflexive address of the ICE-TCP listener is refreshed
+	tcpDiscoveryInterval = 5 * time.Minute
+
+	// Number of packets which are buffered per ICE-TCP connection
+	tcpMuxReadBufferSize = 64
+
+	// Size of the write buffer per ICE-TCP connection in bytes
+	tcpMuxWriteBufferSize = 4 << 20
+)
+
+var errNoTCPDiscoveryServer = errors.New("none of the STUN servers is reachable via TCP")
+
+// setupTCPMux creates a listener which accepts ICE-TCP connections for passive host candidates (RFC 6544).
+// Active candidates are gathered by pion/ice itself for each remote passive candidate.
+func (i *Interface) setupTCPMux() error {
+	l, err := icex.ListenTCP(context.Background(), "tcp", i.Settings.ICE.TCP.Port)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	i.tcpMuxPort = l.Addr().(*net.TCPAddr).Port //nolint:forcetypeassert
+
+	// pion/ice frames the packets of ICE-TCP connections according to RFC 4571.
+	// Hence, the proxies can forward WireGuard packets over them just like over UDP.
+	i.tcpMux = ice.NewTCPMuxDefault(ice.TCPMuxParams{
+		Listener:        l,
+		Logger:          log.NewPionLogger(i.logger, "ice.tcpmux"),
+		ReadBufferSize:  tcpMuxReadBufferSize,
+		WriteBufferSize: tcpMuxWriteBufferSize,
+	})
+
+	i.logger.Debug("Created TCP mux for passive candidates", zap.Int("port", i.tcpMuxPort))
+
+	return nil
+}
+
+// runTCPDiscovery periodically discovers the server reflexive address of the ICE-TCP listener.
+func (i *Interface) runTCPDiscovery(ctx context.Context) {
+	ticker := time.NewTicker(tcpDiscoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := i.discoverTCPMappedAddress(ctx); err != nil && ctx.Err() == nil {
+			i.logger.Debug("Failed to discover mapped address of ICE-TCP listener", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// discoverTCPMappedAddress sends STUN binding requests over TCP from the port of the ICE-TCP listener
+// to the configured STUN servers until one of them responds.
+func (i *Interface) discoverTCPMappedAddress(ctx context.Context) error {
+	pk := i.PublicKey()
+
+	uris, err := i.Settings.AgentURLs(ctx, &pk)
+	if err != nil {
+		return fmt.Errorf("failed to gather STUN servers: %w", err)
+	}
+
+	for _, u := range uris {
+		if u.Scheme != stun.SchemeTypeSTUN {
+			continue
+		}
+
+		server, err := net.ResolveTCPAddr("tcp4", net.JoinHostPort(u.Host, strconv.Itoa(u.Port)))
+		if err != nil {
+			continue
+		}
+
+		mapped, local, err := icex.DiscoverTCPMappedAddress(ctx, i.tcpMuxPort, server)
+		if err != nil {
+			i.logger.Debug("Failed to discover mapped address of ICE-TCP listener", zap.String("server", u.String()), zap.Error(err))
+
+			continue
+		}
+
+		// Without a NAT the mapped address equals our passive host candidate
+		if mapped.IP.Equal(local.IP) && mapped.Port == local.Port {
+			mapped = nil
+		}
+
+		i.tcpMu.Lock()
+		changed := (i.tcpMapped == nil) != (mapped == nil) ||
+			(mapped != nil && (!i.tcpMapped.IP.Equal(mapped.IP) || i.tcpMapped.Port != mapped.Port))
+		i.tcpMapped, i.tcpLocal = mapped, local
+		i.tcpMu.Unlock()
+
+		if changed && mapped != nil {
+			i.logger.Info("Discovered mapped address of ICE-TCP listener", zap.Stringer("mapped_address", mapped))
+		}
+
+		return nil
+	}
+
+	return errNoTCPDiscoveryServer
+}
+
+// tcpMappedCandidate returns a passive server reflexive ICE-TCP candidate for the mapped address of the ICE-TCP listener.
+// It returns nil if the listener is not behind a NAT or its mapped address is unknown.
+func (i *Interface) tcpMappedCandidate() (ice.Candidate, error) {
+	if len(i.Settings.ICE.CandidateTypes) > 0 && !i.Settings.ICE.HasCandidateType(ice.CandidateTypeServerReflexive) {
+		return nil, nil //nolint:nilnil
+	}
+
+	i.tcpMu.RLock()
+	mapped, local := i.tcpMapped, i.tcpLocal
+	i.tcpMu.RUnlock()
+
+	if mapped == nil || !i.Settings.ICE.HasNetworkType(ice.NetworkTypeTCP4) {
+		return nil, nil //nolint:nilnil
+	}
+
+	return icex.NewTCPMappedCandidate(mapped, local)
+}
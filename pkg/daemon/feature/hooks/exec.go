@@ -5,11 +5,13 @@ package hooks
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net"
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
@@ -17,9 +19,12 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"cunicu.li/cunicu/pkg/config"
+	"cunicu.li/cunicu/pkg/crypto"
 	"cunicu.li/cunicu/pkg/daemon"
 	"cunicu.li/cunicu/pkg/daemon/feature/epdisc"
+	"cunicu.li/cunicu/pkg/daemon/feature/pdisc"
 	"cunicu.li/cunicu/pkg/log"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
 	"cunicu.li/cunicu/pkg/wg"
 )
 
@@ -177,3 +182,18 @@ func (h *ExecHook) OnPeerStateChanged(p *daemon.Peer, newState, prevState daemon
 
 	go h.run(pm, "changed", "peer", "connection-state", p.Interface.Name(), p.PublicKey(), newState, prevState)
 }
+
+func (h *ExecHook) OnPeerExpired(p *daemon.Peer, lastSeen time.Time) {
+	go h.run(p.Marshal(), "expired", "peer", p.Interface.Name(), p.PublicKey(), strconv.FormatInt(lastSeen.UnixMilli(), 10))
+}
+
+func (h *ExecHook) OnClaimRejected(i *daemon.Interface, d *pdiscproto.PeerDescription, prefix net.IPNet, reason error) {
+	cause := "conflict"
+	if errors.Is(reason, pdisc.ErrAllowedIPNotPermitted) {
+		cause = "policy"
+	}
+
+	pk, _ := crypto.ParseKeyBytes(d.PublicKey)
+
+	go h.run(marshalDescription(d), "rejected", "allowed-ip", i.Name(), pk, prefix.String(), cause)
+}
@@ -8,6 +8,7 @@ import (
 
 	"cunicu.li/cunicu/pkg/config"
 	"cunicu.li/cunicu/pkg/daemon"
+	"cunicu.li/cunicu/pkg/daemon/feature/pdisc"
 	"cunicu.li/cunicu/pkg/log"
 )
 
@@ -16,6 +17,8 @@ var Get = daemon.RegisterFeature(New, 70) //nolint:gochecknoglobals
 type Hook interface {
 	daemon.AllHandler
 	daemon.PeerStateChangedHandler
+	pdisc.PeerExpiredHandler
+	pdisc.ClaimRejectedHandler
 }
 
 type Interface struct {
@@ -49,6 +52,11 @@ func New(i *daemon.Interface) (*Interface, error) {
 		h.AddPeerHandler(hk)
 		h.AddPeerStateChangeHandler(hk)
 
+		if pi := pdisc.Get(i); pi != nil {
+			pi.AddPeerExpiredHandler(hk)
+			pi.AddClaimRejectedHandler(hk)
+		}
+
 		h.hooks = append(h.hooks, hk)
 	}
 
@@ -6,6 +6,7 @@ package hooks
 import (
 	"cunicu.li/cunicu/pkg/daemon"
 	coreproto "cunicu.li/cunicu/pkg/proto/core"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
 )
 
 func marshalRedactedInterface(i *daemon.Interface) *coreproto.Interface {
@@ -13,3 +14,13 @@ func marshalRedactedInterface(i *daemon.Interface) *coreproto.Interface {
 		return p.Marshal().Redact()
 	}).Redact()
 }
+
+// marshalDescription returns the peer as described by a peer description
+// as the peer might not have been added to the interface.
+func marshalDescription(d *pdiscproto.PeerDescription) *coreproto.Peer {
+	return &coreproto.Peer{
+		Name:       d.Name,
+		PublicKey:  d.PublicKey,
+		AllowedIps: d.AllowedIps,
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"time"
 
 	"go.uber.org/zap"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
@@ -20,6 +21,7 @@ import (
 	"cunicu.li/cunicu/pkg/daemon/feature/epdisc"
 	"cunicu.li/cunicu/pkg/log"
 	hooksproto "cunicu.li/cunicu/pkg/proto/feature/hooks"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
 	rpcproto "cunicu.li/cunicu/pkg/proto/rpc"
 	"cunicu.li/cunicu/pkg/wg"
 )
@@ -139,3 +141,20 @@ func (h *WebHook) OnPeerStateChanged(p *daemon.Peer, _, _ daemon.PeerState) {
 		Peer: pm,
 	})
 }
+
+func (h *WebHook) OnPeerExpired(p *daemon.Peer, _ time.Time) {
+	go h.run(&hooksproto.WebHookBody{
+		Type: rpcproto.EventType_PEER_EXPIRED,
+		Peer: p.Marshal().Redact(),
+	})
+}
+
+func (h *WebHook) OnClaimRejected(i *daemon.Interface, d *pdiscproto.PeerDescription, prefix net.IPNet, reason error) {
+	go h.run(&hooksproto.WebHookBody{
+		Type:      rpcproto.EventType_PEER_ALLOWED_IP_REJECTED,
+		Interface: marshalRedactedInterface(i),
+		Peer:      marshalDescription(d),
+		Prefix:    prefix.String(),
+		Reason:    reason.Error(),
+	})
+}
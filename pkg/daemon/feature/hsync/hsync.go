@@ -2,11 +2,14 @@
 // SPDX-License-Identifier: Apache-2.0
 
 // Package hsync synchronizes /etc/hosts with pairs of peer hostname and their respective IP addresses
+// as well as a zone file with DNS SRV records of the services offered by the peers
 package hsync
 
 import (
 	"fmt"
 	"net/netip"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
@@ -60,15 +63,37 @@ func (i *Interface) Start() error {
 }
 
 func (i *Interface) Close() error {
-	return i.Update(nil)
+	if err := i.Update(nil); err != nil {
+		return err
+	}
+
+	return i.UpdateServices(nil)
 }
 
-func (i *Interface) Hosts() []Host {
+func (i *Interface) domain() string {
 	d := i.Settings.Domain
 	if d != "" && !strings.HasPrefix(d, ".") {
 		d = "." + d
 	}
 
+	return d
+}
+
+func (i *Interface) comment(p *daemon.Peer) string {
+	return fmt.Sprintf("%s: ifname=%s, ifindex=%d, pk=%s", hostsCommentPrefix,
+		p.Interface.Name(),
+		p.Interface.Index(),
+		p.PublicKey())
+}
+
+// isOwnComment returns true if the comment has been added by us for this interface.
+func (i *Interface) isOwnComment(comment string) bool {
+	return strings.HasPrefix(comment, hostsCommentPrefix) && strings.Contains(comment, fmt.Sprintf("ifindex=%d", i.Index()))
+}
+
+func (i *Interface) Hosts() []Host {
+	d := i.domain()
+
 	hosts := []Host{}
 
 	for _, p := range i.Peers {
@@ -76,7 +101,7 @@ func (i *Interface) Hosts() []Host {
 
 		for name, addrs := range p.Hosts {
 			for _, a := range addrs {
-				// TODO: Validate that the addresses are covered by the peers AllowedIPs
+				// Addresses have already been validated against the AllowedIPs claimed by the peer
 				addr, ok := netip.AddrFromSlice(a)
 				if !ok {
 					continue
@@ -88,12 +113,9 @@ func (i *Interface) Hosts() []Host {
 
 		for addr, names := range m {
 			h := Host{
-				Names: names,
-				IP:    addr.AsSlice(),
-				Comment: fmt.Sprintf("%s: ifname=%s, ifindex=%d, pk=%s", hostsCommentPrefix,
-					p.Interface.Name(),
-					p.Interface.Index(),
-					p.PublicKey()),
+				Names:   names,
+				IP:      addr.AsSlice(),
+				Comment: i.comment(p),
 			}
 
 			hosts = append(hosts, h)
@@ -113,7 +135,7 @@ func (i *Interface) Update(hosts []Host) error {
 	lines = slicesx.Filter(lines, func(line string) bool {
 		h, err := ParseHost(line)
 
-		return err != nil || !strings.HasPrefix(h.Comment, hostsCommentPrefix) || !strings.Contains(h.Comment, fmt.Sprintf("ifindex=%d", i.Index()))
+		return err != nil || !i.isOwnComment(h.Comment)
 	})
 
 	// Add new hosts
@@ -138,8 +160,88 @@ func (i *Interface) Update(hosts []Host) error {
 	return nil
 }
 
+// Services returns SRV records for the services offered by all peers.
+// The records target the hostnames of the peers which are resolved via the hosts file.
+func (i *Interface) Services() []Service {
+	d := i.domain()
+
+	svcs := []Service{}
+
+	i.ForEachPeer(func(p *daemon.Peer) error { //nolint:errcheck
+		if p.Name == "" {
+			return nil
+		}
+
+		target := p.Name + d + "."
+
+		for _, s := range p.Services {
+			svcs = append(svcs, Service{
+				Name:     s.Name + "." + target,
+				Priority: uint16(s.Priority), //nolint:gosec
+				Weight:   uint16(s.Weight),   //nolint:gosec
+				Port:     uint16(s.Port),     //nolint:gosec
+				Target:   target,
+				Comment:  i.comment(p),
+			})
+		}
+
+		return nil
+	})
+
+	return svcs
+}
+
+func (i *Interface) UpdateServices(svcs []Service) error {
+	fn := i.Settings.ServicesFile
+	if fn == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fn), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	lines, err := readLines(fn)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Filter out lines not added by cunīcu
+	lines = slicesx.Filter(lines, func(line string) bool {
+		s, err := ParseService(line)
+
+		return err != nil || !i.isOwnComment(s.Comment)
+	})
+
+	// Add new services
+	for _, s := range svcs {
+		line, err := s.Line()
+		if err != nil {
+			i.logger.Warn("Skipping invalid service", zap.Error(err))
+
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := writeLines(fn, lines); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	i.logger.Info("Updated services file", zap.Int("num_services", len(svcs)))
+
+	return nil
+}
+
 func (i *Interface) Sync() error {
 	hosts := i.Hosts()
 
-	return i.Update(hosts)
+	if err := i.Update(hosts); err != nil {
+		return err
+	}
+
+	svcs := i.Services()
+
+	return i.UpdateServices(svcs)
 }
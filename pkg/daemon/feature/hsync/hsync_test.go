@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package hsync_test
+
+import (
+	"testing"
+
+	"cunicu.li/cunicu/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSuite(t *testing.T) {
+	test.SetupLogging()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hosts Synchronization Suite")
+}
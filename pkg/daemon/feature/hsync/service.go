@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package hsync
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	errInvalidRecord = errors.New("not a SRV record")
+	errInvalidName   = errors.New("invalid name")
+)
+
+// Service is a DNS SRV record (RFC 2782) in zone file notation.
+type Service struct {
+	Name     string
+	Priority uint16
+	Weight   uint16
+	Port     uint16
+	Target   string
+	Comment  string
+}
+
+func ParseService(line string) (Service, error) {
+	tokenStrs := strings.SplitN(line, ";", 2)
+	fields := strings.Fields(tokenStrs[0])
+
+	s := Service{}
+
+	if len(tokenStrs) > 1 {
+		s.Comment = strings.TrimSpace(tokenStrs[1])
+	}
+
+	if len(fields) != 7 || fields[1] != "IN" || fields[2] != "SRV" {
+		return s, errInvalidRecord
+	}
+
+	vals := []*uint16{&s.Priority, &s.Weight, &s.Port}
+	for i, val := range vals {
+		v, err := strconv.ParseUint(fields[3+i], 10, 16)
+		if err != nil {
+			return s, fmt.Errorf("%w: %w", errInvalidRecord, err)
+		}
+
+		*val = uint16(v)
+	}
+
+	s.Name = fields[0]
+	s.Target = fields[6]
+
+	return s, nil
+}
+
+func (s *Service) Line() (string, error) {
+	for _, n := range []string{s.Name, s.Target} {
+		if !isValidName(n) {
+			return "", fmt.Errorf("%w: %q", errInvalidName, n)
+		}
+	}
+
+	parts := []string{
+		s.Name, "IN", "SRV",
+		strconv.Itoa(int(s.Priority)),
+		strconv.Itoa(int(s.Weight)),
+		strconv.Itoa(int(s.Port)),
+		s.Target,
+	}
+
+	if s.Comment != "" {
+		parts = append(parts, ";", s.Comment)
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// isValidName checks that a name is a single field of a zone file line.
+func isValidName(n string) bool {
+	return n != "" && !strings.ContainsFunc(n, func(r rune) bool {
+		return r == ';' || unicode.IsSpace(r) || !unicode.IsPrint(r)
+	})
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package hsync_test
+
+import (
+	"cunicu.li/cunicu/pkg/daemon/feature/hsync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("services", func() {
+	svc := hsync.Service{
+		Name:     "_ssh._tcp.alice.cunicu.",
+		Priority: 10,
+		Weight:   5,
+		Port:     22,
+		Target:   "alice.cunicu.",
+		Comment:  "cunicu: wg0 abc",
+	}
+
+	It("can format a SRV record", func() {
+		line, err := svc.Line()
+		Expect(err).To(Succeed())
+		Expect(line).To(Equal("_ssh._tcp.alice.cunicu. IN SRV 10 5 22 alice.cunicu. ; cunicu: wg0 abc"))
+	})
+
+	It("can parse a formatted SRV record", func() {
+		line, err := svc.Line()
+		Expect(err).To(Succeed())
+
+		s, err := hsync.ParseService(line)
+		Expect(err).To(Succeed())
+		Expect(s).To(Equal(svc))
+	})
+
+	It("parses records without comment", func() {
+		s, err := hsync.ParseService("_ssh._tcp.bob. IN SRV 0 0 2222 bob.")
+		Expect(err).To(Succeed())
+		Expect(s.Port).To(BeNumerically("==", 2222))
+		Expect(s.Comment).To(BeEmpty())
+	})
+
+	DescribeTable("rejects invalid records",
+		func(line string) {
+			_, err := hsync.ParseService(line)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty line", ""),
+		Entry("comment only", "; comment"),
+		Entry("other record type", "alice. IN A 10.0.0.1"),
+		Entry("missing fields", "_ssh._tcp.alice. IN SRV 0 0 22"),
+		Entry("port out of range", "_ssh._tcp.alice. IN SRV 0 0 65536 alice."),
+		Entry("negative priority", "_ssh._tcp.alice. IN SRV -1 0 22 alice."),
+	)
+
+	DescribeTable("refuses to format invalid names",
+		func(name, target string) {
+			s := svc
+			s.Name = name
+			s.Target = target
+
+			_, err := s.Line()
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty name", "", "alice."),
+		Entry("whitespace in name", "_ssh._tcp.alice. IN A 10.0.0.1", "alice."),
+		Entry("newline in name", "_ssh._tcp\nevil.", "alice."),
+		Entry("comment in name", "_ssh._tcp;evil.", "alice."),
+		Entry("whitespace in target", "_ssh._tcp.alice.", "alice. evil."),
+	)
+})
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/daemon"
+	netx "cunicu.li/cunicu/pkg/net"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+)
+
+var (
+	ErrAllowedIPNotPermitted = errors.New("not permitted by AllowedIPs policy")
+	ErrAllowedIPConflict     = errors.New("conflicts with AllowedIPs of another peer")
+)
+
+// ClaimRejectedHandler is invoked for each AllowedIP claimed by a remote peer
+// which is not configured as it violates the AllowedIPs policy or conflicts with another peer.
+type ClaimRejectedHandler interface {
+	OnClaimRejected(i *daemon.Interface, d *pdiscproto.PeerDescription, prefix net.IPNet, reason error)
+}
+
+func (i *Interface) AddClaimRejectedHandler(h ClaimRejectedHandler) {
+	if !slices.Contains(i.onClaimRejected, h) {
+		i.onClaimRejected = append(i.onClaimRejected, h)
+	}
+}
+
+func (i *Interface) RemoveClaimRejectedHandler(h ClaimRejectedHandler) {
+	if idx := slices.Index(i.onClaimRejected, h); idx > -1 {
+		i.onClaimRejected = slices.Delete(i.onClaimRejected, idx, idx+1)
+	}
+}
+
+// claim validates the AllowedIPs of a peer description against the AllowedIPs policy
+// and the AllowedIPs already claimed by other peers. The first peer claiming a prefix wins.
+// It returns true if the accepted AllowedIPs of the peer have changed.
+func (i *Interface) claim(pk crypto.Key, d *pdiscproto.PeerDescription) bool {
+	type rejection struct {
+		prefix net.IPNet
+		reason error
+	}
+
+	accepted := []net.IPNet{}
+	rejections := []rejection{}
+
+	// Tags are chosen by the peer itself. Hence, we only trust the tags certified
+	// by its community certificate if we require one.
+	tags := d.Tags
+	if len(i.cas) > 0 {
+		tags = nil
+		if len(d.Certificates) > 0 {
+			tags = d.Certificates[0].Tags()
+		}
+	}
+
+	i.claimsMu.Lock()
+
+	for _, aip := range d.AllowedIps {
+		_, ipn, err := net.ParseCIDR(aip)
+		if err != nil {
+			i.logger.Warn("Ignoring malformed AllowedIP claimed by peer", zap.Any("peer", pk), zap.String("prefix", aip))
+
+			continue
+		}
+
+		pfx := *ipn
+
+		if !i.Settings.AllowedIPsPolicy.Permits(pfx, pk, tags, i.Settings.Tags) {
+			rejections = append(rejections, rejection{pfx, ErrAllowedIPNotPermitted})
+
+			continue
+		}
+
+		if owner, ok := i.claimedBy(pk, pfx); ok {
+			rejections = append(rejections, rejection{pfx, fmt.Errorf("%w: %s", ErrAllowedIPConflict, owner)})
+
+			continue
+		}
+
+		accepted = append(accepted, pfx)
+	}
+
+	changed := !slices.EqualFunc(accepted, i.claims[pk], equalNet)
+	previous := i.rejected[pk]
+
+	i.claims[pk] = accepted
+	i.rejected[pk] = nil
+
+	for _, r := range rejections {
+		i.rejected[pk] = append(i.rejected[pk], r.prefix)
+	}
+
+	i.claimsMu.Unlock()
+
+	// Only report rejections which have not already been reported for a previous description
+	for _, r := range rejections {
+		if slices.ContainsFunc(previous, func(p net.IPNet) bool {
+			return equalNet(p, r.prefix)
+		}) {
+			continue
+		}
+
+		i.logger.Warn("Rejecting AllowedIP claimed by peer",
+			zap.Any("peer", pk),
+			zap.String("prefix", r.prefix.String()),
+			zap.Error(r.reason))
+
+		for _, h := range i.onClaimRejected {
+			h.OnClaimRejected(i.Interface, d, r.prefix, r.reason)
+		}
+	}
+
+	return changed
+}
+
+// claimedBy returns the peer other than pk which has already claimed a prefix overlapping with pfx.
+// Our own AllowedIPs and those of statically configured peers take precedence over any claim.
+// The caller must hold claimsMu.
+func (i *Interface) claimedBy(pk crypto.Key, pfx net.IPNet) (crypto.Key, bool) {
+	overlaps := func(c net.IPNet) bool {
+		return netx.ContainsNet(&c, &pfx) || netx.ContainsNet(&pfx, &c)
+	}
+
+	if slices.ContainsFunc(i.allowedIPs(), overlaps) {
+		return i.PublicKey(), true
+	}
+
+	for _, p := range i.Settings.Peers {
+		if p.PublicKey != pk && slices.ContainsFunc(p.AllowedIPs, overlaps) {
+			return p.PublicKey, true
+		}
+	}
+
+	for owner, claims := range i.claims {
+		if owner == pk {
+			continue
+		}
+
+		if slices.ContainsFunc(claims, overlaps) {
+			return owner, true
+		}
+	}
+
+	return crypto.Key{}, false
+}
+
+// claimed returns the accepted AllowedIPs of a peer.
+func (i *Interface) claimed(pk crypto.Key) []net.IPNet {
+	i.claimsMu.RLock()
+	defer i.claimsMu.RUnlock()
+
+	return slices.Clone(i.claims[pk])
+}
+
+// release forgets the AllowedIPs claimed by a peer.
+func (i *Interface) release(pk crypto.Key) {
+	i.claimsMu.Lock()
+	defer i.claimsMu.Unlock()
+
+	delete(i.claims, pk)
+	delete(i.rejected, pk)
+}
+
+// reclaim re-evaluates the claims of peers which had some of their AllowedIPs rejected
+// as those might have been released by a removed peer in the meantime.
+func (i *Interface) reclaim() {
+	i.claimsMu.RLock()
+	pks := []crypto.Key{}
+	for pk, rejected := range i.rejected {
+		if len(rejected) > 0 {
+			pks = append(pks, pk)
+		}
+	}
+	i.claimsMu.RUnlock()
+
+	changed := false
+
+	for _, pk := range pks {
+		i.descsMu.RLock()
+		d, ok := i.descs[pk]
+		i.descsMu.RUnlock()
+
+		if ok && d.Change != pdiscproto.PeerDescriptionChange_REMOVE && i.claim(pk, d) {
+			changed = true
+		}
+	}
+
+	if changed {
+		i.reconfigurePeers()
+	}
+}
+
+func equalNet(a, b net.IPNet) bool {
+	return netx.CmpNet(a, b) == 0
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/daemon"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+)
+
+//nolint:gochecknoglobals
+var defaultRoutes = []net.IPNet{
+	{IP: net.IPv4zero, Mask: net.CIDRMask(0, net.IPv4len*8)},
+	{IP: net.IPv6zero, Mask: net.CIDRMask(0, net.IPv6len*8)},
+}
+
+// ExitNode returns the peer which is currently used as exit node.
+func (i *Interface) ExitNode() *daemon.Peer {
+	pk, ok := i.exitNodeKey()
+	if !ok {
+		return nil
+	}
+
+	return i.Peer(pk)
+}
+
+// UseExitNode selects the peer which is used as exit node by its hostname or public key.
+// An empty name disables the use of an exit node.
+func (i *Interface) UseExitNode(name string) error {
+	if name != "" {
+		i.descsMu.RLock()
+		_, err := pdiscproto.SelectExitNode(i.descs, name, i.cas, time.Now())
+		i.descsMu.RUnlock()
+
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, name)
+		}
+	}
+
+	i.routesMu.Lock()
+	i.exitNode = name
+	i.routesMu.Unlock()
+
+	i.logger.Info("Selected exit node", zap.String("peer", name))
+
+	i.reconfigurePeers()
+
+	return nil
+}
+
+// isExitNode returns true if the peer is the exit node selected by us.
+func (i *Interface) isExitNode(pk crypto.Key) bool {
+	sel, ok := i.exitNodeKey()
+
+	return ok && sel == pk
+}
+
+// exitNodeKey returns the public key of the selected exit node.
+func (i *Interface) exitNodeKey() (crypto.Key, bool) {
+	i.routesMu.RLock()
+	name := i.exitNode
+	i.routesMu.RUnlock()
+
+	i.descsMu.RLock()
+	defer i.descsMu.RUnlock()
+
+	pk, err := pdiscproto.SelectExitNode(i.descs, name, i.cas, time.Now())
+
+	return pk, err == nil
+}
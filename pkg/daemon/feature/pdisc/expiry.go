@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc
+
+import (
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/daemon"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+)
+
+// ExpiryCheckInterval is the interval at which we check for expired peer descriptions.
+const ExpiryCheckInterval = 10 * time.Second
+
+type PeerExpiredHandler interface {
+	OnPeerExpired(p *daemon.Peer, lastSeen time.Time)
+}
+
+type expiry struct {
+	lastSeen time.Time
+	deadline time.Time
+}
+
+func (i *Interface) AddPeerExpiredHandler(h PeerExpiredHandler) {
+	if !slices.Contains(i.onPeerExpired, h) {
+		i.onPeerExpired = append(i.onPeerExpired, h)
+	}
+}
+
+func (i *Interface) RemovePeerExpiredHandler(h PeerExpiredHandler) {
+	if idx := slices.Index(i.onPeerExpired, h); idx > -1 {
+		i.onPeerExpired = slices.Delete(i.onPeerExpired, idx, idx+1)
+	}
+}
+
+// run periodically re-announces our own peer description
+// and removes peers whose descriptions have expired.
+func (i *Interface) run() {
+	var announce <-chan time.Time
+
+	if ttl := i.Settings.PeerTTL; ttl > 0 {
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+
+		announce = ticker.C
+	}
+
+	ticker := time.NewTicker(ExpiryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-i.stop:
+			return
+
+		case <-announce:
+			if !i.PrivateKey().IsSet() {
+				continue
+			}
+
+			if err := i.sendPeerDescription(pdiscproto.PeerDescriptionChange_UPDATE, nil); err != nil {
+				i.logger.Error("Failed to send peer description", zap.Error(err))
+			}
+
+		case now := <-ticker.C:
+			i.expire(now)
+		}
+	}
+}
+
+// refresh extends the lifetime of a peer after receiving its description.
+func (i *Interface) refresh(pk crypto.Key, d *pdiscproto.PeerDescription) {
+	i.expiriesMu.Lock()
+	defer i.expiriesMu.Unlock()
+
+	// Peers running older versions do not announce a TTL
+	if d.Ttl == 0 || d.Change == pdiscproto.PeerDescriptionChange_REMOVE {
+		delete(i.expiries, pk)
+
+		return
+	}
+
+	now := time.Now()
+	ttl := time.Duration(d.Ttl) * time.Second
+
+	i.expiries[pk] = expiry{
+		lastSeen: now,
+		deadline: now.Add(ttl + i.Settings.PeerTTLGrace),
+	}
+}
+
+func (i *Interface) forget(pk crypto.Key) {
+	i.expiriesMu.Lock()
+	defer i.expiriesMu.Unlock()
+
+	delete(i.expiries, pk)
+}
+
+// expire removes all peers whose descriptions have not been re-announced in time.
+func (i *Interface) expire(now time.Time) {
+	expired := map[crypto.Key]time.Time{}
+
+	i.expiriesMu.Lock()
+	for pk, e := range i.expiries {
+		if now.After(e.deadline) {
+			expired[pk] = e.lastSeen
+
+			delete(i.expiries, pk)
+		}
+	}
+	i.expiriesMu.Unlock()
+
+	for pk, lastSeen := range expired {
+		i.descsMu.Lock()
+		delete(i.descs, pk)
+		i.descsMu.Unlock()
+
+		cp := i.Peer(pk)
+		if cp == nil {
+			continue
+		}
+
+		// Statically configured peers are authoritative and hence never expire
+		if i.isStatic(pk) {
+			i.logger.Debug("Keeping statically configured peer whose description has expired",
+				zap.Any("peer", pk),
+				zap.Time("last_seen", lastSeen))
+
+			continue
+		}
+
+		i.logger.Info("Removing expired peer",
+			zap.Any("peer", pk),
+			zap.Time("last_seen", lastSeen))
+
+		for _, h := range i.onPeerExpired {
+			h.OnPeerExpired(cp, lastSeen)
+		}
+
+		if err := i.RemovePeer(pk); err != nil {
+			i.logger.Error("Failed to remove expired peer", zap.Any("peer", pk), zap.Error(err))
+		}
+	}
+}
+
+// isReannouncement returns true if the description does not differ from the one we already know.
+func (i *Interface) isReannouncement(pk crypto.Key, d *pdiscproto.PeerDescription) bool {
+	if d.Change != pdiscproto.PeerDescriptionChange_UPDATE || d.PublicKeyNew != nil {
+		return false
+	}
+
+	i.descsMu.RLock()
+	old, ok := i.descs[pk]
+	i.descsMu.RUnlock()
+
+	if !ok {
+		return false
+	}
+
+	oldc, ok1 := proto.Clone(old).(*pdiscproto.PeerDescription)
+	newc, ok2 := proto.Clone(d).(*pdiscproto.PeerDescription)
+	if !ok1 || !ok2 {
+		panic("type assertion failed")
+	}
+
+	oldc.Change = newc.Change
+
+	return proto.Equal(oldc, newc)
+}
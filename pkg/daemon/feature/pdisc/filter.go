@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc
+
+import (
+	"maps"
+
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/config"
+)
+
+// SetFilter replaces the whitelist and blacklist of the interface
+// and removes all discovered peers which are no longer accepted.
+// Peers which become accepted are added with their next announcement.
+func (i *Interface) SetFilter(f config.PeerFilter) error {
+	// Peer descriptions are not handled until all rejected peers have been removed
+	i.filterMu.Lock()
+	defer i.filterMu.Unlock()
+
+	i.filter = f
+
+	i.descsMu.RLock()
+	descs := maps.Clone(i.descs)
+	i.descsMu.RUnlock()
+
+	for pk, d := range descs {
+		if i.isAccepted(pk, d) {
+			continue
+		}
+
+		i.logger.Info("Removing peer rejected by whitelist or blacklist", zap.Any("peer", pk))
+
+		if err := i.dropPeer(pk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"cunicu.li/cunicu/pkg/crypto"
 	"cunicu.li/cunicu/pkg/daemon"
+	"cunicu.li/cunicu/pkg/daemon/feature/epdisc"
 	"cunicu.li/cunicu/pkg/daemon/feature/hsync"
 	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
 	"cunicu.li/cunicu/pkg/signaling"
@@ -26,14 +27,13 @@ func (i *Interface) OnInterfaceModified(ci *daemon.Interface, old *wg.Interface,
 	// Only send an update if the private key changed.
 	// There are currently no other attributes which would need to be re-announced
 	if m.Is(daemon.InterfaceModifiedPrivateKey) {
-		var pkOld *crypto.Key
+		var skOld *crypto.Key
 
-		if skOld := crypto.Key(old.PrivateKey); skOld.IsSet() {
-			pk := skOld.PublicKey()
-			pkOld = &pk
+		if sk := crypto.Key(old.PrivateKey); sk.IsSet() {
+			skOld = &sk
 		}
 
-		if err := i.sendPeerDescription(pdiscproto.PeerDescriptionChange_UPDATE, pkOld); err != nil {
+		if err := i.sendPeerDescription(pdiscproto.PeerDescriptionChange_UPDATE, skOld); err != nil {
 			i.logger.Error("Failed to send peer description", zap.Error(err))
 		}
 	}
@@ -53,6 +53,25 @@ func (i *Interface) OnSignalingMessage(kp *crypto.PublicKeyPair, msg *signaling.
 			return
 		}
 
+		// Peers rotating their key sign the update with their new key
+		// and prove the possession of their old key in the description.
+		if d.PublicKeyNew != nil {
+			pkNew, err := crypto.ParseKeyBytes(d.PublicKeyNew)
+			if err != nil {
+				i.logger.Error("Failed to parse new public key", zap.Error(err))
+
+				return
+			}
+
+			if err := d.VerifyKeyRotation(crypto.Key(i.Settings.Community)); err != nil {
+				i.logger.Warn("Ignoring key rotation without proof of the old key", zap.Any("peer", pk), zap.Error(err))
+
+				return
+			}
+
+			pk = pkNew
+		}
+
 		if pk != kp.Theirs {
 			i.logger.Error("Received a peer description for from a wrong peer")
 
@@ -73,13 +92,59 @@ func (i *Interface) OnPeerDescription(d *pdiscproto.PeerDescription) error { //n
 		return fmt.Errorf("invalid public key: %w", err)
 	}
 
-	if !i.isAccepted(pk) {
-		i.logger.Warn("Ignoring non-whitelisted peer", zap.Any("peer", pk))
+	// Hold the filter until the peer has been configured.
+	// Otherwise, SetFilter() could miss a peer which has been accepted by the previous filter.
+	i.filterMu.RLock()
+	defer i.filterMu.RUnlock()
+
+	// Apply revocations gossiped by other community members
+	if len(i.cas) > 0 {
+		for _, r := range d.Revocations {
+			if _, err := i.applyRevocation(r); err != nil {
+				i.logger.Warn("Ignoring invalid revocation", zap.Any("peer", pk), zap.Error(err))
+			}
+		}
+	}
+
+	if i.isRevoked(pk) {
+		i.logger.Warn("Ignoring revoked peer", zap.Any("peer", pk))
+
+		return nil
+	}
+
+	cp := i.Peer(pk)
+
+	// Peers which became rejected by an updated description are removed
+	if !i.isAccepted(pk, d) {
+		i.logger.Warn("Ignoring peer rejected by whitelist or blacklist", zap.Any("peer", pk))
+
+		if cp != nil {
+			return i.dropPeer(pk)
+		}
 
 		return nil
 	}
 
-	cp := i.Peers[pk]
+	// Only accept members of the community which have been certified by one of our CAs
+	if len(i.cas) > 0 {
+		if _, err := d.VerifyCertificates(i.cas, time.Now()); err != nil {
+			i.logger.Warn("Ignoring peer without valid community certificate", zap.Any("peer", pk), zap.Error(err))
+
+			return nil
+		}
+	}
+
+	// Only peer with nodes which are selected by our topology policy.
+	// Already existing peers are removed in case their tags have changed.
+	if d.Change != pdiscproto.PeerDescriptionChange_REMOVE && !i.Settings.Topology.Accepts(i.Settings.Tags, d.Tags) {
+		i.logger.Debug("Ignoring peer which is not selected by the topology policy", zap.Any("peer", pk), zap.Any("tags", d.Tags))
+
+		if cp != nil {
+			return i.dropPeer(pk)
+		}
+
+		return nil
+	}
 
 	switch d.Change {
 	case pdiscproto.PeerDescriptionChange_ADD:
@@ -104,9 +169,40 @@ func (i *Interface) OnPeerDescription(d *pdiscproto.PeerDescription) error { //n
 		}
 	}
 
-	cfg := d.Config()
+	// The description is tracked by the new key of peers which rotated their key
+	pkDesc := pk
+	if pkNew, err := crypto.ParseKeyBytes(d.PublicKeyNew); d.PublicKeyNew != nil && err == nil {
+		pkDesc = pkNew
+
+		i.forget(pk)
+		i.release(pk)
+	}
+
+	i.refresh(pkDesc, d)
 
-	i.descs[pk] = d
+	// Periodic re-announcements only extend the lifetime of the peer
+	if cp != nil && i.isReannouncement(pk, d) {
+		return nil
+	}
+
+	i.descsMu.Lock()
+	if pkDesc != pk {
+		delete(i.descs, pk)
+	}
+	i.descs[pkDesc] = d
+	i.descsMu.Unlock()
+
+	// Only configure AllowedIPs which the peer is permitted to claim
+	if d.Change != pdiscproto.PeerDescriptionChange_REMOVE {
+		i.claim(pkDesc, d)
+
+		// Remember the relay offered by the peer for establishing connections to other peers
+		if epi := epdisc.Get(i.Interface); epi != nil {
+			epi.SetPeerRelay(pkDesc, d.Relay)
+		}
+	}
+
+	cfg := i.peerConfig(pkDesc, d)
 
 	switch d.Change {
 	case pdiscproto.PeerDescriptionChange_ADD:
@@ -146,6 +242,9 @@ func (i *Interface) OnPeerDescription(d *pdiscproto.PeerDescription) error { //n
 		}
 	}
 
+	// The advertised neighbors of the peer might have changed our routes
+	i.updateRoutes()
+
 	// Re-announce ourself in case this is a new peer we did not knew already
 	if cp == nil {
 		// TODO: Fix the race which requires the delay
@@ -163,4 +262,10 @@ func (i *Interface) OnPeerAdded(p *daemon.Peer) {
 	i.ApplyDescription(p)
 }
 
-func (i *Interface) OnPeerRemoved(_ *daemon.Peer) {}
+func (i *Interface) OnPeerRemoved(p *daemon.Peer) {
+	// Prefixes of the removed peer might now be claimed by others
+	i.release(p.PublicKey())
+	i.reclaim()
+
+	i.updateRoutes()
+}
@@ -9,12 +9,16 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"cunicu.li/cunicu/pkg/buildinfo"
+	"cunicu.li/cunicu/pkg/config"
 	"cunicu.li/cunicu/pkg/crypto"
 	"cunicu.li/cunicu/pkg/daemon"
+	"cunicu.li/cunicu/pkg/daemon/feature/epdisc"
 	"cunicu.li/cunicu/pkg/log"
 	proto "cunicu.li/cunicu/pkg/proto/core"
 	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
@@ -29,8 +33,44 @@ var Get = daemon.RegisterFeature(New, 60) //nolint:gochecknoglobals
 type Interface struct {
 	*daemon.Interface
 
-	filter map[crypto.Key]bool
-	descs  map[crypto.Key]*pdiscproto.PeerDescription
+	descs   map[crypto.Key]*pdiscproto.PeerDescription
+	descsMu sync.RWMutex
+
+	// Whitelist and blacklist which can be adjusted at runtime
+	filter   config.PeerFilter
+	filterMu sync.RWMutex
+
+	// Deadlines after which peers are removed if they did not re-announce themselves
+	expiries      map[crypto.Key]expiry
+	expiriesMu    sync.Mutex
+	onPeerExpired []PeerExpiredHandler
+
+	// AllowedIPs claimed by peers which have been accepted or rejected
+	claims          map[crypto.Key][]net.IPNet
+	rejected        map[crypto.Key][]net.IPNet
+	claimsMu        sync.RWMutex
+	onClaimRejected []ClaimRejectedHandler
+
+	stop chan struct{}
+
+	// Trusted community CAs and our own certificate chain issued by one of them
+	cas   []crypto.Key
+	certs []*pdiscproto.Certificate
+
+	// Revoked public keys of former community members
+	revoked   map[crypto.Key]*pdiscproto.Revocation
+	revokedMu sync.RWMutex
+
+	// Routes to peers which are reached via other peers and our neighbors last advertised
+	routes     map[crypto.Key]pdiscproto.Route
+	advertised []*pdiscproto.Neighbor
+
+	// Hostname or public key of the peer which is used as exit node
+	exitNode string
+	routesMu sync.RWMutex
+
+	// Serializes the reconfiguration of discovered peers
+	reconfigureMu sync.Mutex
 
 	logger *log.Logger
 }
@@ -42,17 +82,44 @@ func New(i *daemon.Interface) (*Interface, error) {
 
 	pd := &Interface{
 		Interface: i,
-		filter:    map[crypto.Key]bool{},
+		filter:    i.Settings.PeerFilter(),
 		descs:     map[crypto.Key]*pdiscproto.PeerDescription{},
+		revoked:   map[crypto.Key]*pdiscproto.Revocation{},
+		expiries:  map[crypto.Key]expiry{},
+		claims:    map[crypto.Key][]net.IPNet{},
+		rejected:  map[crypto.Key][]net.IPNet{},
+		routes:    map[crypto.Key]pdiscproto.Route{},
+		exitNode:  i.Settings.UseExitNode,
+		stop:      make(chan struct{}),
 		logger:    log.Global.Named("pdisc").With(zap.String("intf", i.Name())),
 	}
 
-	for _, k := range pd.Settings.Whitelist {
-		pd.filter[k] = true
+	if fn := pd.Settings.CommunityCertificate; fn != "" {
+		var err error
+		if pd.certs, err = pdiscproto.LoadCertificates(fn); err != nil {
+			return nil, fmt.Errorf("failed to load community certificate: %w", err)
+		}
 	}
 
-	for _, k := range pd.Settings.Blacklist {
-		pd.filter[k] = false
+	if pd.cas = pd.Settings.CommunityCA; len(pd.cas) > 0 {
+		if len(pd.certs) == 0 {
+			pd.logger.Warn("No community certificate configured. Other peers will not accept us")
+		} else if i.PrivateKey().IsSet() {
+			if _, err := pdiscproto.VerifyCertificateChain(pd.certs, i.PublicKey(), pd.cas, time.Now()); err != nil {
+				pd.logger.Warn("Invalid community certificate. Other peers will not accept us", zap.Error(err))
+			}
+		}
+
+		rs, err := loadRevocations()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load revocations: %w", err)
+		}
+
+		for _, r := range rs {
+			if _, err := pd.applyRevocation(r); err != nil {
+				pd.logger.Warn("Ignoring invalid revocation", zap.Error(err))
+			}
+		}
 	}
 
 	// Avoid sending a peer description if the interface does not have a private key yet
@@ -64,6 +131,7 @@ func New(i *daemon.Interface) (*Interface, error) {
 
 	i.AddModifiedHandler(pd)
 	i.AddPeerHandler(pd)
+	i.AddPeerStateChangeHandler(pd)
 
 	return pd, nil
 }
@@ -80,10 +148,14 @@ func (i *Interface) Start() error {
 		return fmt.Errorf("failed to subscribe on peer discovery channel: %w", err)
 	}
 
+	go i.run()
+
 	return nil
 }
 
 func (i *Interface) Close() error {
+	close(i.stop)
+
 	if err := i.sendPeerDescription(pdiscproto.PeerDescriptionChange_REMOVE, nil); err != nil {
 		i.logger.Error("Failed to send peer description", zap.Error(err))
 	}
@@ -92,6 +164,9 @@ func (i *Interface) Close() error {
 }
 
 func (i *Interface) Description(cp *daemon.Peer) *pdiscproto.PeerDescription {
+	i.descsMu.RLock()
+	defer i.descsMu.RUnlock()
+
 	if d, ok := i.descs[cp.PublicKey()]; ok {
 		return d
 	}
@@ -99,18 +174,17 @@ func (i *Interface) Description(cp *daemon.Peer) *pdiscproto.PeerDescription {
 	return nil
 }
 
-func (i *Interface) sendPeerDescription(chg pdiscproto.PeerDescriptionChange, pkOld *crypto.Key) error {
+// allowedIPs returns the AllowedIPs which remote peers should route to this interface.
+func (i *Interface) allowedIPs() []net.IPNet {
 	pk := i.PublicKey()
-
-	// Gather all allowed IPs for this interface
-	allowedIPs := []*net.IPNet{}
+	allowedIPs := []net.IPNet{}
 
 	// Static addresses
 	for _, addr := range i.Settings.Addresses {
 		_, bits := addr.Mask.Size()
 		addr.Mask = net.CIDRMask(bits, bits)
 
-		allowedIPs = append(allowedIPs, &addr)
+		allowedIPs = append(allowedIPs, addr)
 	}
 
 	// Auto-generated prefixes
@@ -120,20 +194,67 @@ func (i *Interface) sendPeerDescription(chg pdiscproto.PeerDescriptionChange, pk
 		_, bits := addr.Mask.Size()
 		addr.Mask = net.CIDRMask(bits, bits)
 
-		allowedIPs = append(allowedIPs, &addr)
+		allowedIPs = append(allowedIPs, addr)
 	}
 
 	// Other networks
-	for _, netw := range i.Settings.Networks {
-		allowedIPs = append(allowedIPs, &netw)
+	allowedIPs = append(allowedIPs, i.Settings.Networks...)
+
+	return allowedIPs
+}
+
+// isStatic returns true if the peer is configured statically in the settings of the interface.
+func (i *Interface) isStatic(pk crypto.Key) bool {
+	for _, p := range i.Settings.Peers {
+		if p.PublicKey == pk {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (i *Interface) sendPeerDescription(chg pdiscproto.PeerDescriptionChange, skOld *crypto.Key) error {
+	pk := i.PublicKey()
+
+	allowedIPs := []string{}
+	for _, aip := range i.allowedIPs() {
+		allowedIPs = append(allowedIPs, aip.String())
 	}
 
 	d := &pdiscproto.PeerDescription{
-		Change:     chg,
-		Name:       i.Settings.HostName,
-		AllowedIps: slices.String(allowedIPs),
-		BuildInfo:  buildinfo.BuildInfo(),
-		Hosts:      map[string]*pdiscproto.PeerAddresses{},
+		Change:       chg,
+		Name:         i.Settings.HostName,
+		AllowedIps:   allowedIPs,
+		BuildInfo:    buildinfo.BuildInfo(),
+		Hosts:        map[string]*pdiscproto.PeerAddresses{},
+		Certificates: i.certs,
+		Revocations:  i.revocations(),
+		Ttl:          uint32(i.Settings.PeerTTL.Seconds()),
+		Tags:         i.Settings.Tags,
+		ExitNode:     i.Settings.ExitNode,
+	}
+
+	if epi := epdisc.Get(i.Interface); epi != nil {
+		d.Relay = epi.RelayURL()
+	}
+
+	for _, s := range i.Settings.Services {
+		d.Services = append(d.Services, &proto.Service{
+			Name:     s.Name,
+			Port:     uint32(s.Port),     //nolint:gosec
+			Priority: uint32(s.Priority), //nolint:gosec
+			Weight:   uint32(s.Weight),   //nolint:gosec
+		})
+	}
+
+	// Advertise our neighbors so that remote peers can route via us
+	if i.Settings.MultiHopRouting && chg != pdiscproto.PeerDescriptionChange_REMOVE {
+		d.Neighbors = i.neighbors()
+
+		i.routesMu.Lock()
+		i.advertised = d.Neighbors
+		i.routesMu.Unlock()
 	}
 
 	for name, addrs := range i.Settings.ExtraHosts {
@@ -162,13 +283,19 @@ func (i *Interface) sendPeerDescription(chg pdiscproto.PeerDescriptionChange, pk
 		}
 	}
 
-	if pkOld != nil {
+	if skOld != nil {
 		if d.Change != pdiscproto.PeerDescriptionChange_UPDATE {
 			return errFailedUpdatePublicKey
 		}
 
 		d.PublicKeyNew = i.PublicKey().Bytes()
-		d.PublicKey = pkOld.Bytes()
+		d.PublicKey = skOld.PublicKey().Bytes()
+
+		// Prove the possession of the old key.
+		// The possession of the new key is proven by the signaling envelope.
+		if err := d.ProveKeyRotation(*skOld, crypto.Key(i.Settings.Community)); err != nil {
+			return fmt.Errorf("failed to prove key rotation: %w", err)
+		}
 	} else {
 		d.PublicKey = i.PublicKey().Bytes()
 	}
@@ -191,25 +318,63 @@ func (i *Interface) sendPeerDescription(chg pdiscproto.PeerDescriptionChange, pk
 	return nil
 }
 
-func (i *Interface) isAccepted(pk crypto.Key) bool {
-	if verdict, ok := i.filter[pk]; ok {
-		return verdict
+// isAccepted checks whether a peer is accepted by the whitelist and blacklist.
+// The caller must hold filterMu.
+func (i *Interface) isAccepted(pk crypto.Key, d *pdiscproto.PeerDescription) bool {
+	if i.isRevoked(pk) {
+		return false
 	}
 
-	return true
+	return i.filter.Accepts(pk, d.Name, d.Config().AllowedIPs)
 }
 
 func (i *Interface) ApplyDescription(cp *daemon.Peer) {
-	if d, ok := i.descs[cp.PublicKey()]; ok {
+	if d := i.Description(cp); d != nil {
 		cp.Name = d.Name
+		cp.Services = nil
+
+		// Services end up in the zone file of the hosts synchronization.
+		// Hence, we apply the same validation as for our own services.
+		for _, s := range d.Services {
+			ss := config.ServiceSettings{
+				Name:     s.Name,
+				Port:     int(s.Port),
+				Priority: int(s.Priority),
+				Weight:   int(s.Weight),
+			}
+
+			if err := ss.Check(); err != nil {
+				i.logger.Debug("Ignoring invalid service",
+					zap.Any("peer", cp.PublicKey()),
+					zap.Error(err))
+
+				continue
+			}
+
+			cp.Services = append(cp.Services, s)
+		}
 
 		if hosts := d.Hosts; len(hosts) > 0 {
 			cp.Hosts = map[string][]net.IP{}
+			claimed := i.claimed(cp.PublicKey())
 
 			for name, addrs := range hosts {
 				hs := []net.IP{}
 				for _, addr := range addrs.Addresses {
-					hs = append(hs, addr.Address())
+					// Peers may only advertise names for addresses which are routed to them
+					ip := addr.Address()
+					if !slices.Contains(claimed, func(n net.IPNet) bool {
+						return n.Contains(ip)
+					}) {
+						i.logger.Debug("Ignoring host address which is not covered by the AllowedIPs of the peer",
+							zap.Any("peer", cp.PublicKey()),
+							zap.String("name", name),
+							zap.Stringer("address", ip))
+
+						continue
+					}
+
+					hs = append(hs, ip)
 				}
 
 				cp.Hosts[name] = hs
@@ -217,3 +382,22 @@ func (i *Interface) ApplyDescription(cp *daemon.Peer) {
 		}
 	}
 }
+
+// dropPeer forgets the description of a discovered peer and removes it from the interface.
+func (i *Interface) dropPeer(pk crypto.Key) error {
+	i.descsMu.Lock()
+	delete(i.descs, pk)
+	i.descsMu.Unlock()
+
+	i.forget(pk)
+
+	if i.Peer(pk) == nil {
+		return nil
+	}
+
+	if err := i.RemovePeer(pk); err != nil {
+		return fmt.Errorf("failed to remove peer: %w", err)
+	}
+
+	return nil
+}
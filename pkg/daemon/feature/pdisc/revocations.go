@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/config"
+	"cunicu.li/cunicu/pkg/crypto"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+)
+
+var errNoCommunityCA = errors.New("revocations require a configured community CA")
+
+// revocationsMu serializes the access to the revocations file which is shared by all interfaces.
+var revocationsMu sync.Mutex //nolint:gochecknoglobals
+
+func loadRevocations() ([]*pdiscproto.Revocation, error) {
+	revocationsMu.Lock()
+	defer revocationsMu.Unlock()
+
+	rs, err := pdiscproto.LoadRevocations(config.RevocationsFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	return rs, err
+}
+
+func persistRevocation(r *pdiscproto.Revocation) error {
+	revocationsMu.Lock()
+	defer revocationsMu.Unlock()
+
+	rs, err := pdiscproto.LoadRevocations(config.RevocationsFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if slices.ContainsFunc(rs, func(s *pdiscproto.Revocation) bool {
+		return bytes.Equal(s.PublicKey, r.PublicKey)
+	}) {
+		return nil
+	}
+
+	buf, err := pdiscproto.MarshalRevocationsPEM(append(rs, r))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(config.RevocationsFile), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(config.RevocationsFile, buf, 0o600)
+}
+
+// Revoke applies a revocation, persists it and distributes it to the other community members.
+func (i *Interface) Revoke(r *pdiscproto.Revocation) error {
+	if _, err := i.applyRevocation(r); err != nil {
+		return err
+	}
+
+	return i.sendPeerDescription(pdiscproto.PeerDescriptionChange_ADD, nil)
+}
+
+// applyRevocation verifies a revocation and removes the revoked peer.
+// It returns true if the revocation has not been known before.
+func (i *Interface) applyRevocation(r *pdiscproto.Revocation) (bool, error) {
+	if len(i.cas) == 0 {
+		return false, errNoCommunityCA
+	}
+
+	if err := r.Verify(i.cas, time.Now()); err != nil {
+		return false, fmt.Errorf("invalid revocation: %w", err)
+	}
+
+	pk, err := crypto.ParseKeyBytes(r.PublicKey)
+	if err != nil {
+		return false, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	i.revokedMu.Lock()
+	_, known := i.revoked[pk]
+	i.revoked[pk] = r
+	i.revokedMu.Unlock()
+
+	if known {
+		return false, nil
+	}
+
+	i.logger.Warn("Revoked community membership of peer",
+		zap.Any("peer", pk),
+		zap.String("reason", r.Reason))
+
+	if err := persistRevocation(r); err != nil {
+		i.logger.Error("Failed to persist revocation", zap.Error(err))
+	}
+
+	i.descsMu.Lock()
+	delete(i.descs, pk)
+	i.descsMu.Unlock()
+
+	i.forget(pk)
+
+	if cp := i.Peer(pk); cp != nil {
+		if err := i.RemovePeer(pk); err != nil {
+			return true, fmt.Errorf("failed to remove peer: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+func (i *Interface) isRevoked(pk crypto.Key) bool {
+	i.revokedMu.RLock()
+	defer i.revokedMu.RUnlock()
+
+	_, ok := i.revoked[pk]
+
+	return ok
+}
+
+// revocations returns all revocations known to the interface.
+func (i *Interface) revocations() []*pdiscproto.Revocation {
+	i.revokedMu.RLock()
+	defer i.revokedMu.RUnlock()
+
+	rs := make([]*pdiscproto.Revocation, 0, len(i.revoked))
+	for _, r := range i.revoked {
+		rs = append(rs, r)
+	}
+
+	// Keep the order stable so that re-announcements are identical
+	slices.SortFunc(rs, func(a, b *pdiscproto.Revocation) int {
+		return bytes.Compare(a.PublicKey, b.PublicKey)
+	})
+
+	return rs
+}
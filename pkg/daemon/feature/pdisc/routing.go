@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc
+
+import (
+	"bytes"
+	"maps"
+	"net"
+	"slices"
+
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/daemon"
+	"cunicu.li/cunicu/pkg/daemon/feature/epdisc"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+)
+
+// Route returns the route to a peer which is reached via another peer.
+func (i *Interface) Route(cp *daemon.Peer) (pdiscproto.Route, bool) {
+	i.routesMu.RLock()
+	defer i.routesMu.RUnlock()
+
+	r, ok := i.routes[cp.PublicKey()]
+
+	return r, ok
+}
+
+// neighbors returns all peers which are currently reachable directly.
+func (i *Interface) neighbors() []*pdiscproto.Neighbor {
+	epi := epdisc.Get(i.Interface)
+
+	ns := []*pdiscproto.Neighbor{}
+
+	i.ForEachPeer(func(cp *daemon.Peer) error { //nolint:errcheck
+		r := cp.Reachability()
+
+		if epi != nil {
+			if epp, ok := epi.Peers[cp]; ok {
+				r = epp.Reachability()
+			}
+		}
+
+		if m := pdiscproto.LinkMetric(r); m > 0 {
+			ns = append(ns, &pdiscproto.Neighbor{
+				PublicKey: cp.PublicKey().Bytes(),
+				Metric:    m,
+			})
+		}
+
+		return nil
+	})
+
+	// Keep the order stable so that re-announcements are identical
+	slices.SortFunc(ns, func(a, b *pdiscproto.Neighbor) int {
+		return bytes.Compare(a.PublicKey, b.PublicKey)
+	})
+
+	return ns
+}
+
+// peerConfig returns the WireGuard configuration of a discovered peer.
+// With multi-hop routing, the allowed IPs of peers which are reached via
+// another peer are assigned to the next hop instead.
+// The selected exit node additionally receives the default routes.
+func (i *Interface) peerConfig(pk crypto.Key, d *pdiscproto.PeerDescription) wgtypes.PeerConfig {
+	cfg := d.Config()
+	cfg.AllowedIPs = i.claimed(pk)
+
+	if i.Settings.MultiHopRouting {
+		cfg.AllowedIPs = i.routedAllowedIPs(pk, cfg.AllowedIPs)
+	}
+
+	if i.isExitNode(pk) {
+		cfg.AllowedIPs = append(cfg.AllowedIPs, defaultRoutes...)
+	}
+
+	return cfg
+}
+
+// routedAllowedIPs returns the allowed IPs of a peer including those
+// of all destinations which are routed via the peer.
+func (i *Interface) routedAllowedIPs(pk crypto.Key, allowedIPs []net.IPNet) []net.IPNet {
+	i.routesMu.RLock()
+	defer i.routesMu.RUnlock()
+
+	if _, ok := i.routes[pk]; ok {
+		allowedIPs = nil
+	}
+
+	i.descsMu.RLock()
+	defer i.descsMu.RUnlock()
+
+	for dst, r := range i.routes {
+		if r.NextHop != pk {
+			continue
+		}
+
+		if _, ok := i.descs[dst]; ok {
+			allowedIPs = append(allowedIPs, i.claimed(dst)...)
+		}
+	}
+
+	return allowedIPs
+}
+
+// updateRoutes recomputes the routes to peers which are not directly reachable
+// and reconfigures the allowed IPs of all discovered peers if they changed.
+func (i *Interface) updateRoutes() {
+	if !i.Settings.MultiHopRouting {
+		return
+	}
+
+	i.descsMu.RLock()
+	routes := pdiscproto.ComputeRoutes(i.PublicKey(), i.neighbors(), i.descs)
+	i.descsMu.RUnlock()
+
+	i.routesMu.Lock()
+	changed := !maps.Equal(routes, i.routes)
+	i.routes = routes
+	i.routesMu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	i.logger.Debug("Routes changed", zap.Any("routes", routes))
+
+	i.reconfigurePeers()
+}
+
+// reconfigurePeers re-applies the WireGuard configuration of all discovered peers.
+// It is serialized as concurrent invocations could otherwise apply outdated configurations,
+// e.g. keep the default routes assigned to a previously selected exit node.
+func (i *Interface) reconfigurePeers() {
+	i.reconfigureMu.Lock()
+	defer i.reconfigureMu.Unlock()
+
+	i.descsMu.RLock()
+	descs := maps.Clone(i.descs)
+	i.descsMu.RUnlock()
+
+	for pk, d := range descs {
+		if i.Peer(pk) == nil {
+			continue
+		}
+
+		cfg := i.peerConfig(pk, d)
+		if err := i.UpdatePeer(&cfg); err != nil {
+			i.logger.Error("Failed to update peer", zap.Any("peer", pk), zap.Error(err))
+		}
+	}
+}
+
+func (i *Interface) OnPeerStateChanged(_ *daemon.Peer, _, _ daemon.PeerState) {
+	if !i.Settings.MultiHopRouting {
+		return
+	}
+
+	// Advertise changes of our neighbors to remote peers
+	ns := i.neighbors()
+
+	i.routesMu.Lock()
+	changed := !slices.EqualFunc(ns, i.advertised, func(a, b *pdiscproto.Neighbor) bool {
+		return bytes.Equal(a.PublicKey, b.PublicKey) && a.Metric == b.Metric
+	})
+	i.routesMu.Unlock()
+
+	if changed {
+		if err := i.sendPeerDescription(pdiscproto.PeerDescriptionChange_UPDATE, nil); err != nil {
+			i.logger.Error("Failed to send peer description", zap.Error(err))
+		}
+	}
+
+	i.updateRoutes()
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pske
+
+import (
+	"context"
+	"crypto/mlkem"
+
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/daemon"
+	pskeproto "cunicu.li/cunicu/pkg/proto/feature/pske"
+	"cunicu.li/cunicu/pkg/signaling"
+	"cunicu.li/cunicu/pkg/wg"
+)
+
+func (i *Interface) OnInterfaceModified(ci *daemon.Interface, old *wg.Interface, m daemon.InterfaceModifier) {
+	skOld := crypto.Key(old.PrivateKey)
+	if !m.Is(daemon.InterfaceModifiedPrivateKey) || !skOld.IsSet() || !ci.PrivateKey().IsSet() {
+		return
+	}
+
+	// Our subscriptions and pre-shared keys are bound to the old key
+	for _, p := range i.Peers {
+		if err := p.Resubscribe(context.Background(), skOld); err != nil {
+			p.logger.Error("Failed to update subscription", zap.Error(err))
+		}
+	}
+}
+
+func (i *Interface) OnPeerAdded(cp *daemon.Peer) {
+	if i.hasStaticPresharedKey(cp.PublicKey()) {
+		i.logger.Debug("Skipping peer with static pre-shared key", zap.String("peer", cp.String()))
+
+		return
+	}
+
+	p, err := NewPeer(cp, i)
+	if err != nil {
+		i.logger.Error("Failed to initialize peer", zap.Error(err))
+
+		return
+	}
+
+	i.Peers[cp] = p
+}
+
+func (i *Interface) OnPeerRemoved(cp *daemon.Peer) {
+	p, ok := i.Peers[cp]
+	if !ok {
+		return
+	}
+
+	if err := p.Close(); err != nil {
+		i.logger.Error("Failed to de-initialize peer", zap.Error(err))
+	}
+
+	delete(i.Peers, cp)
+}
+
+// OnSignalingMessage is invoked for every message received via the signaling backend.
+func (p *Peer) OnSignalingMessage(_ *crypto.PublicKeyPair, msg *signaling.Message) {
+	e := msg.Pske
+	if e == nil {
+		return
+	}
+
+	switch {
+	case e.CipherText != nil:
+		p.onCipherText(e)
+	case e.PublicKey != nil:
+		p.onPublicKey(e)
+	default:
+		p.onRequest()
+	}
+}
+
+// onRequest is invoked by the controlling peer if the remote peer asks for a new establishment.
+func (p *Peer) onRequest() {
+	if !p.IsControlling() {
+		p.logger.Warn("Ignoring establishment request as we are not controlling")
+
+		return
+	}
+
+	p.logger.Debug("Received request for pre-shared key establishment")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The initial establishment is still pending
+	if p.timer != nil {
+		p.timer.Reset(0)
+	}
+}
+
+// onPublicKey is invoked by the controlled peer for each received encapsulation key.
+func (p *Peer) onPublicKey(e *pskeproto.PresharedKeyEstablishment) {
+	if p.IsControlling() {
+		p.logger.Warn("Ignoring encapsulation key as we are controlling")
+
+		return
+	}
+
+	ek, err := mlkem.NewEncapsulationKey768(e.PublicKey)
+	if err != nil {
+		p.logger.Error("Received invalid encapsulation key", zap.Error(err))
+
+		return
+	}
+
+	ss, ct := ek.Encapsulate()
+
+	if err := p.send(&pskeproto.PresharedKeyEstablishment{
+		CipherText: ct,
+	}); err != nil {
+		p.logger.Error("Failed to send cipher text", zap.Error(err))
+
+		return
+	}
+
+	if err := p.setPresharedKey(ss); err != nil {
+		p.logger.Error("Failed to set pre-shared key", zap.Error(err))
+
+		return
+	}
+
+	p.logger.Info("Established new pre-shared key")
+}
+
+// onCipherText is invoked by the controlling peer for each received cipher text.
+func (p *Peer) onCipherText(e *pskeproto.PresharedKeyEstablishment) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.dk == nil {
+		p.logger.Warn("Ignoring cipher text without pending establishment")
+
+		return
+	}
+
+	ss, err := p.dk.Decapsulate(e.CipherText)
+	if err != nil {
+		p.logger.Error("Failed to decapsulate cipher text", zap.Error(err))
+
+		return
+	}
+
+	p.dk = nil
+
+	if err := p.setPresharedKey(ss); err != nil {
+		p.logger.Error("Failed to set pre-shared key", zap.Error(err))
+
+		return
+	}
+
+	if ri := p.Interface.Settings.RekeyInterval; ri > 0 {
+		p.timer.Reset(ri)
+	} else {
+		p.timer.Stop()
+	}
+
+	p.logger.Info("Established new pre-shared key")
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pske
+
+import (
+	"context"
+	"crypto/mlkem"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/daemon"
+	"cunicu.li/cunicu/pkg/log"
+	pskeproto "cunicu.li/cunicu/pkg/proto/feature/pske"
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+// retryInterval is the interval after which we re-initiate an
+// establishment if the remote peer did not respond in time.
+const retryInterval = 10 * time.Second
+
+type Peer struct {
+	*daemon.Peer
+	Interface *Interface
+
+	// dk is the ML-KEM decapsulation key of a pending
+	// establishment initiated by us.
+	dk    *mlkem.DecapsulationKey768
+	timer *time.Timer
+	mu    sync.Mutex
+
+	logger *log.Logger
+}
+
+func NewPeer(cp *daemon.Peer, i *Interface) (*Peer, error) {
+	p := &Peer{
+		Peer:      cp,
+		Interface: i,
+		logger: i.logger.Named("peer").With(
+			zap.String("peer", cp.String()),
+		),
+	}
+
+	kp := p.PublicPrivateKeyPair()
+	if _, err := i.Daemon.Backend.Subscribe(context.Background(), kp, p); err != nil {
+		return nil, fmt.Errorf("failed to subscribe to messages: %w", err)
+	}
+
+	p.establish()
+
+	return p, nil
+}
+
+// establish starts a new establishment.
+// The controlling peer initiates the establishment.
+// The controlled peer asks the controlling one to (re-)initiate
+// an establishment as we might have missed its initial attempt.
+func (p *Peer) establish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.IsControlling() {
+		if p.timer == nil {
+			p.timer = time.AfterFunc(0, p.initiate)
+		} else {
+			p.timer.Reset(0)
+		}
+	} else {
+		// Our role might have changed after a key rotation
+		if p.timer != nil {
+			p.timer.Stop()
+		}
+
+		if err := p.send(&pskeproto.PresharedKeyEstablishment{}); err != nil {
+			p.logger.Error("Failed to request key establishment", zap.Error(err))
+		}
+	}
+}
+
+// Resubscribe migrates the subscription after the private key of the interface has changed
+// and establishes a new pre-shared key with the remote peer.
+func (p *Peer) Resubscribe(ctx context.Context, skOld crypto.Key) error {
+	kpNew := p.PublicPrivateKeyPair()
+	if _, err := p.Interface.Daemon.Backend.Subscribe(ctx, kpNew, p); err != nil {
+		return fmt.Errorf("failed to subscribe to messages: %w", err)
+	}
+
+	kpOld := &crypto.KeyPair{
+		Ours:   skOld,
+		Theirs: p.PublicKey(),
+	}
+
+	if _, err := p.Interface.Daemon.Backend.Unsubscribe(ctx, kpOld, p); err != nil {
+		return fmt.Errorf("failed to unsubscribe from messages: %w", err)
+	}
+
+	p.establish()
+
+	return nil
+}
+
+// Close stops re-keying and unsubscribes from signaling messages of the peer.
+func (p *Peer) Close() error {
+	p.mu.Lock()
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.mu.Unlock()
+
+	kp := p.PublicPrivateKeyPair()
+	if _, err := p.Interface.Daemon.Backend.Unsubscribe(context.Background(), kp, p); err != nil {
+		return fmt.Errorf("failed to unsubscribe from messages: %w", err)
+	}
+
+	return nil
+}
+
+// initiate starts a new establishment by sending a fresh ML-KEM encapsulation key to the remote peer.
+func (p *Peer) initiate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	dk, err := mlkem.GenerateKey768()
+	if err != nil {
+		p.logger.Error("Failed to generate ML-KEM key", zap.Error(err))
+
+		return
+	}
+
+	p.dk = dk
+
+	if err := p.send(&pskeproto.PresharedKeyEstablishment{
+		PublicKey: dk.EncapsulationKey().Bytes(),
+	}); err != nil {
+		p.logger.Error("Failed to send encapsulation key", zap.Error(err))
+	}
+
+	// Retry if we do not receive a cipher text in time
+	p.timer.Reset(retryInterval)
+
+	p.logger.Debug("Initiated pre-shared key establishment")
+}
+
+func (p *Peer) send(e *pskeproto.PresharedKeyEstablishment) error {
+	msg := &signaling.Message{
+		Pske: e,
+	}
+
+	return p.Interface.Daemon.Backend.Publish(context.Background(), p.PublicPrivateKeyPair(), msg)
+}
+
+func (p *Peer) setPresharedKey(ss []byte) error {
+	psk, err := crypto.ParseKeyBytes(ss)
+	if err != nil {
+		return fmt.Errorf("invalid shared key: %w", err)
+	}
+
+	return p.SetPresharedKey(&psk)
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pske implements the establishment of post-quantum safe pre-shared keys (PSKs) using ML-KEM
+package pske
+
+import (
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/daemon"
+	"cunicu.li/cunicu/pkg/log"
+)
+
+var Get = daemon.RegisterFeature(New, 40) //nolint:gochecknoglobals
+
+type Interface struct {
+	*daemon.Interface
+
+	Peers map[*daemon.Peer]*Peer
+
+	logger *log.Logger
+}
+
+func New(i *daemon.Interface) (*Interface, error) {
+	if !i.Settings.EstablishPresharedKeys {
+		return nil, daemon.ErrFeatureDeactivated
+	}
+
+	p := &Interface{
+		Interface: i,
+		Peers:     map[*daemon.Peer]*Peer{},
+		logger:    log.Global.Named("pske").With(zap.String("intf", i.Name())),
+	}
+
+	i.AddModifiedHandler(p)
+	i.AddPeerHandler(p)
+
+	return p, nil
+}
+
+func (i *Interface) Start() error {
+	i.logger.Info("Started pre-shared key establishment")
+
+	return nil
+}
+
+func (i *Interface) Close() error {
+	for _, p := range i.Peers {
+		if err := p.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hasStaticPresharedKey checks if the user has configured a
+// pre-shared key for the peer which we must not overwrite.
+func (i *Interface) hasStaticPresharedKey(pk crypto.Key) bool {
+	for _, ps := range i.Settings.Peers {
+		if ps.PublicKey != pk {
+			continue
+		}
+
+		if ps.PresharedKey.IsSet() || crypto.Key(ps.PresharedKeyPassphrase).IsSet() {
+			return true
+		}
+	}
+
+	return false
+}
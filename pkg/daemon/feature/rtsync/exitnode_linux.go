@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package rtsync
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"cunicu.li/cunicu/pkg/link"
+	netx "cunicu.li/cunicu/pkg/net"
+)
+
+// ExitNodeTable is the routing table and firewall mark which is used for default routes
+// via an exit node, if the interface has no firewall mark configured.
+const ExitNodeTable = 51820
+
+// exitNodeTable returns the routing table for default routes via an exit node.
+// Like wg-quick, we use the firewall mark of the interface as table number.
+func (i *Interface) exitNodeTable() int {
+	if i.FirewallMark != 0 {
+		return i.FirewallMark
+	}
+
+	return ExitNodeTable
+}
+
+// addDefaultRoute routes all traffic via the exit node while excluding
+// the encapsulated traffic of the WireGuard socket by its firewall mark.
+// The sockets of the daemon itself, e.g. for ICE, TURN and signaling,
+// are excluded by their own firewall mark.
+func (i *Interface) addDefaultRoute(dst net.IPNet) error {
+	table := i.exitNodeTable()
+
+	if i.FirewallMark == 0 {
+		if err := i.ConfigureDevice(wgtypes.Config{
+			FirewallMark: &table,
+		}); err != nil {
+			return fmt.Errorf("failed to set firewall mark: %w", err)
+		}
+	}
+
+	// Replies of marked packets must pass the reverse path filter
+	if dst.IP.To4() != nil {
+		if err := os.WriteFile("/proc/sys/net/ipv4/conf/all/src_valid_mark", []byte("1"), 0o644); err != nil { //nolint:gosec
+			return fmt.Errorf("failed to enable src_valid_mark: %w", err)
+		}
+	}
+
+	return link.AddDefaultRoute(i.Device, dst, table, netx.DaemonMark)
+}
+
+// deleteDefaultRoute removes the default route via the exit node and its policy routing rules.
+func (i *Interface) deleteDefaultRoute(dst net.IPNet) error {
+	return link.DeleteDefaultRoute(i.Device, dst, i.exitNodeTable(), netx.DaemonMark)
+}
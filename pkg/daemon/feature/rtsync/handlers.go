@@ -58,6 +58,14 @@ func (i *Interface) OnPeerRemoved(p *daemon.Peer) {
 			zap.Any("peer", p),
 		)
 	}
+
+	for _, aip := range p.AllowedIPs {
+		if isDefaultRoute(aip) && !i.hasDefaultRoute(p, aip) {
+			if err := i.deleteDefaultRoute(aip); err != nil {
+				i.logger.Error("Failed to delete default route", zap.Error(err))
+			}
+		}
+	}
 }
 
 func (i *Interface) OnPeerModified(p *daemon.Peer, _ *wgtypes.Peer, _ daemon.PeerModifier, ipsAdded, ipsRemoved []net.IPNet) {
@@ -80,6 +88,22 @@ func (i *Interface) OnPeerModified(p *daemon.Peer, _ *wgtypes.Peer, _ daemon.Pee
 	}
 
 	for _, dst := range ipsAdded {
+		// Default routes of exit nodes require policy routing
+		if isDefaultRoute(dst) {
+			if err := i.addDefaultRoute(dst); err != nil {
+				i.logger.Error("Failed to add default route", zap.Error(err))
+
+				continue
+			}
+
+			i.logger.Info("Added default route via exit node",
+				zap.String("dst", dst.String()),
+				zap.Any("intf", p.Interface),
+				zap.Any("peer", p))
+
+			continue
+		}
+
 		var gw net.IP
 		if isV6 := dst.IP.To4() == nil; isV6 {
 			gw = gwV6
@@ -106,6 +130,18 @@ func (i *Interface) OnPeerModified(p *daemon.Peer, _ *wgtypes.Peer, _ daemon.Pee
 	}
 
 	for _, dst := range ipsRemoved {
+		if isDefaultRoute(dst) {
+			if i.hasDefaultRoute(p, dst) {
+				continue
+			}
+
+			if err := i.deleteDefaultRoute(dst); err != nil {
+				i.logger.Error("Failed to delete default route", zap.Error(err))
+			}
+
+			continue
+		}
+
 		if err := p.Interface.Device.DeleteRoute(dst, i.Settings.RoutingTable); err != nil && !errors.Is(err, syscall.ESRCH) {
 			i.logger.Error("Failed to delete route", zap.Error(err))
 
@@ -118,3 +154,30 @@ func (i *Interface) OnPeerModified(p *daemon.Peer, _ *wgtypes.Peer, _ daemon.Pee
 			zap.Any("peer", p))
 	}
 }
+
+func isDefaultRoute(n net.IPNet) bool {
+	ones, _ := n.Mask.Size()
+
+	return ones == 0
+}
+
+// hasDefaultRoute returns true if another peer still has a
+// default route of the same address family in its AllowedIPs.
+// This is the case while switching between exit nodes.
+func (i *Interface) hasDefaultRoute(p *daemon.Peer, dst net.IPNet) bool {
+	isV4 := dst.IP.To4() != nil
+
+	for _, q := range i.Peers {
+		if q == p {
+			continue
+		}
+
+		for _, aip := range q.AllowedIPs {
+			if isDefaultRoute(aip) && (aip.IP.To4() != nil) == isV4 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -6,6 +6,8 @@
 package rtsync
 
 import (
+	"net"
+
 	"cunicu.li/cunicu/pkg/daemon"
 )
 
@@ -20,3 +22,11 @@ func (i *Interface) syncKernel() error {
 func (i *Interface) watchKernel() error {
 	return errNotSupported
 }
+
+func (i *Interface) addDefaultRoute(_ net.IPNet) error {
+	return errNotSupported
+}
+
+func (i *Interface) deleteDefaultRoute(_ net.IPNet) error {
+	return errNotSupported
+}
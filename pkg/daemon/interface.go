@@ -6,6 +6,9 @@ package daemon
 import (
 	"fmt"
 	"io"
+	"maps"
+	"slices"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -29,7 +32,10 @@ type Interface struct {
 	// OS abstractions for kernel device
 	device.Device
 
-	Peers map[crypto.Key]*Peer
+	// Peers is only modified by the watcher while holding peersMu.
+	// Other goroutines must use Peer() or ForEachPeer() instead.
+	Peers   map[crypto.Key]*Peer
+	peersMu sync.RWMutex
 
 	LastSync time.Time
 
@@ -109,6 +115,30 @@ func (i *Interface) PrivateKey() crypto.Key {
 	return crypto.Key(i.Interface.PrivateKey)
 }
 
+// Peer returns the peer with the public key pk or nil if there is none.
+func (i *Interface) Peer(pk crypto.Key) *Peer {
+	i.peersMu.RLock()
+	defer i.peersMu.RUnlock()
+
+	return i.Peers[pk]
+}
+
+// ForEachPeer invokes cb for each peer of the interface.
+// The callback is invoked for a snapshot of the peers and may hence add or remove peers itself.
+func (i *Interface) ForEachPeer(cb func(p *Peer) error) error {
+	i.peersMu.RLock()
+	peers := slices.Collect(maps.Values(i.Peers))
+	i.peersMu.RUnlock()
+
+	for _, p := range peers {
+		if err := cb(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (i *Interface) WireGuardConfig() *wgtypes.Config {
 	cfg := &wgtypes.Config{
 		PrivateKey:   (*wgtypes.Key)(i.PrivateKey().Bytes()),
@@ -116,9 +146,11 @@ func (i *Interface) WireGuardConfig() *wgtypes.Config {
 		FirewallMark: &i.FirewallMark,
 	}
 
-	for _, peer := range i.Peers {
-		cfg.Peers = append(cfg.Peers, *peer.WireGuardConfig())
-	}
+	i.ForEachPeer(func(p *Peer) error { //nolint:errcheck
+		cfg.Peers = append(cfg.Peers, *p.WireGuardConfig())
+
+		return nil
+	})
 
 	return cfg
 }
@@ -153,11 +185,13 @@ func (i *Interface) MarshalWithPeers(cb func(p *Peer) *coreproto.Peer) *coreprot
 		}
 	}
 
-	for _, p := range i.Peers {
+	i.ForEachPeer(func(p *Peer) error { //nolint:errcheck
 		if qp := cb(p); qp != nil {
 			q.Peers = append(q.Peers, qp)
 		}
-	}
+
+		return nil
+	})
 
 	if !i.LastSync.IsZero() {
 		q.LastSyncTimestamp = proto.Time(i.LastSync)
@@ -302,7 +336,9 @@ func (i *Interface) syncInterface(newIntf *wgtypes.Device) {
 
 		i.logger.Info("Removed peer", zap.Any("peer", p.PublicKey()))
 
+		i.peersMu.Lock()
 		delete(i.Peers, pk)
+		i.peersMu.Unlock()
 
 		for _, h := range i.onPeer {
 			h.OnPeerRemoved(p)
@@ -322,7 +358,9 @@ func (i *Interface) syncInterface(newIntf *wgtypes.Device) {
 			)
 		}
 
+		i.peersMu.Lock()
 		i.Peers[p.PublicKey()] = p
+		i.peersMu.Unlock()
 
 		for _, h := range i.onPeer {
 			h.OnPeerAdded(p)
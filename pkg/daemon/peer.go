@@ -25,8 +25,9 @@ import (
 type Peer struct {
 	*wgtypes.Peer
 
-	Name  string
-	Hosts map[string][]net.IP
+	Name     string
+	Hosts    map[string][]net.IP
+	Services []*coreproto.Service
 
 	Interface *Interface
 
@@ -305,6 +306,7 @@ func (p *Peer) Marshal() *coreproto.Peer {
 		AllowedIps:                  allowedIPs,
 		ProtocolVersion:             uint32(p.ProtocolVersion), //nolint:gosec
 		Reachability:                p.Reachability(),
+		Services:                    p.Services,
 	}
 
 	if p.Endpoint != nil {
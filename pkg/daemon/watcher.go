@@ -206,7 +206,9 @@ func (w *Watcher) syncInterfaces() error {
 			h.OnInterfaceRemoved(i)
 		}
 
+		w.mu.Lock()
 		delete(w.interfaces, wgd.Name)
+		w.mu.Unlock()
 	}
 
 	for _, wgd := range added {
@@ -230,7 +232,9 @@ func (w *Watcher) syncInterfaces() error {
 
 		i.syncInterface(&wgdCopy)
 
+		w.mu.Lock()
 		w.interfaces[wgd.Name] = i
+		w.mu.Unlock()
 	}
 
 	for _, wgd := range kept {
@@ -255,11 +259,7 @@ func (w *Watcher) Peer(intf string, pk *crypto.Key) *Peer {
 		return nil
 	}
 
-	if p, ok := i.Peers[*pk]; ok {
-		return p
-	}
-
-	return nil
+	return i.Peer(*pk)
 }
 
 func (w *Watcher) PeerByPublicKey(pk *crypto.Key) *Peer {
@@ -267,7 +267,7 @@ func (w *Watcher) PeerByPublicKey(pk *crypto.Key) *Peer {
 	defer w.mu.RUnlock()
 
 	for _, i := range w.interfaces {
-		if p, ok := i.Peers[*pk]; ok {
+		if p := i.Peer(*pk); p != nil {
 			return p
 		}
 	}
@@ -311,12 +311,6 @@ func (w *Watcher) ForEachInterface(cb func(i *Interface) error) error {
 
 func (w *Watcher) ForEachPeer(cb func(p *Peer) error) error {
 	return w.ForEachInterface(func(i *Interface) error {
-		for _, p := range i.Peers {
-			if err := cb(p); err != nil {
-				return err
-			}
-		}
-
-		return nil
+		return i.ForEachPeer(cb)
 	})
 }
@@ -0,0 +1,337 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package ice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/pion/stun/v3"
+
+	netx "cunicu.li/cunicu/pkg/net"
+)
+
+// NATBehavior is the mapping or filtering behavior of a NAT as classified by RFC 5780.
+type NATBehavior int
+
+const (
+	NATBehaviorUnknown NATBehavior = iota
+	NATBehaviorEndpointIndependent
+	NATBehaviorAddressDependent
+	NATBehaviorAddressAndPortDependent
+)
+
+// Flags of the CHANGE-REQUEST attribute (RFC 5780 Sect. 7.2).
+const (
+	changeIP   = 0x04
+	changePort = 0x02
+)
+
+// DefaultNATDiscoveryTimeout is the time to wait for a response to a single STUN test.
+const DefaultNATDiscoveryTimeout = 2 * time.Second
+
+var (
+	errNoResponse     = errors.New("no response from STUN server")
+	errNoOtherAddress = errors.New("STUN server does not support NAT behavior discovery")
+	errWrongSource    = errors.New("STUN server responded from an unexpected address")
+	errInvalidAddress = errors.New("invalid local address")
+)
+
+func (b NATBehavior) String() string {
+	switch b {
+	case NATBehaviorEndpointIndependent:
+		return "endpoint-independent"
+	case NATBehaviorAddressDependent:
+		return "address-dependent"
+	case NATBehaviorAddressAndPortDependent:
+		return "address-and-port-dependent"
+	default:
+		return "unknown"
+	}
+}
+
+// NATDiscovery is the result of the NAT behavior discovery.
+type NATDiscovery struct {
+	Mapping   NATBehavior
+	Filtering NATBehavior
+
+	// Our address as seen by the STUN server
+	MappedAddress *net.UDPAddr
+
+	// Our address from which the tests have been performed
+	LocalAddress *net.UDPAddr
+}
+
+// BehindNAT checks if the mapped address differs from our local address.
+func (d *NATDiscovery) BehindNAT() bool {
+	return netx.CmpUDPAddr(d.MappedAddress, d.LocalAddress) != 0
+}
+
+// DiscoverNATBehavior classifies the mapping and filtering behavior of the NAT
+// in front of us by the tests described in RFC 5780 Sect. 4.3 and 4.4.
+// The STUN server must support the OTHER-ADDRESS and CHANGE-REQUEST attributes.
+func DiscoverNATBehavior(ctx context.Context, server *net.UDPAddr, timeout time.Duration) (*NATDiscovery, error) {
+	if timeout == 0 {
+		timeout = DefaultNATDiscoveryTimeout
+	}
+
+	network := "udp4"
+	if server.IP.To4() == nil {
+		network = "udp6"
+	}
+
+	t, err := newNATTester(ctx, network, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	defer t.Close()
+
+	// Test I: Determine mapped address and the alternate address of the server
+	resp, _, err := t.bindingRequest(server, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var other stun.OtherAddress
+	if err := other.GetFrom(resp); err != nil {
+		return nil, errNoOtherAddress
+	}
+
+	d := &NATDiscovery{}
+
+	if d.MappedAddress, err = mappedAddress(resp); err != nil {
+		return nil, err
+	}
+
+	if d.LocalAddress, err = localAddress(t.conn, server); err != nil {
+		return nil, err
+	}
+
+	otherAddr := &net.UDPAddr{IP: other.IP, Port: other.Port}
+
+	if d.Mapping, err = t.mappingBehavior(server, d.MappedAddress, otherAddr); err != nil {
+		return nil, err
+	}
+
+	// The mapping tests have opened the NAT towards the alternate address of the server.
+	// Hence, the filtering tests are performed from a fresh socket.
+	ft, err := newNATTester(ctx, network, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	defer ft.Close()
+
+	if d.Filtering, err = ft.filteringBehavior(server, otherAddr); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+type natTester struct {
+	ctx     context.Context //nolint:containedctx
+	conn    *net.UDPConn
+	timeout time.Duration
+	stop    func() bool
+}
+
+func newNATTester(ctx context.Context, network string, timeout time.Duration) (*natTester, error) {
+	conn, err := netx.ListenUDP(network, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	t := &natTester{
+		ctx:     ctx,
+		conn:    conn,
+		timeout: timeout,
+	}
+
+	t.stop = context.AfterFunc(ctx, func() {
+		conn.SetReadDeadline(time.Now()) //nolint:errcheck
+	})
+
+	return t, nil
+}
+
+func (t *natTester) Close() error {
+	t.stop()
+
+	return t.conn.Close()
+}
+
+// mappingBehavior performs tests II and III of RFC 5780 Sect. 4.3.
+func (t *natTester) mappingBehavior(server *net.UDPAddr, mapped1, other *net.UDPAddr) (NATBehavior, error) {
+	// Test II: Send to the alternate address but primary port
+	resp, _, err := t.bindingRequest(&net.UDPAddr{IP: other.IP, Port: server.Port}, 0)
+	if err != nil {
+		return NATBehaviorUnknown, err
+	}
+
+	mapped2, err := mappedAddress(resp)
+	if err != nil {
+		return NATBehaviorUnknown, err
+	}
+
+	if netx.CmpUDPAddr(mapped1, mapped2) == 0 {
+		return NATBehaviorEndpointIndependent, nil
+	}
+
+	// Test III: Send to the alternate address and port
+	if resp, _, err = t.bindingRequest(other, 0); err != nil {
+		return NATBehaviorUnknown, err
+	}
+
+	mapped3, err := mappedAddress(resp)
+	if err != nil {
+		return NATBehaviorUnknown, err
+	}
+
+	if netx.CmpUDPAddr(mapped2, mapped3) == 0 {
+		return NATBehaviorAddressDependent, nil
+	}
+
+	return NATBehaviorAddressAndPortDependent, nil
+}
+
+// filteringBehavior performs tests I, II and III of RFC 5780 Sect. 4.4.
+// The responses must originate from the requested alternate address and port.
+// Otherwise, the server has ignored the CHANGE-REQUEST attribute.
+func (t *natTester) filteringBehavior(server, other *net.UDPAddr) (NATBehavior, error) {
+	// Test I: Create a mapping towards the primary address and port only
+	if _, _, err := t.bindingRequest(server, 0); err != nil {
+		return NATBehaviorUnknown, err
+	}
+
+	// Test II: Request a response from the alternate address and port
+	if _, src, err := t.bindingRequest(server, changeIP|changePort); err == nil {
+		if netx.CmpUDPAddr(src, other) != 0 {
+			return NATBehaviorUnknown, fmt.Errorf("%w: %s", errWrongSource, src)
+		}
+
+		return NATBehaviorEndpointIndependent, nil
+	} else if !errors.Is(err, errNoResponse) {
+		return NATBehaviorUnknown, err
+	}
+
+	// Test III: Request a response from the alternate port only
+	if _, src, err := t.bindingRequest(server, changePort); err == nil {
+		if netx.CmpUDPAddr(src, &net.UDPAddr{IP: server.IP, Port: other.Port}) != 0 {
+			return NATBehaviorUnknown, fmt.Errorf("%w: %s", errWrongSource, src)
+		}
+
+		return NATBehaviorAddressDependent, nil
+	} else if !errors.Is(err, errNoResponse) {
+		return NATBehaviorUnknown, err
+	}
+
+	return NATBehaviorAddressAndPortDependent, nil
+}
+
+// bindingRequest sends a STUN binding request and waits for the response.
+// The request is retransmitted until the timeout expires.
+// It returns the response and the address from which it has been received.
+func (t *natTester) bindingRequest(dst *net.UDPAddr, change byte) (*stun.Message, *net.UDPAddr, error) {
+	setters := []stun.Setter{stun.TransactionID, stun.BindingRequest}
+	if change != 0 {
+		setters = append(setters, stun.RawAttribute{
+			Type:  stun.AttrChangeRequest,
+			Value: []byte{0, 0, 0, change},
+		})
+	}
+
+	req, err := stun.Build(setters...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deadline := time.Now().Add(t.timeout)
+	buf := make([]byte, 1500)
+
+	for rto := 250 * time.Millisecond; time.Now().Before(deadline); rto *= 2 {
+		if err := t.ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		if _, err := t.conn.WriteToUDP(req.Raw, dst); err != nil {
+			return nil, nil, fmt.Errorf("failed to send request: %w", err)
+		}
+
+		readDeadline := time.Now().Add(rto)
+		if readDeadline.After(deadline) {
+			readDeadline = deadline
+		}
+
+		if err := t.conn.SetReadDeadline(readDeadline); err != nil {
+			return nil, nil, err
+		}
+
+		for {
+			n, src, err := t.conn.ReadFromUDP(buf)
+			if err != nil {
+				var nerr net.Error
+				if errors.As(err, &nerr) && nerr.Timeout() {
+					break // Retransmit
+				}
+
+				return nil, nil, err
+			}
+
+			resp := &stun.Message{}
+			if err := stun.Decode(buf[:n], resp); err != nil || resp.TransactionID != req.TransactionID {
+				continue
+			}
+
+			return resp, src, nil
+		}
+	}
+
+	return nil, nil, errNoResponse
+}
+
+func mappedAddress(m *stun.Message) (*net.UDPAddr, error) {
+	var xor stun.XORMappedAddress
+	if err := xor.GetFrom(m); err == nil {
+		return &net.UDPAddr{IP: xor.IP, Port: xor.Port}, nil
+	}
+
+	var mapped stun.MappedAddress
+	if err := mapped.GetFrom(m); err != nil {
+		return nil, fmt.Errorf("missing mapped address: %w", err)
+	}
+
+	return &net.UDPAddr{IP: mapped.IP, Port: mapped.Port}, nil
+}
+
+// localAddress returns the address of conn as used to reach the server.
+func localAddress(conn *net.UDPConn, server *net.UDPAddr) (*net.UDPAddr, error) {
+	laddr, ok := conn.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errInvalidAddress, conn.LocalAddr())
+	}
+
+	if !laddr.IP.IsUnspecified() {
+		return laddr, nil
+	}
+
+	// Determine the source IP which the kernel selects for the server
+	c, err := net.DialUDP("udp", nil, server)
+	if err != nil {
+		return nil, err
+	}
+
+	defer c.Close()
+
+	raddr, ok := c.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errInvalidAddress, c.LocalAddr())
+	}
+
+	return &net.UDPAddr{IP: raddr.IP, Port: laddr.Port}, nil
+}
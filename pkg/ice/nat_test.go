@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package ice_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/stun/v3"
+
+	icex "cunicu.li/cunicu/pkg/ice"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// stunServer is a stand-in for a STUN server supporting RFC 5780 which listens on two addresses and two ports.
+// It simulates the mapping and filtering behavior of a NAT in front of the client.
+type stunServer struct {
+	ips   [2]net.IP
+	ports [2]int
+	conns [2][2]*net.UDPConn
+
+	nat       bool
+	mapping   icex.NATBehavior
+	filtering icex.NATBehavior
+
+	// Server addresses and ports which the clients have sent to
+	// indexed by the address of the client
+	sent   map[string]map[[2]int]bool
+	sentMu sync.Mutex
+
+	// Respond from the primary address and port regardless of the CHANGE-REQUEST attribute
+	ignoreChange atomic.Bool
+}
+
+func newSTUNServer(nat bool, mapping, filtering icex.NATBehavior) (*stunServer, error) {
+	s := &stunServer{
+		ips:       [2]net.IP{net.IPv4(127, 0, 0, 1), net.IPv4(127, 0, 0, 2)},
+		nat:       nat,
+		mapping:   mapping,
+		filtering: filtering,
+		sent:      map[string]map[[2]int]bool{},
+	}
+
+	for pi := range 2 {
+		for ii := range 2 {
+			conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: s.ips[ii], Port: s.ports[pi]})
+			if err != nil {
+				s.Close()
+
+				return nil, err
+			}
+
+			s.ports[pi] = conn.LocalAddr().(*net.UDPAddr).Port //nolint:forcetypeassert
+			s.conns[ii][pi] = conn
+
+			go s.serve(ii, pi)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *stunServer) Addr() *net.UDPAddr {
+	return &net.UDPAddr{IP: s.ips[0], Port: s.ports[0]}
+}
+
+func (s *stunServer) Close() {
+	for _, conns := range s.conns {
+		for _, conn := range conns {
+			if conn != nil {
+				conn.Close()
+			}
+		}
+	}
+}
+
+func (s *stunServer) serve(ii, pi int) {
+	buf := make([]byte, 1500)
+
+	for {
+		n, src, err := s.conns[ii][pi].ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		req := &stun.Message{}
+		if err := stun.Decode(buf[:n], req); err != nil {
+			continue
+		}
+
+		// Simulate the mapping behavior by the reported port
+		mapped := *src
+		if s.nat {
+			mapped.Port += 1000
+
+			switch s.mapping {
+			case icex.NATBehaviorAddressDependent:
+				mapped.Port += 100 * ii
+			case icex.NATBehaviorAddressAndPortDependent:
+				mapped.Port += 100*ii + 10*pi
+			default:
+			}
+		}
+
+		// Respond from another address and/or port if requested
+		rii, rpi := ii, pi
+
+		if v, err := req.Get(stun.AttrChangeRequest); err == nil && len(v) == 4 && !s.ignoreChange.Load() {
+			if v[3]&0x04 != 0 {
+				rii = 1 - ii
+			}
+
+			if v[3]&0x02 != 0 {
+				rpi = 1 - pi
+			}
+		}
+
+		// Simulate the filtering behavior by dropping responses
+		if !s.pass(src, ii, pi, rii, rpi) {
+			continue
+		}
+
+		resp, err := stun.Build(
+			stun.NewTransactionIDSetter(req.TransactionID),
+			stun.BindingSuccess,
+			&stun.XORMappedAddress{IP: mapped.IP, Port: mapped.Port},
+			&stun.OtherAddress{IP: s.ips[1], Port: s.ports[1]},
+		)
+		if err != nil {
+			continue
+		}
+
+		s.conns[rii][rpi].WriteToUDP(resp.Raw, src) //nolint:errcheck
+	}
+}
+
+// pass records that the client has sent to the server address ii and port pi
+// and checks if the NAT lets a response from address rii and port rpi pass.
+func (s *stunServer) pass(src *net.UDPAddr, ii, pi, rii, rpi int) bool {
+	s.sentMu.Lock()
+	defer s.sentMu.Unlock()
+
+	sent, ok := s.sent[src.String()]
+	if !ok {
+		sent = map[[2]int]bool{}
+		s.sent[src.String()] = sent
+	}
+
+	sent[[2]int{ii, pi}] = true
+
+	if !s.nat {
+		return true
+	}
+
+	switch s.filtering {
+	case icex.NATBehaviorAddressDependent:
+		return sent[[2]int{rii, 0}] || sent[[2]int{rii, 1}]
+	case icex.NATBehaviorAddressAndPortDependent:
+		return sent[[2]int{rii, rpi}]
+	default:
+		return true
+	}
+}
+
+var _ = Describe("NAT behavior discovery", func() {
+	behaviors := []icex.NATBehavior{
+		icex.NATBehaviorEndpointIndependent,
+		icex.NATBehaviorAddressDependent,
+		icex.NATBehaviorAddressAndPortDependent,
+	}
+
+	discover := func(nat bool, mapping, filtering icex.NATBehavior) *icex.NATDiscovery {
+		s, err := newSTUNServer(nat, mapping, filtering)
+		Expect(err).To(Succeed())
+
+		defer s.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		d, err := icex.DiscoverNATBehavior(ctx, s.Addr(), 500*time.Millisecond)
+		Expect(err).To(Succeed())
+
+		return d
+	}
+
+	It("detects the absence of a NAT", func() {
+		d := discover(false, icex.NATBehaviorEndpointIndependent, icex.NATBehaviorEndpointIndependent)
+
+		Expect(d.BehindNAT()).To(BeFalse())
+		Expect(d.Mapping).To(Equal(icex.NATBehaviorEndpointIndependent))
+		Expect(d.Filtering).To(Equal(icex.NATBehaviorEndpointIndependent))
+	})
+
+	for _, mapping := range behaviors {
+		It("classifies "+mapping.String()+" mapping", func() {
+			d := discover(true, mapping, icex.NATBehaviorEndpointIndependent)
+
+			Expect(d.BehindNAT()).To(BeTrue())
+			Expect(d.Mapping).To(Equal(mapping))
+		})
+	}
+
+	for _, filtering := range behaviors {
+		It("classifies "+filtering.String()+" filtering", func() {
+			d := discover(true, icex.NATBehaviorEndpointIndependent, filtering)
+
+			Expect(d.Filtering).To(Equal(filtering))
+		})
+	}
+
+	It("fails for servers ignoring the CHANGE-REQUEST attribute", func() {
+		s, err := newSTUNServer(true, icex.NATBehaviorEndpointIndependent, icex.NATBehaviorEndpointIndependent)
+		Expect(err).To(Succeed())
+
+		defer s.Close()
+
+		s.ignoreChange.Store(true)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err = icex.DiscoverNATBehavior(ctx, s.Addr(), 500*time.Millisecond)
+		Expect(err).To(MatchError(ContainSubstring("unexpected address")))
+	})
+
+	It("fails for servers without RFC 5780 support", func() {
+		conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		Expect(err).To(Succeed())
+
+		defer conn.Close()
+
+		go func() {
+			buf := make([]byte, 1500)
+
+			n, src, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+
+			req := &stun.Message{}
+			if err := stun.Decode(buf[:n], req); err != nil {
+				return
+			}
+
+			resp, _ := stun.Build(
+				stun.NewTransactionIDSetter(req.TransactionID),
+				stun.BindingSuccess,
+				&stun.XORMappedAddress{IP: src.IP, Port: src.Port},
+			)
+
+			conn.WriteToUDP(resp.Raw, src) //nolint:errcheck
+		}()
+
+		_, err = icex.DiscoverNATBehavior(context.Background(), conn.LocalAddr().(*net.UDPAddr), 500*time.Millisecond) //nolint:forcetypeassert
+		Expect(err).To(HaveOccurred())
+	})
+})
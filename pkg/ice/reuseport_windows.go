@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package ice
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// reusePort allows a listening and a connecting TCP socket to share the same local port.
+func reusePort(_, _ string, c syscall.RawConn) error {
+	var serr error
+
+	if err := c.Control(func(fd uintptr) {
+		serr = windows.SetsockoptInt(windows.Handle(fd), windows.SOL_SOCKET, windows.SO_REUSEADDR, 1)
+	}); err != nil {
+		return err
+	}
+
+	return serr
+}
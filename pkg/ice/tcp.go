@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package ice
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pion/ice/v4"
+	"github.com/pion/stun/v3"
+
+	netx "cunicu.li/cunicu/pkg/net"
+)
+
+// Size of the STUN message header (RFC 8489 Sect. 5).
+const stunHeaderSize = 20
+
+var errUnexpectedResponse = errors.New("unexpected STUN response")
+
+// ListenTCP creates a listener for passive ICE-TCP candidates (RFC 6544).
+// The port of the listener can be shared with DiscoverTCPMappedAddress
+// in order to discover its server reflexive address.
+func ListenTCP(ctx context.Context, network string, port int) (*net.TCPListener, error) {
+	lc := &net.ListenConfig{
+		Control: reusePortAndMark,
+	}
+
+	l, err := lc.Listen(ctx, network, net.JoinHostPort("", strconv.Itoa(port)))
+	if err != nil {
+		return nil, err
+	}
+
+	return l.(*net.TCPListener), nil //nolint:forcetypeassert
+}
+
+// DiscoverTCPMappedAddress sends a STUN binding request over TCP from the local port
+// of a listener created by ListenTCP and returns the mapped and local addresses.
+// The mapped address can be advertised as a passive server reflexive candidate.
+func DiscoverTCPMappedAddress(ctx context.Context, port int, server *net.TCPAddr) (mapped, local *net.TCPAddr, err error) {
+	network := "tcp4"
+	if server.IP.To4() == nil {
+		network = "tcp6"
+	}
+
+	d := &net.Dialer{
+		LocalAddr: &net.TCPAddr{Port: port},
+		Control:   reusePortAndMark,
+		Timeout:   DefaultNATDiscoveryTimeout,
+	}
+
+	conn, err := d.DialContext(ctx, network, server.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(DefaultNATDiscoveryTimeout)); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := stun.Build(stun.TransactionID, stun.BindingRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := conn.Write(req.Raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	// STUN messages are self-delimiting over stream transports (RFC 8489 Sect. 6.2.2)
+	buf := make([]byte, stunHeaderSize)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	buf = append(buf, make([]byte, binary.BigEndian.Uint16(buf[2:4]))...)
+	if _, err := io.ReadFull(conn, buf[stunHeaderSize:]); err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	resp := &stun.Message{}
+	if err := stun.Decode(buf, resp); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if resp.TransactionID != req.TransactionID || resp.Type != stun.BindingSuccess {
+		return nil, nil, errUnexpectedResponse
+	}
+
+	addr, err := mappedAddress(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mapped = &net.TCPAddr{IP: addr.IP, Port: addr.Port}
+
+	local, ok := conn.LocalAddr().(*net.TCPAddr)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", errInvalidAddress, conn.LocalAddr())
+	}
+
+	return mapped, local, nil
+}
+
+// reusePortAndMark allows the listener and the discovery connection to share a port
+// and marks their sockets as traffic of the daemon.
+func reusePortAndMark(network, address string, c syscall.RawConn) error {
+	if err := reusePort(network, address, c); err != nil {
+		return err
+	}
+
+	return netx.MarkControl(network, address, c)
+}
+
+// NewTCPMappedCandidate returns a passive server reflexive ICE-TCP candidate
+// for the addresses returned by DiscoverTCPMappedAddress.
+func NewTCPMappedCandidate(mapped, local *net.TCPAddr) (ice.Candidate, error) {
+	c, err := ice.NewCandidateServerReflexive(&ice.CandidateServerReflexiveConfig{
+		Network:   "tcp",
+		Address:   mapped.IP.String(),
+		Port:      mapped.Port,
+		Component: ice.ComponentRTP,
+		RelAddr:   local.IP.String(),
+		RelPort:   local.Port,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// pion/ice has no TCP type in the config of server reflexive candidates.
+	// Setting it afterwards also yields the priority of a passive server
+	// reflexive candidate (RFC 6544 Sect. 4.2).
+	if err := c.AddExtension(ice.CandidateExtension{
+		Key:   "tcptype",
+		Value: ice.TCPTypePassive.String(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package ice_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pion/ice/v4"
+	"github.com/pion/stun/v3"
+
+	icex "cunicu.li/cunicu/pkg/ice"
+	epdiscproto "cunicu.li/cunicu/pkg/proto/feature/epdisc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// serveSTUNOverTCP responds to a single STUN binding request received over TCP.
+func serveSTUNOverTCP(l net.Listener) {
+	conn, err := l.Accept()
+	if err != nil {
+		return
+	}
+
+	defer conn.Close()
+
+	// Our binding requests only consist of the 20 byte header
+	buf := make([]byte, 20)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return
+	}
+
+	req := &stun.Message{}
+	if err := stun.Decode(buf, req); err != nil {
+		return
+	}
+
+	src := conn.RemoteAddr().(*net.TCPAddr) //nolint:forcetypeassert
+
+	resp, err := stun.Build(
+		stun.NewTransactionIDSetter(req.TransactionID),
+		stun.BindingSuccess,
+		&stun.XORMappedAddress{IP: src.IP, Port: src.Port},
+	)
+	if err != nil {
+		return
+	}
+
+	conn.Write(resp.Raw) //nolint:errcheck
+}
+
+var _ = Describe("ICE-TCP", func() {
+	It("discovers the mapped address of a passive listener", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		server, err := net.Listen("tcp4", "127.0.0.1:0")
+		Expect(err).To(Succeed())
+
+		defer server.Close()
+
+		go serveSTUNOverTCP(server)
+
+		l, err := icex.ListenTCP(ctx, "tcp4", 0)
+		Expect(err).To(Succeed())
+
+		defer l.Close()
+
+		port := l.Addr().(*net.TCPAddr).Port //nolint:forcetypeassert
+
+		mapped, local, err := icex.DiscoverTCPMappedAddress(ctx, port, server.Addr().(*net.TCPAddr)) //nolint:forcetypeassert
+		Expect(err).To(Succeed())
+		Expect(local.Port).To(Equal(port))
+		Expect(mapped.Port).To(Equal(port))
+		Expect(mapped.IP.Equal(net.IPv4(127, 0, 0, 1))).To(BeTrue())
+
+		// The listener still accepts connections for passive candidates
+		go func() {
+			conn, err := net.Dial("tcp4", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+			if err == nil {
+				conn.Close()
+			}
+		}()
+
+		conn, err := l.Accept()
+		Expect(err).To(Succeed())
+		Expect(conn.Close()).To(Succeed())
+	})
+
+	It("advertises the mapped address as a passive server reflexive candidate", func() {
+		mapped := &net.TCPAddr{IP: net.IPv4(198, 51, 100, 1), Port: 40000}
+		local := &net.TCPAddr{IP: net.IPv4(192, 168, 1, 2), Port: 51820}
+
+		c, err := icex.NewTCPMappedCandidate(mapped, local)
+		Expect(err).To(Succeed())
+		Expect(c.Type()).To(Equal(ice.CandidateTypeServerReflexive))
+		Expect(c.NetworkType()).To(Equal(ice.NetworkTypeTCP4))
+		Expect(c.TCPType()).To(Equal(ice.TCPTypePassive))
+		Expect(c.Address()).To(Equal("198.51.100.1"))
+		Expect(c.Port()).To(Equal(40000))
+		Expect(c.RelatedAddress().Address).To(Equal("192.168.1.2"))
+		Expect(c.RelatedAddress().Port).To(Equal(51820))
+
+		// RFC 6544 Sect. 4.2: direction preference 2 for passive server reflexive candidates
+		Expect(c.Priority() >> 8 & 0xffff).To(BeEquivalentTo(1<<13*2 + 8191))
+
+		hc, err := ice.NewCandidateHost(&ice.CandidateHostConfig{
+			Network:   "tcp",
+			Address:   local.IP.String(),
+			Port:      local.Port,
+			Component: ice.ComponentRTP,
+			TCPType:   ice.TCPTypePassive,
+		})
+		Expect(err).To(Succeed())
+		Expect(c.Priority()).To(BeNumerically("<", hc.Priority()))
+
+		uc, err := ice.NewCandidateServerReflexive(&ice.CandidateServerReflexiveConfig{
+			Network:   "udp",
+			Address:   mapped.IP.String(),
+			Port:      mapped.Port,
+			Component: ice.ComponentRTP,
+			RelAddr:   local.IP.String(),
+			RelPort:   local.Port,
+		})
+		Expect(err).To(Succeed())
+		Expect(c.Priority()).To(BeNumerically("<", uc.Priority()))
+
+		// The TCP type survives the signaling of the candidate
+		rc, err := epdiscproto.NewCandidate(c).ICECandidate()
+		Expect(err).To(Succeed())
+		Expect(rc.Type()).To(Equal(ice.CandidateTypeServerReflexive))
+		Expect(rc.TCPType()).To(Equal(ice.TCPTypePassive))
+		Expect(rc.Priority()).To(Equal(c.Priority()))
+		Expect(rc.Equal(c)).To(BeTrue())
+	})
+})
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package link
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// DefaultRouteRules returns the policy routing rules for a default route in a separate table.
+// Like wg-quick, all traffic which is not marked with the table number as firewall mark is directed to the table.
+// The suppress rule ignores the default route of the main table, while keeping its more specific routes.
+// Traffic marked with one of the bypass marks is routed via the main table.
+func DefaultRouteRules(dst net.IPNet, table int, bypassMarks ...int) []*netlink.Rule {
+	family := unix.AF_INET
+	if dst.IP.To4() == nil {
+		family = unix.AF_INET6
+	}
+
+	toTable := netlink.NewRule()
+	toTable.Family = family
+	toTable.Mark = uint32(table) //nolint:gosec
+	toTable.Invert = true
+	toTable.Table = table
+
+	suppress := netlink.NewRule()
+	suppress.Family = family
+	suppress.Table = unix.RT_TABLE_MAIN
+	suppress.SuppressPrefixlen = 0
+
+	rules := []*netlink.Rule{toTable, suppress}
+
+	for _, mark := range bypassMarks {
+		bypass := netlink.NewRule()
+		bypass.Family = family
+		bypass.Mark = uint32(mark) //nolint:gosec
+		bypass.Table = unix.RT_TABLE_MAIN
+
+		rules = append(rules, bypass)
+	}
+
+	return rules
+}
+
+// AddDefaultRoute adds a default route via the link to a separate table
+// and installs the policy routing rules returned by DefaultRouteRules.
+// Rules without priority are inserted in front of existing ones.
+// Hence, the bypass rules take precedence over the suppress rule and the rule directing traffic to the table.
+func AddDefaultRoute(l Link, dst net.IPNet, table int, bypassMarks ...int) error {
+	if err := l.AddRoute(dst, nil, table); err != nil {
+		return fmt.Errorf("failed to add route: %w", err)
+	}
+
+	for _, rule := range DefaultRouteRules(dst, table, bypassMarks...) {
+		if err := netlink.RuleAdd(rule); err != nil && !errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("failed to add rule: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteDefaultRoute removes a default route added by AddDefaultRoute and its policy routing rules.
+func DeleteDefaultRoute(l Link, dst net.IPNet, table int, bypassMarks ...int) error {
+	for _, rule := range DefaultRouteRules(dst, table, bypassMarks...) {
+		if err := netlink.RuleDel(rule); err != nil && !errors.Is(err, syscall.ENOENT) {
+			return fmt.Errorf("failed to delete rule: %w", err)
+		}
+	}
+
+	if err := l.DeleteRoute(dst, table); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return fmt.Errorf("failed to delete route: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package link_test
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+
+	g "cunicu.li/gont/v2/pkg"
+	nl "github.com/vishvananda/netlink"
+
+	"cunicu.li/cunicu/pkg/link"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("default route", Ordered, func() {
+	const (
+		table = 51820
+		mark  = 0xc0de
+	)
+
+	var (
+		err      error
+		ns       *g.Namespace
+		exitLink *link.LinuxLink
+		dst      net.IPNet
+	)
+
+	// Returns the name of the link via which the destination is routed for packets with the given mark
+	routeVia := func(ip net.IP, mark uint32) string {
+		rts, err := nl.RouteGetWithOptions(ip, &nl.RouteGetOptions{
+			Mark: mark,
+		})
+		Expect(err).To(Succeed())
+		Expect(rts).NotTo(BeEmpty())
+
+		l, err := nl.LinkByIndex(rts[0].LinkIndex)
+		Expect(err).To(Succeed())
+
+		return l.Attrs().Name
+	}
+
+	addVeth := func(name string, addr string) {
+		la := nl.NewLinkAttrs()
+		la.Name = name
+
+		l := &nl.Veth{LinkAttrs: la, PeerName: name + "-peer"}
+		Expect(nl.LinkAdd(l)).To(Succeed())
+
+		peer, err := nl.LinkByName(name + "-peer")
+		Expect(err).To(Succeed())
+		Expect(nl.LinkSetUp(peer)).To(Succeed())
+		Expect(nl.LinkSetUp(l)).To(Succeed())
+
+		a, err := nl.ParseAddr(addr)
+		Expect(err).To(Succeed())
+		Expect(nl.AddrAdd(l, a)).To(Succeed())
+	}
+
+	BeforeAll(func() {
+		name := fmt.Sprintf("rt-test-%d", rand.Intn(1000)) //nolint:gosec
+		ns, err = g.NewNamespace(name)
+		Expect(err).To(Succeed())
+
+		DeferCleanup(ns.Close)
+
+		dst = net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(0, 32)}
+	})
+
+	// Overload It() to enter/exit namespace
+	It := func(name string, function func()) {
+		It(name, Offset(1), func() {
+			exit, err := ns.Enter()
+			Expect(err).To(Succeed())
+
+			defer exit()
+
+			function()
+		})
+	}
+
+	It("routes via the underlay by default", func() {
+		addVeth("underlay", "192.0.2.1/24")
+		addVeth("exit", "10.0.0.1/24")
+
+		Expect(nl.RouteAdd(&nl.Route{
+			Dst: &dst,
+			Gw:  net.IPv4(192, 0, 2, 254),
+		})).To(Succeed())
+
+		exitLink, err = link.FindLink("exit")
+		Expect(err).To(Succeed())
+
+		Expect(routeVia(net.IPv4(198, 51, 100, 1), 0)).To(Equal("underlay"))
+	})
+
+	It("can add a default route via the exit node", func() {
+		err := link.AddDefaultRoute(exitLink, dst, table, mark)
+		Expect(err).To(Succeed())
+
+		rts, err := nl.RouteListFiltered(nl.FAMILY_V4, &nl.Route{Table: table}, nl.RT_FILTER_TABLE)
+		Expect(err).To(Succeed())
+		Expect(rts).To(HaveLen(1))
+		Expect(rts[0].LinkIndex).To(Equal(exitLink.Index()))
+
+		rules, err := nl.RuleList(nl.FAMILY_V4)
+		Expect(err).To(Succeed())
+		Expect(rules).To(ContainElement(And(
+			HaveField("Table", table),
+			HaveField("Mark", uint32(table)),
+			HaveField("Invert", true),
+		)))
+		Expect(rules).To(ContainElement(And(
+			HaveField("Table", 254),
+			HaveField("Mark", uint32(mark)),
+		)))
+	})
+
+	It("routes unmarked traffic via the exit node", func() {
+		Expect(routeVia(net.IPv4(198, 51, 100, 1), 0)).To(Equal("exit"))
+	})
+
+	It("routes traffic of the WireGuard socket via the underlay", func() {
+		Expect(routeVia(net.IPv4(198, 51, 100, 1), table)).To(Equal("underlay"))
+	})
+
+	It("routes traffic of the daemon via the underlay", func() {
+		Expect(routeVia(net.IPv4(198, 51, 100, 1), mark)).To(Equal("underlay"))
+	})
+
+	It("keeps more specific routes of the main table", func() {
+		Expect(routeVia(net.IPv4(192, 0, 2, 2), 0)).To(Equal("underlay"))
+	})
+
+	It("can delete the default route again", func() {
+		err := link.DeleteDefaultRoute(exitLink, dst, table, mark)
+		Expect(err).To(Succeed())
+
+		rts, err := nl.RouteListFiltered(nl.FAMILY_V4, &nl.Route{Table: table}, nl.RT_FILTER_TABLE)
+		Expect(err).To(Succeed())
+		Expect(rts).To(BeEmpty())
+
+		rules, err := nl.RuleList(nl.FAMILY_V4)
+		Expect(err).To(Succeed())
+		Expect(rules).NotTo(ContainElement(HaveField("Table", table)))
+
+		Expect(routeVia(net.IPv4(198, 51, 100, 1), 0)).To(Equal("underlay"))
+	})
+
+	It("ignores missing routes and rules", func() {
+		err := link.DeleteDefaultRoute(exitLink, dst, table, mark)
+		Expect(err).To(Succeed())
+	})
+})
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package net
+
+import (
+	"context"
+	"net"
+)
+
+// DaemonMark is the firewall mark of the sockets which the daemon uses for its own traffic,
+// e.g. ICE connectivity checks, TURN allocations and signaling.
+// Policy routing rules exempt marked packets from the default route via an exit node.
+const DaemonMark = 0xc0de
+
+// ListenConfig returns a listen config which marks its sockets with DaemonMark.
+func ListenConfig() *net.ListenConfig {
+	return &net.ListenConfig{
+		Control: MarkControl,
+	}
+}
+
+// Dialer returns a dialer which marks its sockets with DaemonMark.
+func Dialer() *net.Dialer {
+	return &net.Dialer{
+		Control: MarkControl,
+	}
+}
+
+// ListenUDP acts like net.ListenUDP but marks the socket with DaemonMark.
+func ListenUDP(network string, laddr *net.UDPAddr) (*net.UDPConn, error) {
+	address := ""
+	if laddr != nil {
+		address = laddr.String()
+	}
+
+	conn, err := ListenConfig().ListenPacket(context.Background(), network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.(*net.UDPConn), nil //nolint:forcetypeassert
+}
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package net
+
+import (
+	"errors"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// MarkControl marks a socket with DaemonMark.
+// Setting the mark requires the CAP_NET_ADMIN capability.
+// Without it, sockets remain unmarked.
+func MarkControl(_, _ string, c syscall.RawConn) error {
+	var operr error
+
+	if err := c.Control(func(fd uintptr) {
+		operr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_MARK, DaemonMark)
+	}); err != nil {
+		return err
+	}
+
+	if errors.Is(operr, unix.EPERM) {
+		return nil
+	}
+
+	return operr
+}
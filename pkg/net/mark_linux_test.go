@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package net_test
+
+import (
+	"net"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	netx "cunicu.li/cunicu/pkg/net"
+	osx "cunicu.li/cunicu/pkg/os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("socket marks", func() {
+	mark := func(c syscall.Conn) int {
+		rc, err := c.SyscallConn()
+		Expect(err).To(Succeed())
+
+		var m int
+		var operr error
+
+		err = rc.Control(func(fd uintptr) {
+			m, operr = unix.GetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_MARK)
+		})
+		Expect(err).To(Succeed())
+		Expect(operr).To(Succeed())
+
+		return m
+	}
+
+	BeforeEach(func() {
+		if !osx.HasAdminPrivileges() {
+			Skip("Insufficient privileges")
+		}
+	})
+
+	It("marks UDP sockets", func() {
+		conn, err := netx.ListenUDP("udp", nil)
+		Expect(err).To(Succeed())
+
+		defer conn.Close()
+
+		Expect(mark(conn)).To(Equal(netx.DaemonMark))
+	})
+
+	It("marks sockets of pion", func() {
+		n, err := netx.NewNet()
+		Expect(err).To(Succeed())
+
+		l, err := n.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		Expect(err).To(Succeed())
+
+		defer l.Close()
+
+		conn, err := n.DialTCP("tcp", nil, l.Addr().(*net.TCPAddr)) //nolint:forcetypeassert
+		Expect(err).To(Succeed())
+
+		defer conn.Close()
+
+		Expect(mark(conn.(syscall.Conn))).To(Equal(netx.DaemonMark)) //nolint:forcetypeassert
+	})
+})
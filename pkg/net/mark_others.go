@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package net
+
+import (
+	"syscall"
+)
+
+// MarkControl is a no-op as firewall marks are only supported on Linux.
+func MarkControl(_, _ string, _ syscall.RawConn) error {
+	return nil
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package net
+
+import (
+	"context"
+	"net"
+	"syscall"
+
+	"github.com/pion/transport/v3"
+	"github.com/pion/transport/v3/stdnet"
+)
+
+// Net is a transport.Net for pion/ice and pion/turn which marks its sockets with DaemonMark.
+type Net struct {
+	*stdnet.Net
+}
+
+func NewNet() (*Net, error) {
+	n, err := stdnet.NewNet()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Net{
+		Net: n,
+	}, nil
+}
+
+func (n *Net) ListenPacket(network string, address string) (net.PacketConn, error) {
+	return ListenConfig().ListenPacket(context.Background(), network, address)
+}
+
+func (n *Net) ListenUDP(network string, laddr *net.UDPAddr) (transport.UDPConn, error) {
+	return ListenUDP(network, laddr)
+}
+
+func (n *Net) ListenTCP(network string, laddr *net.TCPAddr) (transport.TCPListener, error) {
+	address := ""
+	if laddr != nil {
+		address = laddr.String()
+	}
+
+	l, err := ListenConfig().Listen(context.Background(), network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return tcpListener{l.(*net.TCPListener)}, nil //nolint:forcetypeassert
+}
+
+func (n *Net) Dial(network, address string) (net.Conn, error) {
+	return Dialer().Dial(network, address)
+}
+
+func (n *Net) DialUDP(network string, laddr, raddr *net.UDPAddr) (transport.UDPConn, error) {
+	d := Dialer()
+	if laddr != nil {
+		d.LocalAddr = laddr
+	}
+
+	conn, err := d.Dial(network, raddr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.(*net.UDPConn), nil //nolint:forcetypeassert
+}
+
+func (n *Net) DialTCP(network string, laddr, raddr *net.TCPAddr) (transport.TCPConn, error) {
+	d := Dialer()
+	if laddr != nil {
+		d.LocalAddr = laddr
+	}
+
+	conn, err := d.Dial(network, raddr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.(*net.TCPConn), nil //nolint:forcetypeassert
+}
+
+func (n *Net) CreateDialer(d *net.Dialer) transport.Dialer {
+	md := *d
+	md.Control = func(network, address string, c syscall.RawConn) error {
+		if d.Control != nil {
+			if err := d.Control(network, address, c); err != nil {
+				return err
+			}
+		}
+
+		return MarkControl(network, address, c)
+	}
+
+	return n.Net.CreateDialer(&md)
+}
+
+type tcpListener struct {
+	*net.TCPListener
+}
+
+func (l tcpListener) AcceptTCP() (transport.TCPConn, error) {
+	return l.TCPListener.AcceptTCP()
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package portmap
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// DefaultGateway returns the IPv4 gateway of the default route in the main routing table.
+func DefaultGateway() (net.IP, error) {
+	rts, err := netlink.RouteListFiltered(unix.AF_INET, &netlink.Route{
+		Table: unix.RT_TABLE_MAIN,
+	}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routes: %w", err)
+	}
+
+	for _, rt := range rts {
+		if rt.Gw == nil {
+			continue
+		}
+
+		if rt.Dst == nil {
+			return rt.Gw, nil
+		}
+
+		if ones, _ := rt.Dst.Mask.Size(); ones == 0 {
+			return rt.Gw, nil
+		}
+	}
+
+	return nil, errNoGateway
+}
@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package portmap
+
+import "net"
+
+func DefaultGateway() (net.IP, error) {
+	return nil, errNotSupported
+}
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package portmap
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/log"
+)
+
+const (
+	DefaultLifetime      = 2 * time.Hour
+	DefaultRetryInterval = 5 * time.Minute
+
+	// Timeout for a single request including its retransmissions
+	requestTimeout = 4 * time.Second
+
+	// Lower bound for renewals of mappings with a short lifetime
+	minRenewInterval = 10 * time.Second
+)
+
+type Config struct {
+	// Internal port which should be mapped
+	Port int
+
+	// Clients are tried in order until one of them succeeds
+	Clients []Client
+
+	// Requested lifetime of the mapping
+	Lifetime time.Duration
+
+	// Interval after which a failed request is retried
+	RetryInterval time.Duration
+
+	// OnMapping is invoked whenever the mapping has been created or changed.
+	// It is called with nil if the mapping has been lost.
+	OnMapping func(m *Mapping)
+
+	Logger *log.Logger
+}
+
+// Mapper maintains a port mapping by renewing it before its lease expires.
+type Mapper struct {
+	Config
+
+	mapping *Mapping
+	client  Client
+	mu      sync.RWMutex
+
+	ctx    context.Context //nolint:containedctx
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewMapper starts requesting a port mapping in the background.
+func NewMapper(cfg Config) *Mapper {
+	if cfg.Lifetime == 0 {
+		cfg.Lifetime = DefaultLifetime
+	}
+
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = DefaultRetryInterval
+	}
+
+	if cfg.Logger == nil {
+		cfg.Logger = log.Global.Named("portmap")
+	}
+
+	m := &Mapper{
+		Config: cfg,
+		done:   make(chan struct{}),
+	}
+
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+
+	go m.run()
+
+	return m
+}
+
+// Mapping returns the current mapping or nil if there is none.
+func (m *Mapper) Mapping() *Mapping {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.mapping
+}
+
+// Close stops renewing and deletes the mapping.
+func (m *Mapper) Close() error {
+	m.cancel()
+	<-m.done
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.mapping == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	if err := m.client.Unmap(ctx, m.mapping); err != nil {
+		return err
+	}
+
+	m.Logger.Info("Deleted port mapping", zap.Stringer("mapping", m.mapping))
+
+	m.mapping = nil
+
+	return nil
+}
+
+func (m *Mapper) run() {
+	defer close(m.done)
+
+	for {
+		next := m.refresh()
+
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-time.After(next):
+		}
+	}
+}
+
+// refresh requests or renews the mapping and returns the interval after which it should be refreshed again.
+func (m *Mapper) refresh() time.Duration {
+	m.mu.RLock()
+	current, client := m.mapping, m.client
+	m.mu.RUnlock()
+
+	suggested := m.Port
+	clients := m.Clients
+
+	// Renew an existing mapping with the same protocol and external port
+	if current != nil {
+		suggested = current.External.Port
+		clients = append([]Client{client}, slices.DeleteFunc(slices.Clone(clients), func(c Client) bool {
+			return c == client
+		})...)
+	}
+
+	for _, c := range clients {
+		ctx, cancel := context.WithTimeout(m.ctx, requestTimeout)
+		mapping, err := c.Map(ctx, m.Port, suggested, m.Lifetime)
+		cancel()
+
+		if err != nil {
+			if m.ctx.Err() != nil {
+				return 0
+			}
+
+			m.Logger.Debug("Failed to request port mapping",
+				zap.String("protocol", string(c.Protocol())),
+				zap.Error(err))
+
+			continue
+		}
+
+		m.set(c, mapping)
+
+		return max(mapping.Lifetime/2, minRenewInterval)
+	}
+
+	// Keep the previous mapping until it expires
+	// but make sure to notice the loss of the mapping in time.
+	if current != nil {
+		if remaining := time.Until(current.Expires); remaining > 0 {
+			return min(m.RetryInterval, remaining)
+		}
+
+		m.set(nil, nil)
+	}
+
+	return m.RetryInterval
+}
+
+func (m *Mapper) set(c Client, mapping *Mapping) {
+	m.mu.Lock()
+	previous := m.mapping
+	m.mapping, m.client = mapping, c
+	m.mu.Unlock()
+
+	if changed := previous == nil || mapping == nil ||
+		!previous.External.IP.Equal(mapping.External.IP) ||
+		previous.External.Port != mapping.External.Port; !changed {
+		return
+	}
+
+	if mapping != nil {
+		m.Logger.Info("Created port mapping",
+			zap.Stringer("mapping", mapping),
+			zap.Duration("lifetime", mapping.Lifetime))
+	} else if previous != nil {
+		m.Logger.Warn("Lost port mapping", zap.Stringer("mapping", previous))
+	}
+
+	if m.OnMapping != nil && (mapping != nil || previous != nil) {
+		m.OnMapping(mapping)
+	}
+}
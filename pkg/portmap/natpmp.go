@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package portmap
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// NAT-PMP opcodes and result codes (RFC 6886).
+const (
+	natpmpVersion = 0
+
+	natpmpOpExternalAddress = 0
+	natpmpOpMapUDP          = 1
+	natpmpOpResponse        = 128
+
+	natpmpResultSuccess            = 0
+	natpmpResultUnsupportedVersion = 1
+)
+
+// NATPMPClient requests port mappings via the NAT Port Mapping Protocol (RFC 6886).
+type NATPMPClient struct {
+	Gateway *net.UDPAddr
+}
+
+func (c *NATPMPClient) Protocol() Protocol {
+	return ProtocolNATPMP
+}
+
+func (c *NATPMPClient) Map(ctx context.Context, port, suggested int, lifetime time.Duration) (*Mapping, error) {
+	extIP, err := c.externalAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, laddr, err := c.mapPort(ctx, port, suggested, lifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	lifetime = time.Duration(binary.BigEndian.Uint32(resp[12:16])) * time.Second
+
+	return &Mapping{
+		Protocol: ProtocolNATPMP,
+		Internal: &net.UDPAddr{
+			IP:   laddr.IP,
+			Port: int(binary.BigEndian.Uint16(resp[8:10])),
+		},
+		External: &net.UDPAddr{
+			IP:   extIP,
+			Port: int(binary.BigEndian.Uint16(resp[10:12])),
+		},
+		Lifetime: lifetime,
+		Expires:  time.Now().Add(lifetime),
+	}, nil
+}
+
+func (c *NATPMPClient) Unmap(ctx context.Context, m *Mapping) error {
+	// A mapping is deleted by requesting a zero lifetime and external port
+	_, _, err := c.mapPort(ctx, m.Internal.Port, 0, 0)
+
+	return err
+}
+
+func (c *NATPMPClient) externalAddress(ctx context.Context) (net.IP, error) {
+	req := []byte{natpmpVersion, natpmpOpExternalAddress}
+
+	resp, _, err := c.request(ctx, req, natpmpOpExternalAddress, 12)
+	if err != nil {
+		return nil, err
+	}
+
+	return net.IP(resp[8:12]).To16(), nil
+}
+
+func (c *NATPMPClient) mapPort(ctx context.Context, port, suggested int, lifetime time.Duration) ([]byte, *net.UDPAddr, error) {
+	req := make([]byte, 12)
+	req[0] = natpmpVersion
+	req[1] = natpmpOpMapUDP
+	binary.BigEndian.PutUint16(req[4:6], uint16(port))      //nolint:gosec
+	binary.BigEndian.PutUint16(req[6:8], uint16(suggested)) //nolint:gosec
+	binary.BigEndian.PutUint32(req[8:12], uint32(lifetime.Seconds()))
+
+	resp, laddr, err := c.request(ctx, req, natpmpOpMapUDP, 16)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Make sure the response belongs to our internal port
+	if int(binary.BigEndian.Uint16(resp[8:10])) != port {
+		return nil, nil, fmt.Errorf("%w: mismatching internal port", errInvalidResponse)
+	}
+
+	return resp, laddr, nil
+}
+
+// request sends a NAT-PMP request and validates the result code and size of the response.
+func (c *NATPMPClient) request(ctx context.Context, req []byte, op byte, size int) ([]byte, *net.UDPAddr, error) {
+	resp, laddr, err := request(ctx, c.Gateway, req, func(b []byte) bool {
+		return len(b) >= 4 && b[0] == natpmpVersion && b[1] == natpmpOpResponse|op
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch code := binary.BigEndian.Uint16(resp[2:4]); code {
+	case natpmpResultSuccess:
+	case natpmpResultUnsupportedVersion:
+		return nil, nil, errUnsupportedVersion
+	default:
+		return nil, nil, fmt.Errorf("%w: result code %d", errInvalidResponse, code)
+	}
+
+	if len(resp) < size {
+		return nil, nil, fmt.Errorf("%w: too short", errInvalidResponse)
+	}
+
+	return resp, laddr, nil
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package portmap
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// PCP opcodes and result codes (RFC 6887).
+const (
+	pcpVersion = 2
+
+	pcpOpMap      = 1
+	pcpOpResponse = 0x80
+
+	pcpProtocolUDP = 17
+
+	pcpResultSuccess            = 0
+	pcpResultUnsupportedVersion = 1
+
+	pcpHeaderSize = 24
+	pcpMapSize    = 36
+)
+
+// PCPClient requests port mappings via the Port Control Protocol (RFC 6887).
+type PCPClient struct {
+	Gateway *net.UDPAddr
+
+	// Nonce identifies our mappings towards the PCP server.
+	// It is generated by the first request.
+	nonce [12]byte
+}
+
+func (c *PCPClient) Protocol() Protocol {
+	return ProtocolPCP
+}
+
+func (c *PCPClient) Map(ctx context.Context, port, suggested int, lifetime time.Duration) (*Mapping, error) {
+	if c.nonce == [12]byte{} {
+		if _, err := rand.Read(c.nonce[:]); err != nil {
+			return nil, fmt.Errorf("failed to generate nonce: %w", err)
+		}
+	}
+
+	resp, laddr, err := c.mapPort(ctx, port, suggested, lifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	lifetime = time.Duration(binary.BigEndian.Uint32(resp[4:8])) * time.Second
+	opData := resp[pcpHeaderSize:]
+
+	return &Mapping{
+		Protocol: ProtocolPCP,
+		Internal: laddr,
+		External: &net.UDPAddr{
+			IP:   net.IP(bytes.Clone(opData[20:36])),
+			Port: int(binary.BigEndian.Uint16(opData[18:20])),
+		},
+		Lifetime: lifetime,
+		Expires:  time.Now().Add(lifetime),
+	}, nil
+}
+
+func (c *PCPClient) Unmap(ctx context.Context, m *Mapping) error {
+	// A mapping is deleted by requesting a zero lifetime
+	_, _, err := c.mapPort(ctx, m.Internal.Port, 0, 0)
+
+	return err
+}
+
+func (c *PCPClient) mapPort(ctx context.Context, port, suggested int, lifetime time.Duration) ([]byte, *net.UDPAddr, error) {
+	// The client address must be the source address of our request
+	laddr, err := localAddress(c.Gateway)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := make([]byte, pcpHeaderSize+pcpMapSize)
+	req[0] = pcpVersion
+	req[1] = pcpOpMap
+	binary.BigEndian.PutUint32(req[4:8], uint32(lifetime.Seconds()))
+	copy(req[8:24], laddr.To16())
+
+	opData := req[pcpHeaderSize:]
+	copy(opData[0:12], c.nonce[:])
+	opData[12] = pcpProtocolUDP
+	binary.BigEndian.PutUint16(opData[16:18], uint16(port))      //nolint:gosec
+	binary.BigEndian.PutUint16(opData[18:20], uint16(suggested)) //nolint:gosec
+
+	// Suggest any external address of the same family
+	if laddr.To4() != nil {
+		copy(opData[20:36], net.IPv4zero.To16())
+	}
+
+	resp, la, err := request(ctx, c.Gateway, req, func(b []byte) bool {
+		if len(b) < 4 || b[1] != pcpOpResponse|pcpOpMap {
+			return false
+		}
+
+		// Servers which do not support our version respond with their own
+		if b[3] == pcpResultUnsupportedVersion {
+			return true
+		}
+
+		return len(b) >= pcpHeaderSize+pcpMapSize &&
+			b[0] == pcpVersion &&
+			bytes.Equal(b[pcpHeaderSize:pcpHeaderSize+12], c.nonce[:])
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch code := resp[3]; code {
+	case pcpResultSuccess:
+	case pcpResultUnsupportedVersion:
+		return nil, nil, errUnsupportedVersion
+	default:
+		return nil, nil, fmt.Errorf("%w: result code %d", errInvalidResponse, code)
+	}
+
+	return resp, &net.UDPAddr{
+		IP:   la.IP,
+		Port: port,
+	}, nil
+}
+
+// localAddress returns the local address which is used to reach the gateway.
+func localAddress(gateway *net.UDPAddr) (net.IP, error) {
+	conn, err := net.DialUDP("udp", nil, gateway)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial gateway: %w", err)
+	}
+
+	defer conn.Close()
+
+	laddr, ok := conn.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errInvalidResponse, conn.LocalAddr())
+	}
+
+	return laddr.IP, nil
+}
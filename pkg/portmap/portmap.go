@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package portmap requests UDP port mappings from the local router via PCP, NAT-PMP or UPnP-IGD.
+package portmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+type Protocol string
+
+const (
+	// Port Control Protocol (RFC 6887)
+	ProtocolPCP Protocol = "pcp"
+
+	// NAT Port Mapping Protocol (RFC 6886)
+	ProtocolNATPMP Protocol = "nat-pmp"
+
+	// UPnP Internet Gateway Device Protocol
+	ProtocolUPnP Protocol = "upnp"
+
+	// DefaultPort is the port at which routers accept PCP and NAT-PMP requests.
+	DefaultPort = 5351
+)
+
+var (
+	errNotSupported       = errors.New("not supported on this platform")
+	errUnknownProtocol    = errors.New("unknown port mapping protocol")
+	errUnsupportedVersion = errors.New("unsupported protocol version")
+	errInvalidResponse    = errors.New("invalid response")
+	errNoGateway          = errors.New("no default gateway found")
+)
+
+// ParseProtocol validates the name of a port mapping protocol.
+func ParseProtocol(s string) (Protocol, error) {
+	switch p := Protocol(s); p {
+	case ProtocolPCP, ProtocolNATPMP, ProtocolUPnP:
+		return p, nil
+	default:
+		return "", fmt.Errorf("%w: %s", errUnknownProtocol, s)
+	}
+}
+
+// Mapping is a UDP port mapping which has been granted by the router.
+type Mapping struct {
+	Protocol Protocol
+
+	// Address and port of the host behind the router
+	Internal *net.UDPAddr
+
+	// Public address and port of the mapping
+	External *net.UDPAddr
+
+	Lifetime time.Duration
+	Expires  time.Time
+}
+
+func (m *Mapping) String() string {
+	return fmt.Sprintf("%s -> %s via %s", m.External, m.Internal, m.Protocol)
+}
+
+// Client requests port mappings via one of the router protocols.
+type Client interface {
+	Protocol() Protocol
+
+	// Map requests or renews a mapping for an internal port.
+	// The router should preferably map the suggested external port.
+	Map(ctx context.Context, port, suggested int, lifetime time.Duration) (*Mapping, error)
+
+	// Unmap deletes a mapping.
+	Unmap(ctx context.Context, m *Mapping) error
+}
+
+// NewClient creates a client for the given protocol.
+// The gateway is only used by PCP and NAT-PMP while UPnP-IGD devices are discovered via SSDP.
+func NewClient(p Protocol, gateway *net.UDPAddr) (Client, error) {
+	switch p {
+	case ProtocolPCP:
+		return &PCPClient{Gateway: gateway}, nil
+	case ProtocolNATPMP:
+		return &NATPMPClient{Gateway: gateway}, nil
+	case ProtocolUPnP:
+		return &UPnPClient{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownProtocol, p)
+	}
+}
+
+// request sends a request to a PCP or NAT-PMP server and waits for a response.
+// The request is retransmitted with exponential back-off as recommended by RFC 6886 Sect. 3.1.
+func request(ctx context.Context, gateway *net.UDPAddr, req []byte, check func([]byte) bool) ([]byte, *net.UDPAddr, error) {
+	conn, err := net.DialUDP("udp", nil, gateway)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial gateway: %w", err)
+	}
+
+	defer conn.Close()
+
+	laddr, _ := conn.LocalAddr().(*net.UDPAddr)
+
+	// Unblock pending reads when the context is cancelled
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetReadDeadline(time.Now()) //nolint:errcheck
+	})
+	defer stop()
+
+	buf := make([]byte, 1100)
+
+	for timeout := 250 * time.Millisecond; ; timeout *= 2 {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		if _, err := conn.Write(req); err != nil {
+			return nil, nil, fmt.Errorf("failed to send request: %w", err)
+		}
+
+		if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, nil, err
+		}
+
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, nil, ctx.Err()
+				}
+
+				var nerr net.Error
+				if errors.As(err, &nerr) && nerr.Timeout() {
+					break // Retransmit
+				}
+
+				return nil, nil, err
+			}
+
+			if check(buf[:n]) {
+				return buf[:n], laddr, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package portmap_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"cunicu.li/cunicu/pkg/portmap"
+	"cunicu.li/cunicu/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSuite(t *testing.T) {
+	test.SetupLogging()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Port Mapping Suite")
+}
+
+var _ = Describe("port mapping", func() {
+	var (
+		r   *router
+		ctx context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		r, err = newRouter()
+		Expect(err).To(Succeed())
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+		DeferCleanup(cancel)
+	})
+
+	AfterEach(func() {
+		Expect(r.Close()).To(Succeed())
+	})
+
+	clients := map[portmap.Protocol]func() portmap.Client{
+		portmap.ProtocolPCP: func() portmap.Client {
+			return &portmap.PCPClient{Gateway: r.Addr()}
+		},
+		portmap.ProtocolNATPMP: func() portmap.Client {
+			return &portmap.NATPMPClient{Gateway: r.Addr()}
+		},
+		portmap.ProtocolUPnP: func() portmap.Client {
+			return &portmap.UPnPClient{SSDPAddress: r.SSDPAddr()}
+		},
+	}
+
+	for proto, newClient := range clients {
+		Context(string(proto), func() {
+			It("maps a port", func() {
+				c := newClient()
+				Expect(c.Protocol()).To(Equal(proto))
+
+				m, err := c.Map(ctx, 51820, 51821, time.Hour)
+				Expect(err).To(Succeed())
+
+				Expect(m.Protocol).To(Equal(proto))
+				Expect(m.Internal.Port).To(Equal(51820))
+				Expect(m.External.Port).To(Equal(51821))
+				Expect(m.External.IP.Equal(r.ExternalIP)).To(BeTrue())
+				Expect(m.Lifetime).To(Equal(time.Hour))
+
+				ext, ok := r.Mapping(51820)
+				Expect(ok).To(BeTrue())
+				Expect(ext).To(Equal(51821))
+
+				Expect(c.Unmap(ctx, m)).To(Succeed())
+
+				_, ok = r.Mapping(51820)
+				Expect(ok).To(BeFalse())
+			})
+		})
+	}
+
+	It("falls back to NAT-PMP if PCP is not supported", func() {
+		r.mu.Lock()
+		r.PCP = false
+		r.mu.Unlock()
+
+		_, err := clients[portmap.ProtocolPCP]().Map(ctx, 51820, 0, time.Hour)
+		Expect(err).To(HaveOccurred())
+
+		m, err := clients[portmap.ProtocolNATPMP]().Map(ctx, 51820, 0, time.Hour)
+		Expect(err).To(Succeed())
+		Expect(m.External.Port).To(Equal(51820))
+	})
+
+	It("does not find a gateway without UPnP", func() {
+		r.mu.Lock()
+		r.UPnP = false
+		r.mu.Unlock()
+
+		_, err := clients[portmap.ProtocolUPnP]().Map(ctx, 51820, 0, time.Hour)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("mapper", func() {
+		It("uses the first supported protocol", func() {
+			r.mu.Lock()
+			r.PCP = false
+			r.PortOffset = 1000
+			r.mu.Unlock()
+
+			mappings := make(chan *portmap.Mapping, 10)
+
+			m := portmap.NewMapper(portmap.Config{
+				Port: 51820,
+				Clients: []portmap.Client{
+					clients[portmap.ProtocolPCP](),
+					clients[portmap.ProtocolNATPMP](),
+					clients[portmap.ProtocolUPnP](),
+				},
+				Lifetime: 2 * time.Second,
+				OnMapping: func(m *portmap.Mapping) {
+					mappings <- m
+				},
+			})
+
+			var mp *portmap.Mapping
+			Eventually(mappings, 10*time.Second).Should(Receive(&mp))
+			Expect(mp.Protocol).To(Equal(portmap.ProtocolNATPMP))
+			Expect(mp.External.Port).To(Equal(52820))
+			Expect(m.Mapping()).To(Equal(mp))
+
+			Expect(m.Close()).To(Succeed())
+			Expect(m.Mapping()).To(BeNil())
+
+			_, ok := r.Mapping(51820)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	It("parses protocols", func() {
+		p, err := portmap.ParseProtocol("nat-pmp")
+		Expect(err).To(Succeed())
+		Expect(p).To(Equal(portmap.ProtocolNATPMP))
+
+		_, err = portmap.ParseProtocol("foo")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("has matching external addresses", func() {
+		c := clients[portmap.ProtocolPCP]()
+
+		m, err := c.Map(ctx, 1234, 0, time.Minute)
+		Expect(err).To(Succeed())
+		Expect(m.External.IP.To4()).To(Equal(net.IP(r.ExternalIP.To4())))
+	})
+})
@@ -0,0 +1,282 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package portmap_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// router is a stand-in for a home router which grants port mappings via PCP, NAT-PMP and UPnP-IGD.
+type router struct {
+	ExternalIP net.IP
+
+	// Enabled protocols
+	PCP    bool
+	NATPMP bool
+	UPnP   bool
+
+	// Offset which is added to the suggested external port
+	PortOffset int
+
+	// Mappings by internal port
+	Mappings map[int]int
+	mu       sync.Mutex
+
+	conn net.PacketConn
+	ssdp net.PacketConn
+	http *httptest.Server
+}
+
+func newRouter() (*router, error) {
+	r := &router{
+		ExternalIP: net.IPv4(203, 0, 113, 1).To4(),
+		PCP:        true,
+		NATPMP:     true,
+		UPnP:       true,
+		Mappings:   map[int]int{},
+	}
+
+	var err error
+	if r.conn, err = net.ListenPacket("udp4", "127.0.0.1:0"); err != nil {
+		return nil, err
+	}
+
+	if r.ssdp, err = net.ListenPacket("udp4", "127.0.0.1:0"); err != nil {
+		return nil, err
+	}
+
+	r.http = httptest.NewServer(r)
+
+	go r.serve()
+	go r.serveSSDP()
+
+	return r, nil
+}
+
+func (r *router) Addr() *net.UDPAddr {
+	return r.conn.LocalAddr().(*net.UDPAddr) //nolint:forcetypeassert
+}
+
+func (r *router) SSDPAddr() *net.UDPAddr {
+	return r.ssdp.LocalAddr().(*net.UDPAddr) //nolint:forcetypeassert
+}
+
+func (r *router) Close() error {
+	r.http.Close()
+	r.ssdp.Close()
+
+	return r.conn.Close()
+}
+
+func (r *router) Mapping(port int) (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ext, ok := r.Mappings[port]
+
+	return ext, ok
+}
+
+func (r *router) mapPort(port, suggested int, lifetime uint32) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if lifetime == 0 {
+		delete(r.Mappings, port)
+
+		return 0
+	}
+
+	if suggested == 0 {
+		suggested = port
+	}
+
+	ext := suggested + r.PortOffset
+	r.Mappings[port] = ext
+
+	return ext
+}
+
+func (r *router) serve() {
+	buf := make([]byte, 1100)
+
+	for {
+		n, addr, err := r.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		var resp []byte
+
+		r.mu.Lock()
+		pcp, natpmp := r.PCP, r.NATPMP
+		r.mu.Unlock()
+
+		switch req := buf[:n]; {
+		case req[0] == 2 && pcp:
+			resp = r.handlePCP(req)
+		case req[0] == 2:
+			// NAT-PMP servers respond to unknown versions with their own version
+			resp = []byte{0, 128 | req[1], 0, 1, 0, 0, 0, 0}
+		case req[0] == 0 && natpmp:
+			resp = r.handleNATPMP(req)
+		default:
+			continue
+		}
+
+		r.conn.WriteTo(resp, addr) //nolint:errcheck
+	}
+}
+
+func (r *router) handlePCP(req []byte) []byte {
+	resp := make([]byte, 60)
+	resp[0] = 2
+	resp[1] = 0x80 | req[1]
+
+	lifetime := binary.BigEndian.Uint32(req[4:8])
+	port := int(binary.BigEndian.Uint16(req[40:42]))
+	suggested := int(binary.BigEndian.Uint16(req[42:44]))
+
+	ext := r.mapPort(port, suggested, lifetime)
+
+	binary.BigEndian.PutUint32(resp[4:8], lifetime)
+	copy(resp[24:60], req[24:60])
+	binary.BigEndian.PutUint16(resp[42:44], uint16(ext)) //nolint:gosec
+	copy(resp[44:60], r.ExternalIP.To16())
+
+	return resp
+}
+
+func (r *router) handleNATPMP(req []byte) []byte {
+	switch req[1] {
+	case 0:
+		resp := make([]byte, 12)
+		resp[1] = 128
+		copy(resp[8:12], r.ExternalIP.To4())
+
+		return resp
+
+	case 1:
+		resp := make([]byte, 16)
+		resp[1] = 129
+
+		port := int(binary.BigEndian.Uint16(req[4:6]))
+		suggested := int(binary.BigEndian.Uint16(req[6:8]))
+		lifetime := binary.BigEndian.Uint32(req[8:12])
+
+		ext := r.mapPort(port, suggested, lifetime)
+
+		copy(resp[8:10], req[4:6])
+		binary.BigEndian.PutUint16(resp[10:12], uint16(ext)) //nolint:gosec
+		binary.BigEndian.PutUint32(resp[12:16], lifetime)
+
+		return resp
+	}
+
+	return nil
+}
+
+func (r *router) serveSSDP() {
+	buf := make([]byte, 1100)
+
+	for {
+		n, addr, err := r.ssdp.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		r.mu.Lock()
+		upnp := r.UPnP
+		r.mu.Unlock()
+
+		if !upnp || !strings.HasPrefix(string(buf[:n]), "M-SEARCH") {
+			continue
+		}
+
+		resp := "HTTP/1.1 200 OK\r\n" +
+			"ST: urn:schemas-upnp-org:device:InternetGatewayDevice:1\r\n" +
+			"LOCATION: " + r.http.URL + "/rootDesc.xml\r\n\r\n"
+
+		r.ssdp.WriteTo([]byte(resp), addr) //nolint:errcheck
+	}
+}
+
+func (r *router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.URL.Path {
+	case "/rootDesc.xml":
+		fmt.Fprint(w, `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <deviceType>urn:schemas-upnp-org:device:InternetGatewayDevice:1</deviceType>
+    <deviceList>
+      <device>
+        <deviceType>urn:schemas-upnp-org:device:WANDevice:1</deviceType>
+        <deviceList>
+          <device>
+            <deviceType>urn:schemas-upnp-org:device:WANConnectionDevice:1</deviceType>
+            <serviceList>
+              <service>
+                <serviceType>urn:schemas-upnp-org:service:WANIPConnection:1</serviceType>
+                <controlURL>/ctl/IPConn</controlURL>
+              </service>
+            </serviceList>
+          </device>
+        </deviceList>
+      </device>
+    </deviceList>
+  </device>
+</root>`)
+
+	case "/ctl/IPConn":
+		body, _ := io.ReadAll(req.Body)
+		action := req.Header.Get("SOAPAction")
+
+		arg := func(name string) int {
+			_, after, _ := strings.Cut(string(body), "<"+name+">")
+			value, _, _ := strings.Cut(after, "<")
+
+			var i int
+			fmt.Sscan(value, &i) //nolint:errcheck
+
+			return i
+		}
+
+		var result string
+
+		switch {
+		case strings.HasSuffix(action, `#AddPortMapping"`):
+			r.mapPort(arg("NewInternalPort"), arg("NewExternalPort"), uint32(arg("NewLeaseDuration"))) //nolint:gosec
+
+		case strings.HasSuffix(action, `#DeletePortMapping"`):
+			r.mu.Lock()
+			for port, ext := range r.Mappings {
+				if ext == arg("NewExternalPort") {
+					delete(r.Mappings, port)
+				}
+			}
+			r.mu.Unlock()
+
+		case strings.HasSuffix(action, `#GetExternalIPAddress"`):
+			result = "<NewExternalIPAddress>" + r.ExternalIP.String() + "</NewExternalIPAddress>"
+
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><s:Fault><detail><UPnPError><errorCode>401</errorCode><errorDescription>Invalid Action</errorDescription></UPnPError></detail></s:Fault></s:Body></s:Envelope>`)
+
+			return
+		}
+
+		fmt.Fprint(w, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><u:Response xmlns:u="urn:schemas-upnp-org:service:WANIPConnection:1">`+result+`</u:Response></s:Body></s:Envelope>`)
+
+	default:
+		http.NotFound(w, req)
+	}
+}
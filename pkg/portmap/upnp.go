@@ -0,0 +1,351 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package portmap
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const upnpSearchTarget = "urn:schemas-upnp-org:device:InternetGatewayDevice:1"
+
+var (
+	errNoGatewayDevice = errors.New("no UPnP internet gateway device found")
+	errSOAPFault       = errors.New("SOAP fault")
+
+	// SSDPAddress is the multicast group to which SSDP discovery requests are sent.
+	SSDPAddress = &net.UDPAddr{ //nolint:gochecknoglobals
+		IP:   net.IPv4(239, 255, 255, 250),
+		Port: 1900,
+	}
+
+	// Services which allow the creation of port mappings, in order of preference.
+	upnpServiceTypes = []string{ //nolint:gochecknoglobals
+		"urn:schemas-upnp-org:service:WANIPConnection:2",
+		"urn:schemas-upnp-org:service:WANIPConnection:1",
+		"urn:schemas-upnp-org:service:WANPPPConnection:1",
+	}
+)
+
+// UPnPClient requests port mappings from UPnP Internet Gateway Devices.
+type UPnPClient struct {
+	// Address to which SSDP discovery requests are sent.
+	// Defaults to the SSDP multicast group.
+	SSDPAddress *net.UDPAddr
+
+	// Description is attached to created port mappings.
+	Description string
+
+	// The control endpoint of the discovered gateway
+	controlURL  *url.URL
+	serviceType string
+}
+
+func (c *UPnPClient) Protocol() Protocol {
+	return ProtocolUPnP
+}
+
+func (c *UPnPClient) Map(ctx context.Context, port, suggested int, lifetime time.Duration) (*Mapping, error) {
+	if c.controlURL == nil {
+		if err := c.discover(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	gateway, err := net.ResolveUDPAddr("udp", net.JoinHostPort(c.controlURL.Hostname(), "1900"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve gateway: %w", err)
+	}
+
+	laddr, err := localAddress(gateway)
+	if err != nil {
+		return nil, err
+	}
+
+	if suggested == 0 {
+		suggested = port
+	}
+
+	desc := c.Description
+	if desc == "" {
+		desc = "cunicu"
+	}
+
+	if _, err := c.call(ctx, "AddPortMapping", [][2]string{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(suggested)},
+		{"NewProtocol", "UDP"},
+		{"NewInternalPort", strconv.Itoa(port)},
+		{"NewInternalClient", laddr.String()},
+		{"NewEnabled", "1"},
+		{"NewPortMappingDescription", desc},
+		{"NewLeaseDuration", strconv.Itoa(int(lifetime.Seconds()))},
+	}); err != nil {
+		// Discover the gateway again in case it has been restarted
+		c.controlURL = nil
+
+		return nil, err
+	}
+
+	resp, err := c.call(ctx, "GetExternalIPAddress", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	extIP := net.ParseIP(resp["NewExternalIPAddress"])
+	if extIP == nil {
+		return nil, fmt.Errorf("%w: invalid external IP address", errInvalidResponse)
+	}
+
+	return &Mapping{
+		Protocol: ProtocolUPnP,
+		Internal: &net.UDPAddr{
+			IP:   laddr,
+			Port: port,
+		},
+		External: &net.UDPAddr{
+			IP:   extIP,
+			Port: suggested,
+		},
+		Lifetime: lifetime,
+		Expires:  time.Now().Add(lifetime),
+	}, nil
+}
+
+func (c *UPnPClient) Unmap(ctx context.Context, m *Mapping) error {
+	if c.controlURL == nil {
+		return nil
+	}
+
+	_, err := c.call(ctx, "DeletePortMapping", [][2]string{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(m.External.Port)},
+		{"NewProtocol", "UDP"},
+	})
+
+	return err
+}
+
+// discover searches for an internet gateway device via SSDP and retrieves its control URL.
+func (c *UPnPClient) discover(ctx context.Context) error {
+	addr := c.SSDPAddress
+	if addr == nil {
+		addr = SSDPAddress
+	}
+
+	req := "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: " + addr.String() + "\r\n" +
+		"ST: " + upnpSearchTarget + "\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: 2\r\n\r\n"
+
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	defer conn.Close()
+
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetReadDeadline(time.Now()) //nolint:errcheck
+	})
+	defer stop()
+
+	if _, err := conn.WriteTo([]byte(req), addr); err != nil {
+		return fmt.Errorf("failed to send search request: %w", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(3 * time.Second)); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 2048)
+
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return errNoGatewayDevice
+		}
+
+		resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+		if err != nil {
+			continue
+		}
+
+		resp.Body.Close()
+
+		location := resp.Header.Get("Location")
+		if resp.StatusCode != http.StatusOK || location == "" {
+			continue
+		}
+
+		if err := c.describe(ctx, location); err == nil {
+			return nil
+		}
+	}
+}
+
+type upnpService struct {
+	ServiceType string `xml:"serviceType"`
+	ControlURL  string `xml:"controlURL"`
+}
+
+type upnpDevice struct {
+	Services []upnpService `xml:"serviceList>service"`
+	Devices  []upnpDevice  `xml:"deviceList>device"`
+}
+
+func (d *upnpDevice) service(typ string) *upnpService {
+	for i := range d.Services {
+		if d.Services[i].ServiceType == typ {
+			return &d.Services[i]
+		}
+	}
+
+	for i := range d.Devices {
+		if s := d.Devices[i].service(typ); s != nil {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// describe fetches the device description and looks up a service which allows the creation of port mappings.
+func (c *UPnPClient) describe(ctx context.Context, location string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	var root struct {
+		URLBase string     `xml:"URLBase"`
+		Device  upnpDevice `xml:"device"`
+	}
+
+	if err := xml.NewDecoder(resp.Body).Decode(&root); err != nil {
+		return fmt.Errorf("failed to decode device description: %w", err)
+	}
+
+	base, err := url.Parse(location)
+	if err != nil {
+		return err
+	}
+
+	if root.URLBase != "" {
+		if base, err = url.Parse(root.URLBase); err != nil {
+			return err
+		}
+	}
+
+	for _, typ := range upnpServiceTypes {
+		if s := root.Device.service(typ); s != nil {
+			if c.controlURL, err = base.Parse(s.ControlURL); err != nil {
+				return err
+			}
+
+			c.serviceType = typ
+
+			return nil
+		}
+	}
+
+	return errNoGatewayDevice
+}
+
+// call invokes a SOAP action of the gateway and returns the arguments of the response.
+func (c *UPnPClient) call(ctx context.Context, action string, args [][2]string) (map[string]string, error) {
+	body := &bytes.Buffer{}
+	body.WriteString(`<?xml version="1.0"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">` +
+		`<s:Body><u:` + action + ` xmlns:u="` + c.serviceType + `">`)
+
+	for _, arg := range args {
+		body.WriteString("<" + arg[0] + ">")
+		xml.EscapeText(body, []byte(arg[1])) //nolint:errcheck
+		body.WriteString("</" + arg[0] + ">")
+	}
+
+	body.WriteString(`</u:` + action + `></s:Body></s:Envelope>`)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.controlURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPAction", `"`+c.serviceType+"#"+action+`"`)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke %s: %w", action, err)
+	}
+
+	defer resp.Body.Close()
+
+	values, err := decodeSOAPResponse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s failed with error %s: %s", errSOAPFault, action, values["errorCode"], values["errorDescription"])
+	}
+
+	return values, nil
+}
+
+// decodeSOAPResponse collects the text of all leaf elements in a SOAP response.
+func decodeSOAPResponse(r io.Reader) (map[string]string, error) {
+	values := map[string]string{}
+	dec := xml.NewDecoder(r)
+
+	var (
+		name string
+		text strings.Builder
+	)
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return values, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode SOAP response: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name = t.Name.Local
+			text.Reset()
+
+		case xml.CharData:
+			text.Write(t)
+
+		case xml.EndElement:
+			if t.Name.Local == name {
+				values[name] = strings.TrimSpace(text.String())
+			}
+
+			name = ""
+		}
+	}
+}
@@ -77,6 +77,12 @@ func (i *Interface) Dump(wr io.Writer, level log.Level) error {
 		return err
 	}
 
+	if i.Ice != nil && i.Ice.NatDiscovery != nil {
+		if _, err := tty.FprintKV(wri, "nat", i.Ice.NatDiscovery.ToString()); err != nil {
+			return err
+		}
+	}
+
 	if i.Ice != nil && level.Verbosity() > 3 {
 		if _, err := fmt.Fprintln(wr); err != nil {
 			return err
@@ -134,6 +134,17 @@ func (p *Peer) Dump(wr io.Writer, level log.Level) error { //nolint:gocognit
 		return err
 	}
 
+	if len(p.Services) > 0 {
+		svcs := []string{}
+		for _, s := range p.Services {
+			svcs = append(svcs, fmt.Sprintf("%s:%d", s.Name, s.Port))
+		}
+
+		if _, err := tty.FprintKV(wri, "services", strings.Join(svcs, ", ")); err != nil {
+			return err
+		}
+	}
+
 	if p.Ice != nil {
 		if _, err := fmt.Fprintln(wr); err != nil {
 			return err
@@ -70,6 +70,27 @@ func (i *Interface) Dump(wr io.Writer, level log.Level) error {
 				return err
 			}
 		}
+
+		if d := i.NatDiscovery; d != nil {
+			if _, err := tty.FprintKV(wr, "mapped address", d.MappedAddress); err != nil {
+				return err
+			}
+
+			if _, err := tty.FprintKV(wr, "nat discovery", fmt.Sprintf("%s via %s", tty.Ago(d.Timestamp.Time()), d.Server)); err != nil {
+				return err
+			}
+		}
+
+		if i.TcpMuxPort != 0 {
+			v := fmt.Sprint(i.TcpMuxPort)
+			if i.TcpMappedAddress != "" {
+				v += fmt.Sprintf(", mapped to %s", i.TcpMappedAddress)
+			}
+
+			if _, err := tty.FprintKV(wr, "ice-tcp port", v); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -105,7 +105,21 @@ func (c *Candidate) ICECandidate() (ice.Candidate, error) {
 		err = fmt.Errorf("%w: %s", ice.ErrUnknownCandidateTyp, c.Type)
 	}
 
-	return ic, err
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the config of host candidates has a TCP type
+	if tt := ice.TCPType(c.TcpType); tt != ice.TCPTypeUnspecified && c.Type != CandidateType_HOST {
+		if err := ic.AddExtension(ice.CandidateExtension{
+			Key:   "tcptype",
+			Value: tt.String(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return ic, nil
 }
 
 func NewCandidatePairStats(cps *ice.CandidatePairStats) *CandidatePairStats {
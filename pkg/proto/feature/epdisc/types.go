@@ -33,3 +33,27 @@ func (p RelayProtocol) ToString() string {
 
 	return "unknown"
 }
+
+func (b NATBehavior) ToString() string {
+	switch b {
+	case NATBehavior_ENDPOINT_INDEPENDENT:
+		return "endpoint-independent"
+	case NATBehavior_ADDRESS_DEPENDENT:
+		return "address-dependent"
+	case NATBehavior_ADDRESS_AND_PORT_DEPENDENT:
+		return "address-and-port-dependent"
+	case NATBehavior_UNKNOWN_BEHAVIOR:
+	}
+
+	return "unknown"
+}
+
+func (d *NATDiscovery) ToString() string {
+	filtering := d.Filtering.ToString() + " filtering"
+
+	if !d.BehindNat {
+		return "none, " + filtering
+	}
+
+	return d.Mapping.ToString() + " mapping, " + filtering
+}
@@ -0,0 +1,305 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	protox "cunicu.li/cunicu/pkg/proto"
+)
+
+const (
+	PEMTypeCertificate = "CUNICU COMMUNITY CERTIFICATE"
+	PEMTypePrivateKey  = "PRIVATE KEY"
+)
+
+var (
+	ErrNoCertificate        = errors.New("missing certificate")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrCertificateExpired   = errors.New("certificate expired")
+	ErrCertificateNotBefore = errors.New("certificate is not valid yet")
+	ErrUntrustedIssuer      = errors.New("certificate is not issued by a trusted CA")
+	ErrNotCA                = errors.New("issuer is not a CA")
+	ErrKeyMismatch          = errors.New("certificate does not match public key")
+	ErrHostnameMismatch     = errors.New("hostname does not match certificate")
+	ErrTagNotCertified      = errors.New("tag is not certified by a role")
+	errNotEd25519Key        = errors.New("not an Ed25519 private key")
+	errInvalidPEM           = errors.New("invalid PEM block")
+)
+
+// GenerateCAKey generates a new Ed25519 key pair for a community certificate authority.
+func GenerateCAKey() (crypto.Key, ed25519.PrivateKey, error) {
+	pk, sk, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		return crypto.Key{}, nil, err
+	}
+
+	return crypto.Key(pk), sk, nil
+}
+
+// Sign signs the certificate with the private key of the issuer.
+func (c *Certificate) Sign(sk ed25519.PrivateKey) error {
+	pk, ok := sk.Public().(ed25519.PublicKey)
+	if !ok {
+		return errNotEd25519Key
+	}
+
+	c.IssuerKey = pk
+	c.Signature = nil
+
+	msg, err := c.signedBytes()
+	if err != nil {
+		return err
+	}
+
+	c.Signature = ed25519.Sign(sk, msg)
+
+	return nil
+}
+
+// Verify checks the signature and validity period of the certificate.
+func (c *Certificate) Verify(now time.Time) error {
+	if len(c.IssuerKey) != ed25519.PublicKeySize {
+		return ErrInvalidSignature
+	}
+
+	msg, err := c.signedBytes()
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(c.IssuerKey, msg, c.Signature) {
+		return ErrInvalidSignature
+	}
+
+	if c.NotBefore != nil && now.Before(c.NotBefore.Time()) {
+		return ErrCertificateNotBefore
+	}
+
+	if c.NotAfter != nil && now.After(c.NotAfter.Time()) {
+		return ErrCertificateExpired
+	}
+
+	return nil
+}
+
+func (c *Certificate) signedBytes() ([]byte, error) {
+	cc, ok := proto.Clone(c).(*Certificate)
+	if !ok {
+		panic("type assertion failed")
+	}
+
+	cc.Signature = nil
+
+	return proto.MarshalOptions{Deterministic: true}.Marshal(cc)
+}
+
+// Tags returns the tags certified by the roles of the certificate.
+// A role of the form "key=value" certifies the tag key with the value value.
+// Any other role certifies a tag with an empty value.
+func (c *Certificate) Tags() map[string]string {
+	tags := map[string]string{}
+
+	for _, role := range c.Roles {
+		key, value, _ := strings.Cut(role, "=")
+		tags[key] = value
+	}
+
+	return tags
+}
+
+// VerifyCertificateChain checks that the certificate chain has been issued for pk
+// by one of the trusted CAs and returns the first certificate of the chain.
+func VerifyCertificateChain(chain []*Certificate, pk crypto.Key, cas []crypto.Key, now time.Time) (*Certificate, error) {
+	if len(chain) == 0 {
+		return nil, ErrNoCertificate
+	}
+
+	for k, c := range chain {
+		if err := c.Verify(now); err != nil {
+			return nil, fmt.Errorf("certificate %d: %w", k, err)
+		}
+
+		if k == 0 {
+			if !bytes.Equal(c.PublicKey, pk.Bytes()) {
+				return nil, ErrKeyMismatch
+			}
+		} else {
+			if !c.Ca {
+				return nil, fmt.Errorf("certificate %d: %w", k, ErrNotCA)
+			}
+
+			if !bytes.Equal(c.PublicKey, chain[k-1].IssuerKey) {
+				return nil, fmt.Errorf("certificate %d: %w", k, ErrKeyMismatch)
+			}
+		}
+
+		if slices.ContainsFunc(cas, func(ca crypto.Key) bool {
+			return bytes.Equal(ca.Bytes(), c.IssuerKey)
+		}) {
+			return chain[0], nil
+		}
+	}
+
+	return nil, ErrUntrustedIssuer
+}
+
+// VerifyCertificates checks that the peer description carries a valid certificate chain
+// issued by one of the trusted CAs and returns the certificate of the peer.
+func (pd *PeerDescription) VerifyCertificates(cas []crypto.Key, now time.Time) (*Certificate, error) {
+	pkb := pd.PublicKey
+	if pd.PublicKeyNew != nil {
+		pkb = pd.PublicKeyNew
+	}
+
+	pk, err := crypto.ParseKeyBytes(pkb)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	c, err := VerifyCertificateChain(pd.Certificates, pk, cas, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Hostname != "" {
+		if pd.Name != c.Hostname {
+			return nil, fmt.Errorf("%w: %s != %s", ErrHostnameMismatch, pd.Name, c.Hostname)
+		}
+
+		// Names advertised for the hosts synchronization must be the certified hostname or one of its subdomains
+		for name := range pd.Hosts {
+			if name != c.Hostname && !strings.HasSuffix(name, "."+c.Hostname) {
+				return nil, fmt.Errorf("%w: %s", ErrHostnameMismatch, name)
+			}
+		}
+	}
+
+	certified := c.Tags()
+	for key, value := range pd.Tags {
+		if cv, ok := certified[key]; !ok || cv != value {
+			return nil, fmt.Errorf("%w: %s=%s", ErrTagNotCertified, key, value)
+		}
+	}
+
+	return c, nil
+}
+
+// NewCertificate creates a new unsigned certificate for the public key pk
+// which is valid for the duration validity.
+func NewCertificate(pk []byte, validity time.Duration) *Certificate {
+	now := time.Now()
+
+	return &Certificate{
+		PublicKey: pk,
+		NotBefore: protox.Time(now),
+		NotAfter:  protox.Time(now.Add(validity)),
+	}
+}
+
+// MarshalCertificatesPEM encodes a certificate chain into PEM blocks.
+func MarshalCertificatesPEM(chain []*Certificate) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	for _, c := range chain {
+		der, err := proto.Marshal(c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal certificate: %w", err)
+		}
+
+		if err := pem.Encode(buf, &pem.Block{
+			Type:  PEMTypeCertificate,
+			Bytes: der,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ParseCertificatesPEM decodes a certificate chain from PEM blocks.
+func ParseCertificatesPEM(buf []byte) ([]*Certificate, error) {
+	chain := []*Certificate{}
+
+	for {
+		var blk *pem.Block
+		if blk, buf = pem.Decode(buf); blk == nil {
+			break
+		}
+
+		if blk.Type != PEMTypeCertificate {
+			return nil, fmt.Errorf("%w: unexpected type %s", errInvalidPEM, blk.Type)
+		}
+
+		c := &Certificate{}
+		if err := proto.Unmarshal(blk.Bytes, c); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal certificate: %w", err)
+		}
+
+		chain = append(chain, c)
+	}
+
+	if len(chain) == 0 {
+		return nil, ErrNoCertificate
+	}
+
+	return chain, nil
+}
+
+// LoadCertificates reads a certificate chain from a PEM file.
+func LoadCertificates(fn string) ([]*Certificate, error) {
+	buf, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseCertificatesPEM(buf)
+}
+
+// MarshalCAKeyPEM encodes the private key of a CA as a PKCS #8 PEM block.
+func MarshalCAKeyPEM(sk ed25519.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  PEMTypePrivateKey,
+		Bytes: der,
+	}), nil
+}
+
+// ParseCAKeyPEM decodes the private key of a CA from a PKCS #8 PEM block.
+// Such keys can also be generated by "openssl genpkey -algorithm ed25519".
+func ParseCAKeyPEM(buf []byte) (ed25519.PrivateKey, error) {
+	blk, _ := pem.Decode(buf)
+	if blk == nil || blk.Type != PEMTypePrivateKey {
+		return nil, errInvalidPEM
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(blk.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	sk, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errNotEd25519Key
+	}
+
+	return sk, nil
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc_test
+
+import (
+	"crypto/ed25519"
+	"time"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("certificates", func() {
+	var (
+		caPK  crypto.Key
+		caSK  ed25519.PrivateKey
+		pk    crypto.Key
+		cert  *pdiscproto.Certificate
+		cas   []crypto.Key
+		chain []*pdiscproto.Certificate
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		caPK, caSK, err = pdiscproto.GenerateCAKey()
+		Expect(err).To(Succeed())
+
+		sk, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		pk = sk.PublicKey()
+		cas = []crypto.Key{caPK}
+
+		cert = pdiscproto.NewCertificate(pk.Bytes(), time.Hour)
+		cert.Hostname = "my-node"
+		cert.Roles = []string{"server"}
+
+		Expect(cert.Sign(caSK)).To(Succeed())
+
+		chain = []*pdiscproto.Certificate{cert}
+	})
+
+	It("accepts a valid certificate", func() {
+		c, err := pdiscproto.VerifyCertificateChain(chain, pk, cas, time.Now())
+		Expect(err).To(Succeed())
+		Expect(c.Roles).To(ConsistOf("server"))
+	})
+
+	It("rejects a missing certificate", func() {
+		_, err := pdiscproto.VerifyCertificateChain(nil, pk, cas, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrNoCertificate))
+	})
+
+	It("rejects a tampered certificate", func() {
+		cert.Roles = append(cert.Roles, "admin")
+
+		_, err := pdiscproto.VerifyCertificateChain(chain, pk, cas, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrInvalidSignature))
+	})
+
+	It("rejects an expired certificate", func() {
+		_, err := pdiscproto.VerifyCertificateChain(chain, pk, cas, time.Now().Add(2*time.Hour))
+		Expect(err).To(MatchError(pdiscproto.ErrCertificateExpired))
+	})
+
+	It("rejects a certificate for another key", func() {
+		sk, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		_, err = pdiscproto.VerifyCertificateChain(chain, sk.PublicKey(), cas, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrKeyMismatch))
+	})
+
+	It("rejects a certificate of an untrusted CA", func() {
+		otherPK, _, err := pdiscproto.GenerateCAKey()
+		Expect(err).To(Succeed())
+
+		_, err = pdiscproto.VerifyCertificateChain(chain, pk, []crypto.Key{otherPK}, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrUntrustedIssuer))
+	})
+
+	Context("intermediate CA", func() {
+		var interCert *pdiscproto.Certificate
+
+		BeforeEach(func() {
+			interPK, interSK, err := pdiscproto.GenerateCAKey()
+			Expect(err).To(Succeed())
+
+			interCert = pdiscproto.NewCertificate(interPK.Bytes(), time.Hour)
+			interCert.Ca = true
+
+			Expect(interCert.Sign(caSK)).To(Succeed())
+			Expect(cert.Sign(interSK)).To(Succeed())
+
+			chain = []*pdiscproto.Certificate{cert, interCert}
+		})
+
+		It("accepts a valid chain", func() {
+			_, err := pdiscproto.VerifyCertificateChain(chain, pk, cas, time.Now())
+			Expect(err).To(Succeed())
+		})
+
+		It("rejects a chain with a non-CA intermediate", func() {
+			interCert.Ca = false
+			Expect(interCert.Sign(caSK)).To(Succeed())
+
+			_, err := pdiscproto.VerifyCertificateChain(chain, pk, cas, time.Now())
+			Expect(err).To(MatchError(pdiscproto.ErrNotCA))
+		})
+
+		It("rejects an incomplete chain", func() {
+			_, err := pdiscproto.VerifyCertificateChain(chain[:1], pk, cas, time.Now())
+			Expect(err).To(MatchError(pdiscproto.ErrUntrustedIssuer))
+		})
+	})
+
+	It("checks the hostname of a peer description", func() {
+		pd := &pdiscproto.PeerDescription{
+			Name:         "my-node",
+			PublicKey:    pk.Bytes(),
+			Certificates: chain,
+		}
+
+		_, err := pd.VerifyCertificates(cas, time.Now())
+		Expect(err).To(Succeed())
+
+		pd.Name = "other-node"
+
+		_, err = pd.VerifyCertificates(cas, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrHostnameMismatch))
+	})
+
+	It("checks the hosts of a peer description", func() {
+		pd := &pdiscproto.PeerDescription{
+			Name:         "my-node",
+			PublicKey:    pk.Bytes(),
+			Certificates: chain,
+			Hosts: map[string]*pdiscproto.PeerAddresses{
+				"my-node":     {},
+				"www.my-node": {},
+			},
+		}
+
+		_, err := pd.VerifyCertificates(cas, time.Now())
+		Expect(err).To(Succeed())
+
+		pd.Hosts["other-node"] = &pdiscproto.PeerAddresses{}
+
+		_, err = pd.VerifyCertificates(cas, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrHostnameMismatch))
+	})
+
+	It("checks the tags of a peer description", func() {
+		pd := &pdiscproto.PeerDescription{
+			Name:         "my-node",
+			PublicKey:    pk.Bytes(),
+			Certificates: chain,
+			Tags:         map[string]string{"server": ""},
+		}
+
+		_, err := pd.VerifyCertificates(cas, time.Now())
+		Expect(err).To(Succeed())
+
+		pd.Tags["role"] = "gateway"
+
+		_, err = pd.VerifyCertificates(cas, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrTagNotCertified))
+	})
+
+	It("certifies tags by roles", func() {
+		cert.Roles = []string{"server", "site=berlin"}
+
+		Expect(cert.Tags()).To(Equal(map[string]string{
+			"server": "",
+			"site":   "berlin",
+		}))
+	})
+
+	It("can be encoded in PEM", func() {
+		buf, err := pdiscproto.MarshalCertificatesPEM(chain)
+		Expect(err).To(Succeed())
+
+		chain2, err := pdiscproto.ParseCertificatesPEM(buf)
+		Expect(err).To(Succeed())
+
+		_, err = pdiscproto.VerifyCertificateChain(chain2, pk, cas, time.Now())
+		Expect(err).To(Succeed())
+	})
+
+	It("can encode CA keys in PEM", func() {
+		buf, err := pdiscproto.MarshalCAKeyPEM(caSK)
+		Expect(err).To(Succeed())
+
+		sk, err := pdiscproto.ParseCAKeyPEM(buf)
+		Expect(err).To(Succeed())
+		Expect(sk.Equal(caSK)).To(BeTrue())
+	})
+})
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc
+
+import (
+	"errors"
+	"time"
+
+	"cunicu.li/cunicu/pkg/crypto"
+)
+
+var (
+	ErrNoSuchExitNode    = errors.New("no peer is advertising itself as exit node")
+	ErrAmbiguousExitNode = errors.New("multiple exit nodes advertise the same hostname")
+)
+
+// SelectExitNode returns the public key of the peer which advertises itself as exit node
+// and matches the given hostname or public key.
+// A match by public key takes precedence over a match by hostname.
+//
+// Hostnames are claimed by the peers themselves.
+// Hence, only hostnames certified by one of the CAs are matched if any CAs are given.
+// If multiple peers advertise the same hostname, ErrAmbiguousExitNode is returned
+// and the exit node must be selected by its public key.
+func SelectExitNode(descs map[crypto.Key]*PeerDescription, name string, cas []crypto.Key, now time.Time) (crypto.Key, error) {
+	if name == "" {
+		return crypto.Key{}, ErrNoSuchExitNode
+	}
+
+	if pk, err := crypto.ParseKey(name); err == nil {
+		if d, ok := descs[pk]; ok && d.ExitNode {
+			return pk, nil
+		}
+	}
+
+	var (
+		sel   crypto.Key
+		found bool
+	)
+
+	for pk, d := range descs {
+		if !d.ExitNode || d.Name != name {
+			continue
+		}
+
+		if len(cas) > 0 {
+			if c, err := d.VerifyCertificates(cas, now); err != nil || c.Hostname != name {
+				continue
+			}
+		}
+
+		if found {
+			return crypto.Key{}, ErrAmbiguousExitNode
+		}
+
+		sel, found = pk, true
+	}
+
+	if !found {
+		return crypto.Key{}, ErrNoSuchExitNode
+	}
+
+	return sel, nil
+}
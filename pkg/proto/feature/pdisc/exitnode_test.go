@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc_test
+
+import (
+	"time"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("exit node selection", func() {
+	var a, b, c crypto.Key
+	var descs map[crypto.Key]*pdiscproto.PeerDescription
+
+	BeforeEach(func() {
+		for _, pk := range []*crypto.Key{&a, &b, &c} {
+			sk, err := crypto.GeneratePrivateKey()
+			Expect(err).To(Succeed())
+
+			*pk = sk.PublicKey()
+		}
+
+		descs = map[crypto.Key]*pdiscproto.PeerDescription{
+			a: {Name: "gw", PublicKey: a.Bytes(), ExitNode: true},
+			b: {Name: "laptop", PublicKey: b.Bytes()},
+			c: {Name: "other-gw", PublicKey: c.Bytes(), ExitNode: true},
+		}
+	})
+
+	It("selects an exit node by hostname", func() {
+		pk, err := pdiscproto.SelectExitNode(descs, "gw", nil, time.Now())
+		Expect(err).To(Succeed())
+		Expect(pk).To(Equal(a))
+	})
+
+	It("selects an exit node by public key", func() {
+		pk, err := pdiscproto.SelectExitNode(descs, c.String(), nil, time.Now())
+		Expect(err).To(Succeed())
+		Expect(pk).To(Equal(c))
+	})
+
+	It("does not select peers which are no exit nodes", func() {
+		_, err := pdiscproto.SelectExitNode(descs, "laptop", nil, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrNoSuchExitNode))
+
+		_, err = pdiscproto.SelectExitNode(descs, b.String(), nil, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrNoSuchExitNode))
+	})
+
+	It("does not select unknown peers", func() {
+		_, err := pdiscproto.SelectExitNode(descs, "unknown", nil, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrNoSuchExitNode))
+	})
+
+	It("selects nothing for an empty name", func() {
+		descs[b].Name = ""
+		descs[b].ExitNode = true
+
+		_, err := pdiscproto.SelectExitNode(descs, "", nil, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrNoSuchExitNode))
+	})
+
+	It("prefers a match by public key", func() {
+		descs[a].Name = c.String()
+
+		pk, err := pdiscproto.SelectExitNode(descs, c.String(), nil, time.Now())
+		Expect(err).To(Succeed())
+		Expect(pk).To(Equal(c))
+	})
+
+	It("refuses to select an exit node if multiple peers advertise the same hostname", func() {
+		descs[c].Name = "gw"
+
+		_, err := pdiscproto.SelectExitNode(descs, "gw", nil, time.Now())
+		Expect(err).To(MatchError(pdiscproto.ErrAmbiguousExitNode))
+
+		// The public key still selects the exit node unambiguously
+		pk, err := pdiscproto.SelectExitNode(descs, c.String(), nil, time.Now())
+		Expect(err).To(Succeed())
+		Expect(pk).To(Equal(c))
+	})
+
+	Context("with community certificates", func() {
+		var cas []crypto.Key
+
+		BeforeEach(func() {
+			caPK, caSK, err := pdiscproto.GenerateCAKey()
+			Expect(err).To(Succeed())
+
+			cas = []crypto.Key{caPK}
+
+			cert := pdiscproto.NewCertificate(a.Bytes(), time.Hour)
+			cert.Hostname = "gw"
+
+			Expect(cert.Sign(caSK)).To(Succeed())
+
+			descs[a].Certificates = []*pdiscproto.Certificate{cert}
+		})
+
+		It("selects an exit node by its certified hostname", func() {
+			pk, err := pdiscproto.SelectExitNode(descs, "gw", cas, time.Now())
+			Expect(err).To(Succeed())
+			Expect(pk).To(Equal(a))
+		})
+
+		It("ignores peers which claim a hostname without certificate", func() {
+			descs[c].Name = "gw"
+
+			pk, err := pdiscproto.SelectExitNode(descs, "gw", cas, time.Now())
+			Expect(err).To(Succeed())
+			Expect(pk).To(Equal(a))
+
+			_, err = pdiscproto.SelectExitNode(descs, "other-gw", cas, time.Now())
+			Expect(err).To(MatchError(pdiscproto.ErrNoSuchExitNode))
+		})
+	})
+})
@@ -4,7 +4,6 @@
 package pdisc
 
 import (
-	"fmt"
 	"net"
 
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
@@ -22,13 +21,12 @@ func (pd *PeerDescription) Config() wgtypes.PeerConfig {
 
 	allowedIPs := []net.IPNet{}
 
+	// Descriptions are received from remote peers.
+	// Hence, we skip malformed prefixes rather than failing.
 	for _, allowedIP := range pd.AllowedIps {
-		_, ipnet, err := net.ParseCIDR(allowedIP)
-		if err != nil {
-			panic(fmt.Errorf("failed to parse WireGuard AllowedIP: %w", err))
+		if _, ipnet, err := net.ParseCIDR(allowedIP); err == nil {
+			allowedIPs = append(allowedIPs, *ipnet)
 		}
-
-		allowedIPs = append(allowedIPs, *ipnet)
 	}
 
 	return wgtypes.PeerConfig{
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc_test
+
+import (
+	"testing"
+
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+	"cunicu.li/cunicu/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSuite(t *testing.T) {
+	test.SetupLogging()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Peer Discovery Protobuf Suite")
+}
+
+var _ = Describe("peer description", func() {
+	It("skips malformed AllowedIPs", func() {
+		d := &pdiscproto.PeerDescription{
+			AllowedIps: []string{"10.237.0.1/32", "not-a-prefix", "fc2f:9a4d::1/128"},
+		}
+
+		cfg := d.Config()
+		Expect(cfg.ReplaceAllowedIPs).To(BeTrue())
+		Expect(cfg.AllowedIPs).To(HaveLen(2))
+		Expect(cfg.AllowedIPs[0].String()).To(Equal("10.237.0.1/32"))
+		Expect(cfg.AllowedIPs[1].String()).To(Equal("fc2f:9a4d::1/128"))
+	})
+})
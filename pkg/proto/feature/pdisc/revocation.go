@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	protox "cunicu.li/cunicu/pkg/proto"
+)
+
+const PEMTypeRevocation = "CUNICU COMMUNITY REVOCATION"
+
+// NewRevocation creates a new unsigned revocation of the public key pk.
+func NewRevocation(pk []byte, reason string) *Revocation {
+	return &Revocation{
+		PublicKey: pk,
+		Timestamp: protox.Time(time.Now()),
+		Reason:    reason,
+	}
+}
+
+// Sign signs the revocation with the private key of the issuer.
+// The certificate chain of an intermediate issuing CA must be set before signing.
+func (r *Revocation) Sign(sk ed25519.PrivateKey) error {
+	pk, ok := sk.Public().(ed25519.PublicKey)
+	if !ok {
+		return errNotEd25519Key
+	}
+
+	r.IssuerKey = pk
+	r.Signature = nil
+
+	msg, err := r.signedBytes()
+	if err != nil {
+		return err
+	}
+
+	r.Signature = ed25519.Sign(sk, msg)
+
+	return nil
+}
+
+// Verify checks that the revocation has been signed either by one of the trusted CAs
+// or by an intermediate CA whose certificate chain has been issued by one of them.
+func (r *Revocation) Verify(cas []crypto.Key, now time.Time) error {
+	if len(r.IssuerKey) != ed25519.PublicKeySize {
+		return ErrInvalidSignature
+	}
+
+	msg, err := r.signedBytes()
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(r.IssuerKey, msg, r.Signature) {
+		return ErrInvalidSignature
+	}
+
+	if slices.ContainsFunc(cas, func(ca crypto.Key) bool {
+		return bytes.Equal(ca.Bytes(), r.IssuerKey)
+	}) {
+		return nil
+	}
+
+	if len(r.Certificates) == 0 {
+		return ErrUntrustedIssuer
+	}
+
+	issuer, err := crypto.ParseKeyBytes(r.IssuerKey)
+	if err != nil {
+		return err
+	}
+
+	c, err := VerifyCertificateChain(r.Certificates, issuer, cas, now)
+	if err != nil {
+		return err
+	}
+
+	if !c.Ca {
+		return ErrNotCA
+	}
+
+	return nil
+}
+
+func (r *Revocation) signedBytes() ([]byte, error) {
+	rc, ok := proto.Clone(r).(*Revocation)
+	if !ok {
+		panic("type assertion failed")
+	}
+
+	rc.Signature = nil
+
+	return proto.MarshalOptions{Deterministic: true}.Marshal(rc)
+}
+
+// MarshalRevocationsPEM encodes a list of revocations into PEM blocks.
+func MarshalRevocationsPEM(rs []*Revocation) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	for _, r := range rs {
+		der, err := proto.Marshal(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal revocation: %w", err)
+		}
+
+		if err := pem.Encode(buf, &pem.Block{
+			Type:  PEMTypeRevocation,
+			Bytes: der,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ParseRevocationsPEM decodes a list of revocations from PEM blocks.
+func ParseRevocationsPEM(buf []byte) ([]*Revocation, error) {
+	rs := []*Revocation{}
+
+	for {
+		var blk *pem.Block
+		if blk, buf = pem.Decode(buf); blk == nil {
+			break
+		}
+
+		if blk.Type != PEMTypeRevocation {
+			return nil, fmt.Errorf("%w: unexpected type %s", errInvalidPEM, blk.Type)
+		}
+
+		r := &Revocation{}
+		if err := proto.Unmarshal(blk.Bytes, r); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal revocation: %w", err)
+		}
+
+		rs = append(rs, r)
+	}
+
+	return rs, nil
+}
+
+// LoadRevocations reads a list of revocations from a PEM file.
+func LoadRevocations(fn string) ([]*Revocation, error) {
+	buf, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRevocationsPEM(buf)
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc_test
+
+import (
+	"crypto/ed25519"
+	"time"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("revocations", func() {
+	var (
+		caSK ed25519.PrivateKey
+		cas  []crypto.Key
+		r    *pdiscproto.Revocation
+	)
+
+	BeforeEach(func() {
+		caPK, sk, err := pdiscproto.GenerateCAKey()
+		Expect(err).To(Succeed())
+
+		caSK = sk
+		cas = []crypto.Key{caPK}
+
+		peerSK, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		r = pdiscproto.NewRevocation(peerSK.PublicKey().Bytes(), "compromised")
+	})
+
+	It("accepts a revocation of a trusted CA", func() {
+		Expect(r.Sign(caSK)).To(Succeed())
+		Expect(r.Verify(cas, time.Now())).To(Succeed())
+	})
+
+	It("rejects a tampered revocation", func() {
+		Expect(r.Sign(caSK)).To(Succeed())
+
+		r.Reason = "other"
+
+		Expect(r.Verify(cas, time.Now())).To(MatchError(pdiscproto.ErrInvalidSignature))
+	})
+
+	It("rejects a revocation of an untrusted CA", func() {
+		_, otherSK, err := pdiscproto.GenerateCAKey()
+		Expect(err).To(Succeed())
+
+		Expect(r.Sign(otherSK)).To(Succeed())
+		Expect(r.Verify(cas, time.Now())).To(MatchError(pdiscproto.ErrUntrustedIssuer))
+	})
+
+	Context("intermediate CA", func() {
+		var (
+			interSK   ed25519.PrivateKey
+			interCert *pdiscproto.Certificate
+		)
+
+		BeforeEach(func() {
+			interPK, sk, err := pdiscproto.GenerateCAKey()
+			Expect(err).To(Succeed())
+
+			interSK = sk
+			interCert = pdiscproto.NewCertificate(interPK.Bytes(), time.Hour)
+			interCert.Ca = true
+
+			Expect(interCert.Sign(caSK)).To(Succeed())
+		})
+
+		It("accepts a revocation with a valid chain", func() {
+			r.Certificates = []*pdiscproto.Certificate{interCert}
+
+			Expect(r.Sign(interSK)).To(Succeed())
+			Expect(r.Verify(cas, time.Now())).To(Succeed())
+		})
+
+		It("rejects a revocation of a non-CA issuer", func() {
+			interCert.Ca = false
+			Expect(interCert.Sign(caSK)).To(Succeed())
+
+			r.Certificates = []*pdiscproto.Certificate{interCert}
+
+			Expect(r.Sign(interSK)).To(Succeed())
+			Expect(r.Verify(cas, time.Now())).To(MatchError(pdiscproto.ErrNotCA))
+		})
+	})
+
+	It("can be encoded in PEM", func() {
+		Expect(r.Sign(caSK)).To(Succeed())
+
+		buf, err := pdiscproto.MarshalRevocationsPEM([]*pdiscproto.Revocation{r})
+		Expect(err).To(Succeed())
+
+		rs, err := pdiscproto.ParseRevocationsPEM(buf)
+		Expect(err).To(Succeed())
+		Expect(rs).To(HaveLen(1))
+		Expect(rs[0].Verify(cas, time.Now())).To(Succeed())
+	})
+})
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc
+
+import (
+	"bytes"
+	"errors"
+
+	"golang.org/x/crypto/nacl/box"
+
+	"cunicu.li/cunicu/pkg/crypto"
+)
+
+const nonceLength = 24
+
+var (
+	ErrNoKeyRotation      = errors.New("description does not rotate a key")
+	ErrInvalidKeyRotation = errors.New("key rotation is not proven by the old key")
+)
+
+// ProveKeyRotation attests the new public key of the description with the old private key.
+// The proof is sealed for the community so that all members can verify it.
+func (pd *PeerDescription) ProveKeyRotation(skOld, community crypto.Key) error {
+	if pd.PublicKeyNew == nil {
+		return ErrNoKeyRotation
+	}
+
+	nonce, err := crypto.GetNonce(nonceLength)
+	if err != nil {
+		return err
+	}
+
+	pkCommunity := community.PublicKey()
+
+	pd.KeyRotationProof = box.Seal(nonce, pd.PublicKeyNew, (*[nonceLength]byte)(nonce), (*[32]byte)(&pkCommunity), (*[32]byte)(&skOld))
+
+	return nil
+}
+
+// VerifyKeyRotation checks that the new public key of the description has been attested by the old key.
+// Together with the signature of the new key on the signaling envelope this proves the possession of both keys.
+func (pd *PeerDescription) VerifyKeyRotation(community crypto.Key) error {
+	if pd.PublicKeyNew == nil {
+		return ErrNoKeyRotation
+	}
+
+	pkOld, err := crypto.ParseKeyBytes(pd.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if len(pd.KeyRotationProof) < nonceLength {
+		return ErrInvalidKeyRotation
+	}
+
+	nonce := pd.KeyRotationProof[:nonceLength]
+
+	pkNew, ok := box.Open(nil, pd.KeyRotationProof[nonceLength:], (*[nonceLength]byte)(nonce), (*[32]byte)(&pkOld), (*[32]byte)(&community))
+	if !ok || !bytes.Equal(pkNew, pd.PublicKeyNew) {
+		return ErrInvalidKeyRotation
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc_test
+
+import (
+	"cunicu.li/cunicu/pkg/crypto"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("key rotation", func() {
+	var (
+		community, skOld, skNew crypto.Key
+		d                       *pdiscproto.PeerDescription
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		community = crypto.GenerateKeyFromPassword("community")
+
+		skOld, err = crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		skNew, err = crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		d = &pdiscproto.PeerDescription{
+			Change:       pdiscproto.PeerDescriptionChange_UPDATE,
+			PublicKey:    skOld.PublicKey().Bytes(),
+			PublicKeyNew: skNew.PublicKey().Bytes(),
+		}
+	})
+
+	It("accepts a rotation proven by the old key", func() {
+		Expect(d.ProveKeyRotation(skOld, community)).To(Succeed())
+		Expect(d.VerifyKeyRotation(community)).To(Succeed())
+	})
+
+	It("rejects a rotation without proof", func() {
+		Expect(d.VerifyKeyRotation(community)).To(MatchError(pdiscproto.ErrInvalidKeyRotation))
+	})
+
+	It("rejects a rotation forged by a third party", func() {
+		// The attacker claims the key of the victim for its own key
+		Expect(d.ProveKeyRotation(skNew, community)).To(Succeed())
+		Expect(d.VerifyKeyRotation(community)).To(MatchError(pdiscproto.ErrInvalidKeyRotation))
+	})
+
+	It("rejects a proof which is replayed for a different key", func() {
+		Expect(d.ProveKeyRotation(skOld, community)).To(Succeed())
+
+		skOther, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		d.PublicKeyNew = skOther.PublicKey().Bytes()
+		Expect(d.VerifyKeyRotation(community)).To(MatchError(pdiscproto.ErrInvalidKeyRotation))
+	})
+
+	It("rejects a proof for another community", func() {
+		Expect(d.ProveKeyRotation(skOld, crypto.GenerateKeyFromPassword("other"))).To(Succeed())
+		Expect(d.VerifyKeyRotation(community)).To(MatchError(pdiscproto.ErrInvalidKeyRotation))
+	})
+
+	It("can not prove descriptions without a new key", func() {
+		d.PublicKeyNew = nil
+		Expect(d.ProveKeyRotation(skOld, community)).To(MatchError(pdiscproto.ErrNoKeyRotation))
+	})
+})
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc
+
+import (
+	"cunicu.li/cunicu/pkg/crypto"
+	coreproto "cunicu.li/cunicu/pkg/proto/core"
+)
+
+// MaxMetric is the metric of unreachable destinations.
+// Paths which accumulate this metric are not used.
+const MaxMetric = 16
+
+// Route is a path to a destination which is not directly reachable.
+type Route struct {
+	// NextHop is the public key of the peer which forwards the traffic.
+	NextHop crypto.Key
+
+	// Metric is the sum of the link metrics along the path.
+	Metric uint32
+}
+
+// LinkMetric returns the metric of a direct link with the given reachability.
+// A metric of zero indicates that the link can not be used.
+func LinkMetric(r coreproto.ReachabilityType) uint32 {
+	switch r {
+	case coreproto.ReachabilityType_DIRECT:
+		return 1
+	case coreproto.ReachabilityType_RELAYED:
+		return 2
+	case coreproto.ReachabilityType_RELAYED_BIDIR:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// ComputeRoutes calculates the shortest paths from self to all other peers.
+//
+// The graph is built from our own neighbors and the neighbors advertised
+// in the peer descriptions of other peers, indexed by their public key.
+// Only routes to destinations which are reached via another peer are returned.
+// Direct links are preferred over indirect paths with an equal metric.
+func ComputeRoutes(self crypto.Key, neighbors []*Neighbor, descs map[crypto.Key]*PeerDescription) map[crypto.Key]Route {
+	links := map[crypto.Key][]*Neighbor{
+		self: neighbors,
+	}
+
+	for pk, d := range descs {
+		if pk != self {
+			links[pk] = d.Neighbors
+		}
+	}
+
+	dist := map[crypto.Key]uint32{
+		self: 0,
+	}
+	nextHops := map[crypto.Key]crypto.Key{}
+	done := map[crypto.Key]bool{}
+
+	for {
+		// Select the closest node which has not been visited yet
+		var (
+			u     crypto.Key
+			found bool
+		)
+
+		for v, d := range dist {
+			if done[v] {
+				continue
+			}
+
+			if !found || d < dist[u] || (d == dist[u] && string(v[:]) < string(u[:])) {
+				u, found = v, true
+			}
+		}
+
+		if !found {
+			break
+		}
+
+		done[u] = true
+
+		for _, n := range links[u] {
+			v, err := crypto.ParseKeyBytes(n.PublicKey)
+			if err != nil || v == self || done[v] || n.Metric == 0 || n.Metric >= MaxMetric {
+				continue
+			}
+
+			nd := dist[u] + n.Metric
+			if nd >= MaxMetric {
+				continue
+			}
+
+			nh := v
+			if u != self {
+				nh = nextHops[u]
+			}
+
+			if d, ok := dist[v]; !ok || nd < d || (nd == d && nh == v) {
+				dist[v] = nd
+				nextHops[v] = nh
+			}
+		}
+	}
+
+	routes := map[crypto.Key]Route{}
+
+	for v, nh := range nextHops {
+		if nh != v {
+			routes[v] = Route{
+				NextHop: nh,
+				Metric:  dist[v],
+			}
+		}
+	}
+
+	return routes
+}
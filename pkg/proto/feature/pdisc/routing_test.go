@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pdisc_test
+
+import (
+	"cunicu.li/cunicu/pkg/crypto"
+	coreproto "cunicu.li/cunicu/pkg/proto/core"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("routing", func() {
+	var a, b, c, d crypto.Key
+
+	neighbor := func(pk crypto.Key, r coreproto.ReachabilityType) *pdiscproto.Neighbor {
+		return &pdiscproto.Neighbor{
+			PublicKey: pk.Bytes(),
+			Metric:    pdiscproto.LinkMetric(r),
+		}
+	}
+
+	desc := func(ns ...*pdiscproto.Neighbor) *pdiscproto.PeerDescription {
+		return &pdiscproto.PeerDescription{
+			Neighbors: ns,
+		}
+	}
+
+	BeforeEach(func() {
+		for _, pk := range []*crypto.Key{&a, &b, &c, &d} {
+			sk, err := crypto.GeneratePrivateKey()
+			Expect(err).To(Succeed())
+
+			*pk = sk.PublicKey()
+		}
+	})
+
+	It("does not return routes for direct neighbors", func() {
+		routes := pdiscproto.ComputeRoutes(a, []*pdiscproto.Neighbor{
+			neighbor(b, coreproto.ReachabilityType_DIRECT),
+		}, map[crypto.Key]*pdiscproto.PeerDescription{
+			b: desc(neighbor(a, coreproto.ReachabilityType_DIRECT)),
+		})
+
+		Expect(routes).To(BeEmpty())
+	})
+
+	It("routes via an intermediate peer", func() {
+		// a - b - c
+		routes := pdiscproto.ComputeRoutes(a, []*pdiscproto.Neighbor{
+			neighbor(b, coreproto.ReachabilityType_DIRECT),
+		}, map[crypto.Key]*pdiscproto.PeerDescription{
+			b: desc(
+				neighbor(a, coreproto.ReachabilityType_DIRECT),
+				neighbor(c, coreproto.ReachabilityType_DIRECT),
+			),
+			c: desc(neighbor(b, coreproto.ReachabilityType_DIRECT)),
+		})
+
+		Expect(routes).To(HaveLen(1))
+		Expect(routes).To(HaveKeyWithValue(c, pdiscproto.Route{
+			NextHop: b,
+			Metric:  2,
+		}))
+	})
+
+	It("routes over multiple hops", func() {
+		// a - b - c - d
+		routes := pdiscproto.ComputeRoutes(a, []*pdiscproto.Neighbor{
+			neighbor(b, coreproto.ReachabilityType_DIRECT),
+		}, map[crypto.Key]*pdiscproto.PeerDescription{
+			b: desc(neighbor(c, coreproto.ReachabilityType_DIRECT)),
+			c: desc(neighbor(d, coreproto.ReachabilityType_RELAYED)),
+		})
+
+		Expect(routes).To(HaveLen(2))
+		Expect(routes).To(HaveKeyWithValue(c, pdiscproto.Route{NextHop: b, Metric: 2}))
+		Expect(routes).To(HaveKeyWithValue(d, pdiscproto.Route{NextHop: b, Metric: 4}))
+	})
+
+	It("prefers direct links over paths with an equal metric", func() {
+		routes := pdiscproto.ComputeRoutes(a, []*pdiscproto.Neighbor{
+			neighbor(b, coreproto.ReachabilityType_DIRECT),
+			neighbor(c, coreproto.ReachabilityType_RELAYED),
+		}, map[crypto.Key]*pdiscproto.PeerDescription{
+			b: desc(neighbor(c, coreproto.ReachabilityType_DIRECT)),
+		})
+
+		Expect(routes).To(BeEmpty())
+	})
+
+	It("prefers cheaper indirect paths over expensive direct links", func() {
+		routes := pdiscproto.ComputeRoutes(a, []*pdiscproto.Neighbor{
+			neighbor(b, coreproto.ReachabilityType_DIRECT),
+			neighbor(c, coreproto.ReachabilityType_RELAYED_BIDIR),
+		}, map[crypto.Key]*pdiscproto.PeerDescription{
+			b: desc(neighbor(c, coreproto.ReachabilityType_DIRECT)),
+		})
+
+		Expect(routes).To(HaveKeyWithValue(c, pdiscproto.Route{NextHop: b, Metric: 2}))
+	})
+
+	It("ignores unusable links", func() {
+		routes := pdiscproto.ComputeRoutes(a, []*pdiscproto.Neighbor{
+			neighbor(b, coreproto.ReachabilityType_NONE),
+		}, map[crypto.Key]*pdiscproto.PeerDescription{
+			b: desc(neighbor(c, coreproto.ReachabilityType_DIRECT)),
+		})
+
+		Expect(routes).To(BeEmpty())
+	})
+
+	It("ignores paths exceeding the maximum metric", func() {
+		routes := pdiscproto.ComputeRoutes(a, []*pdiscproto.Neighbor{
+			neighbor(b, coreproto.ReachabilityType_DIRECT),
+		}, map[crypto.Key]*pdiscproto.PeerDescription{
+			b: desc(&pdiscproto.Neighbor{
+				PublicKey: c.Bytes(),
+				Metric:    pdiscproto.MaxMetric - 1,
+			}),
+		})
+
+		Expect(routes).To(BeEmpty())
+	})
+})
@@ -33,13 +33,15 @@ const (
 	EventType_BACKEND_READY     EventType = 0
 	EventType_SIGNALING_MESSAGE EventType = 1
 	// Core Events
-	EventType_PEER_ADDED         EventType = 10
-	EventType_PEER_REMOVED       EventType = 11
-	EventType_PEER_MODIFIED      EventType = 12
-	EventType_PEER_STATE_CHANGED EventType = 13
-	EventType_INTERFACE_ADDED    EventType = 20
-	EventType_INTERFACE_REMOVED  EventType = 21
-	EventType_INTERFACE_MODIFIED EventType = 22
+	EventType_PEER_ADDED               EventType = 10
+	EventType_PEER_REMOVED             EventType = 11
+	EventType_PEER_MODIFIED            EventType = 12
+	EventType_PEER_STATE_CHANGED       EventType = 13
+	EventType_PEER_EXPIRED             EventType = 14
+	EventType_PEER_ALLOWED_IP_REJECTED EventType = 15
+	EventType_INTERFACE_ADDED          EventType = 20
+	EventType_INTERFACE_REMOVED        EventType = 21
+	EventType_INTERFACE_MODIFIED       EventType = 22
 )
 
 // Enum value maps for EventType.
@@ -51,20 +53,24 @@ var (
 		11: "PEER_REMOVED",
 		12: "PEER_MODIFIED",
 		13: "PEER_STATE_CHANGED",
+		14: "PEER_EXPIRED",
+		15: "PEER_ALLOWED_IP_REJECTED",
 		20: "INTERFACE_ADDED",
 		21: "INTERFACE_REMOVED",
 		22: "INTERFACE_MODIFIED",
 	}
 	EventType_value = map[string]int32{
-		"BACKEND_READY":      0,
-		"SIGNALING_MESSAGE":  1,
-		"PEER_ADDED":         10,
-		"PEER_REMOVED":       11,
-		"PEER_MODIFIED":      12,
-		"PEER_STATE_CHANGED": 13,
-		"INTERFACE_ADDED":    20,
-		"INTERFACE_REMOVED":  21,
-		"INTERFACE_MODIFIED": 22,
+		"BACKEND_READY":            0,
+		"SIGNALING_MESSAGE":        1,
+		"PEER_ADDED":               10,
+		"PEER_REMOVED":             11,
+		"PEER_MODIFIED":            12,
+		"PEER_STATE_CHANGED":       13,
+		"PEER_EXPIRED":             14,
+		"PEER_ALLOWED_IP_REJECTED": 15,
+		"INTERFACE_ADDED":          20,
+		"INTERFACE_REMOVED":        21,
+		"INTERFACE_MODIFIED":       22,
 	}
 )
 
@@ -484,7 +490,7 @@ var file_rpc_event_proto_rawDesc = []byte{
 	0x6e, 0x74, 0x12, 0x31, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
 	0x32, 0x1d, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c,
 	0x69, 0x6e, 0x67, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x2a, 0xc6, 0x01, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x2a, 0xf6, 0x01, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54,
 	0x79, 0x70, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x41, 0x43, 0x4b, 0x45, 0x4e, 0x44, 0x5f, 0x52,
 	0x45, 0x41, 0x44, 0x59, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x4c,
 	0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x10, 0x01, 0x12, 0x0e, 0x0a,
@@ -492,7 +498,10 @@ var file_rpc_event_proto_rawDesc = []byte{
 	0x0c, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x0b, 0x12,
 	0x11, 0x0a, 0x0d, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x4d, 0x4f, 0x44, 0x49, 0x46, 0x49, 0x45, 0x44,
 	0x10, 0x0c, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
-	0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x0d, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x4e,
+	0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x0d, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x45,
+	0x45, 0x52, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x0e, 0x12, 0x1c, 0x0a, 0x18,
+	0x50, 0x45, 0x45, 0x52, 0x5f, 0x41, 0x4c, 0x4c, 0x4f, 0x57, 0x45, 0x44, 0x5f, 0x49, 0x50, 0x5f,
+	0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x0f, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x4e,
 	0x54, 0x45, 0x52, 0x46, 0x41, 0x43, 0x45, 0x5f, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x14, 0x12,
 	0x15, 0x0a, 0x11, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x46, 0x41, 0x43, 0x45, 0x5f, 0x52, 0x45, 0x4d,
 	0x4f, 0x56, 0x45, 0x44, 0x10, 0x15, 0x12, 0x16, 0x0a, 0x12, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x46,
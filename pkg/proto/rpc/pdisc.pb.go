@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.0
+// 	protoc        v5.29.1
+// source: rpc/pdisc.proto
+
+package rpc
+
+import (
+	proto "cunicu.li/cunicu/pkg/proto"
+	pdisc "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RevokePeerParams struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the interface or empty for all interfaces
+	Intf          string            `protobuf:"bytes,1,opt,name=intf,proto3" json:"intf,omitempty"`
+	Revocation    *pdisc.Revocation `protobuf:"bytes,2,opt,name=revocation,proto3" json:"revocation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokePeerParams) Reset() {
+	*x = RevokePeerParams{}
+	mi := &file_rpc_pdisc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokePeerParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokePeerParams) ProtoMessage() {}
+
+func (x *RevokePeerParams) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pdisc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokePeerParams.ProtoReflect.Descriptor instead.
+func (*RevokePeerParams) Descriptor() ([]byte, []int) {
+	return file_rpc_pdisc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RevokePeerParams) GetIntf() string {
+	if x != nil {
+		return x.Intf
+	}
+	return ""
+}
+
+func (x *RevokePeerParams) GetRevocation() *pdisc.Revocation {
+	if x != nil {
+		return x.Revocation
+	}
+	return nil
+}
+
+type UseExitNodeParams struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the interface or empty for all interfaces
+	Intf string `protobuf:"bytes,1,opt,name=intf,proto3" json:"intf,omitempty"`
+	// Hostname or public key of the exit node or empty to disable the exit node
+	Peer          string `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UseExitNodeParams) Reset() {
+	*x = UseExitNodeParams{}
+	mi := &file_rpc_pdisc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UseExitNodeParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UseExitNodeParams) ProtoMessage() {}
+
+func (x *UseExitNodeParams) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pdisc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UseExitNodeParams.ProtoReflect.Descriptor instead.
+func (*UseExitNodeParams) Descriptor() ([]byte, []int) {
+	return file_rpc_pdisc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UseExitNodeParams) GetIntf() string {
+	if x != nil {
+		return x.Intf
+	}
+	return ""
+}
+
+func (x *UseExitNodeParams) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+type PeerFilterParams struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the interface or empty for all interfaces
+	Intf string `protobuf:"bytes,1,opt,name=intf,proto3" json:"intf,omitempty"`
+	// Public key, prefix in CIDR notation or glob(7) pattern of the hostname
+	Selector string `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Remove the selector from the whitelist or blacklist instead of adding it
+	Remove bool `protobuf:"varint,3,opt,name=remove,proto3" json:"remove,omitempty"`
+	// Permit adding the first selector to an empty whitelist
+	// which rejects all peers not matched by the selector
+	EnableWhitelist bool `protobuf:"varint,4,opt,name=enable_whitelist,json=enableWhitelist,proto3" json:"enable_whitelist,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PeerFilterParams) Reset() {
+	*x = PeerFilterParams{}
+	mi := &file_rpc_pdisc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerFilterParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerFilterParams) ProtoMessage() {}
+
+func (x *PeerFilterParams) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pdisc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerFilterParams.ProtoReflect.Descriptor instead.
+func (*PeerFilterParams) Descriptor() ([]byte, []int) {
+	return file_rpc_pdisc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PeerFilterParams) GetIntf() string {
+	if x != nil {
+		return x.Intf
+	}
+	return ""
+}
+
+func (x *PeerFilterParams) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *PeerFilterParams) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+func (x *PeerFilterParams) GetEnableWhitelist() bool {
+	if x != nil {
+		return x.EnableWhitelist
+	}
+	return false
+}
+
+var File_rpc_pdisc_proto protoreflect.FileDescriptor
+
+var file_rpc_pdisc_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x64, 0x69, 0x73, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0a, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x72, 0x70, 0x63, 0x1a, 0x0c, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x66, 0x65, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x2f, 0x70, 0x64, 0x69, 0x73, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x60, 0x0a, 0x10, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x50, 0x65, 0x65, 0x72, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x12, 0x38, 0x0a, 0x0a, 0x72, 0x65, 0x76, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63,
+	0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x70, 0x64, 0x69, 0x73, 0x63, 0x2e, 0x52, 0x65, 0x76, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x3b, 0x0a, 0x11, 0x55, 0x73, 0x65, 0x45, 0x78, 0x69, 0x74, 0x4e, 0x6f, 0x64,
+	0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x65, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x22,
+	0x85, 0x01, 0x0a, 0x10, 0x50, 0x65, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x29, 0x0a, 0x10,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x77, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x57, 0x68,
+	0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x32, 0x88, 0x02, 0x0a, 0x13, 0x50, 0x65, 0x65, 0x72,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x3b, 0x0a, 0x0a, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x50, 0x65, 0x65, 0x72, 0x12, 0x1c, 0x2e,
+	0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b,
+	0x65, 0x50, 0x65, 0x65, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x0d, 0x2e, 0x63, 0x75,
+	0x6e, 0x69, 0x63, 0x75, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0b,
+	0x55, 0x73, 0x65, 0x45, 0x78, 0x69, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x2e, 0x63, 0x75,
+	0x6e, 0x69, 0x63, 0x75, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x55, 0x73, 0x65, 0x45, 0x78, 0x69, 0x74,
+	0x4e, 0x6f, 0x64, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x0d, 0x2e, 0x63, 0x75, 0x6e,
+	0x69, 0x63, 0x75, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x09, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x65, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63,
+	0x75, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x0d, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x08, 0x44, 0x65, 0x6e, 0x79, 0x50,
+	0x65, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x1a, 0x0d, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x00, 0x42, 0x20, 0x5a, 0x1e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x6c, 0x69, 0x2f,
+	0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_pdisc_proto_rawDescOnce sync.Once
+	file_rpc_pdisc_proto_rawDescData = file_rpc_pdisc_proto_rawDesc
+)
+
+func file_rpc_pdisc_proto_rawDescGZIP() []byte {
+	file_rpc_pdisc_proto_rawDescOnce.Do(func() {
+		file_rpc_pdisc_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_pdisc_proto_rawDescData)
+	})
+	return file_rpc_pdisc_proto_rawDescData
+}
+
+var file_rpc_pdisc_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_rpc_pdisc_proto_goTypes = []any{
+	(*RevokePeerParams)(nil),  // 0: cunicu.rpc.RevokePeerParams
+	(*UseExitNodeParams)(nil), // 1: cunicu.rpc.UseExitNodeParams
+	(*PeerFilterParams)(nil),  // 2: cunicu.rpc.PeerFilterParams
+	(*pdisc.Revocation)(nil),  // 3: cunicu.pdisc.Revocation
+	(*proto.Empty)(nil),       // 4: cunicu.Empty
+}
+var file_rpc_pdisc_proto_depIdxs = []int32{
+	3, // 0: cunicu.rpc.RevokePeerParams.revocation:type_name -> cunicu.pdisc.Revocation
+	0, // 1: cunicu.rpc.PeerDiscoverySocket.RevokePeer:input_type -> cunicu.rpc.RevokePeerParams
+	1, // 2: cunicu.rpc.PeerDiscoverySocket.UseExitNode:input_type -> cunicu.rpc.UseExitNodeParams
+	2, // 3: cunicu.rpc.PeerDiscoverySocket.AllowPeer:input_type -> cunicu.rpc.PeerFilterParams
+	2, // 4: cunicu.rpc.PeerDiscoverySocket.DenyPeer:input_type -> cunicu.rpc.PeerFilterParams
+	4, // 5: cunicu.rpc.PeerDiscoverySocket.RevokePeer:output_type -> cunicu.Empty
+	4, // 6: cunicu.rpc.PeerDiscoverySocket.UseExitNode:output_type -> cunicu.Empty
+	4, // 7: cunicu.rpc.PeerDiscoverySocket.AllowPeer:output_type -> cunicu.Empty
+	4, // 8: cunicu.rpc.PeerDiscoverySocket.DenyPeer:output_type -> cunicu.Empty
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_pdisc_proto_init() }
+func file_rpc_pdisc_proto_init() {
+	if File_rpc_pdisc_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_pdisc_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rpc_pdisc_proto_goTypes,
+		DependencyIndexes: file_rpc_pdisc_proto_depIdxs,
+		MessageInfos:      file_rpc_pdisc_proto_msgTypes,
+	}.Build()
+	File_rpc_pdisc_proto = out.File
+	file_rpc_pdisc_proto_rawDesc = nil
+	file_rpc_pdisc_proto_goTypes = nil
+	file_rpc_pdisc_proto_depIdxs = nil
+}
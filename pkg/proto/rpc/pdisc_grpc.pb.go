@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.1
+// source: rpc/pdisc.proto
+
+package rpc
+
+import (
+	context "context"
+	proto "cunicu.li/cunicu/pkg/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PeerDiscoverySocket_RevokePeer_FullMethodName  = "/cunicu.rpc.PeerDiscoverySocket/RevokePeer"
+	PeerDiscoverySocket_UseExitNode_FullMethodName = "/cunicu.rpc.PeerDiscoverySocket/UseExitNode"
+	PeerDiscoverySocket_AllowPeer_FullMethodName   = "/cunicu.rpc.PeerDiscoverySocket/AllowPeer"
+	PeerDiscoverySocket_DenyPeer_FullMethodName    = "/cunicu.rpc.PeerDiscoverySocket/DenyPeer"
+)
+
+// PeerDiscoverySocketClient is the client API for PeerDiscoverySocket service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PeerDiscoverySocketClient interface {
+	RevokePeer(ctx context.Context, in *RevokePeerParams, opts ...grpc.CallOption) (*proto.Empty, error)
+	UseExitNode(ctx context.Context, in *UseExitNodeParams, opts ...grpc.CallOption) (*proto.Empty, error)
+	AllowPeer(ctx context.Context, in *PeerFilterParams, opts ...grpc.CallOption) (*proto.Empty, error)
+	DenyPeer(ctx context.Context, in *PeerFilterParams, opts ...grpc.CallOption) (*proto.Empty, error)
+}
+
+type peerDiscoverySocketClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPeerDiscoverySocketClient(cc grpc.ClientConnInterface) PeerDiscoverySocketClient {
+	return &peerDiscoverySocketClient{cc}
+}
+
+func (c *peerDiscoverySocketClient) RevokePeer(ctx context.Context, in *RevokePeerParams, opts ...grpc.CallOption) (*proto.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(proto.Empty)
+	err := c.cc.Invoke(ctx, PeerDiscoverySocket_RevokePeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerDiscoverySocketClient) UseExitNode(ctx context.Context, in *UseExitNodeParams, opts ...grpc.CallOption) (*proto.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(proto.Empty)
+	err := c.cc.Invoke(ctx, PeerDiscoverySocket_UseExitNode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerDiscoverySocketClient) AllowPeer(ctx context.Context, in *PeerFilterParams, opts ...grpc.CallOption) (*proto.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(proto.Empty)
+	err := c.cc.Invoke(ctx, PeerDiscoverySocket_AllowPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerDiscoverySocketClient) DenyPeer(ctx context.Context, in *PeerFilterParams, opts ...grpc.CallOption) (*proto.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(proto.Empty)
+	err := c.cc.Invoke(ctx, PeerDiscoverySocket_DenyPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PeerDiscoverySocketServer is the server API for PeerDiscoverySocket service.
+// All implementations must embed UnimplementedPeerDiscoverySocketServer
+// for forward compatibility.
+type PeerDiscoverySocketServer interface {
+	RevokePeer(context.Context, *RevokePeerParams) (*proto.Empty, error)
+	UseExitNode(context.Context, *UseExitNodeParams) (*proto.Empty, error)
+	AllowPeer(context.Context, *PeerFilterParams) (*proto.Empty, error)
+	DenyPeer(context.Context, *PeerFilterParams) (*proto.Empty, error)
+	mustEmbedUnimplementedPeerDiscoverySocketServer()
+}
+
+// UnimplementedPeerDiscoverySocketServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPeerDiscoverySocketServer struct{}
+
+func (UnimplementedPeerDiscoverySocketServer) RevokePeer(context.Context, *RevokePeerParams) (*proto.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokePeer not implemented")
+}
+func (UnimplementedPeerDiscoverySocketServer) UseExitNode(context.Context, *UseExitNodeParams) (*proto.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseExitNode not implemented")
+}
+func (UnimplementedPeerDiscoverySocketServer) AllowPeer(context.Context, *PeerFilterParams) (*proto.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllowPeer not implemented")
+}
+func (UnimplementedPeerDiscoverySocketServer) DenyPeer(context.Context, *PeerFilterParams) (*proto.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyPeer not implemented")
+}
+func (UnimplementedPeerDiscoverySocketServer) mustEmbedUnimplementedPeerDiscoverySocketServer() {}
+func (UnimplementedPeerDiscoverySocketServer) testEmbeddedByValue()                             {}
+
+// UnsafePeerDiscoverySocketServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PeerDiscoverySocketServer will
+// result in compilation errors.
+type UnsafePeerDiscoverySocketServer interface {
+	mustEmbedUnimplementedPeerDiscoverySocketServer()
+}
+
+func RegisterPeerDiscoverySocketServer(s grpc.ServiceRegistrar, srv PeerDiscoverySocketServer) {
+	// If the following call pancis, it indicates UnimplementedPeerDiscoverySocketServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PeerDiscoverySocket_ServiceDesc, srv)
+}
+
+func _PeerDiscoverySocket_RevokePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokePeerParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerDiscoverySocketServer).RevokePeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerDiscoverySocket_RevokePeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerDiscoverySocketServer).RevokePeer(ctx, req.(*RevokePeerParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerDiscoverySocket_UseExitNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UseExitNodeParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerDiscoverySocketServer).UseExitNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerDiscoverySocket_UseExitNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerDiscoverySocketServer).UseExitNode(ctx, req.(*UseExitNodeParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerDiscoverySocket_AllowPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerFilterParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerDiscoverySocketServer).AllowPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerDiscoverySocket_AllowPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerDiscoverySocketServer).AllowPeer(ctx, req.(*PeerFilterParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerDiscoverySocket_DenyPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerFilterParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerDiscoverySocketServer).DenyPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerDiscoverySocket_DenyPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerDiscoverySocketServer).DenyPeer(ctx, req.(*PeerFilterParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PeerDiscoverySocket_ServiceDesc is the grpc.ServiceDesc for PeerDiscoverySocket service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PeerDiscoverySocket_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cunicu.rpc.PeerDiscoverySocket",
+	HandlerType: (*PeerDiscoverySocketServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RevokePeer",
+			Handler:    _PeerDiscoverySocket_RevokePeer_Handler,
+		},
+		{
+			MethodName: "UseExitNode",
+			Handler:    _PeerDiscoverySocket_UseExitNode_Handler,
+		},
+		{
+			MethodName: "AllowPeer",
+			Handler:    _PeerDiscoverySocket_AllowPeer_Handler,
+		},
+		{
+			MethodName: "DenyPeer",
+			Handler:    _PeerDiscoverySocket_DenyPeer_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc/pdisc.proto",
+}
@@ -23,5 +23,17 @@ func (s *GetStatusResp) Dump(wr io.Writer, level log.Level) error {
 		}
 	}
 
+	for k, b := range s.Backends {
+		if k > 0 || len(s.Interfaces) > 0 {
+			if _, err := fmt.Fprintln(wr); err != nil {
+				return err
+			}
+		}
+
+		if err := b.Dump(wr, level); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package signaling
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"cunicu.li/cunicu/pkg/log"
+	"cunicu.li/cunicu/pkg/tty"
+)
+
+func (s BackendState) Color() string {
+	switch s {
+	case BackendState_CONNECTING:
+		return tty.FgYellow
+	case BackendState_READY:
+		return tty.FgGreen
+	case BackendState_FAILED:
+		return tty.FgRed
+	}
+
+	return tty.FgDefault
+}
+
+func (s *BackendState) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(s.String())), nil
+}
+
+// Dump writes a human readable version of the backend status to the supplied writer.
+func (s *BackendStatus) Dump(wr io.Writer, _ log.Level) error {
+	wri := tty.NewIndenter(wr, "  ")
+
+	if _, err := fmt.Fprintf(wr, tty.Mods("backend", tty.Bold, tty.FgCyan)+": "+tty.Mods("%s", tty.FgCyan)+"\n", s.Uri); err != nil {
+		return err
+	}
+
+	state := tty.Mods(strings.ToLower(s.State.String()), tty.Bold, s.State.Color())
+	if s.Active {
+		state += " (active)"
+	}
+
+	if _, err := tty.FprintKV(wri, "state", state); err != nil {
+		return err
+	}
+
+	if _, err := tty.FprintKV(wri, "priority", s.Priority); err != nil {
+		return err
+	}
+
+	if _, err := tty.FprintKV(wri, "messages", fmt.Sprintf("%d published, %d received", s.Published, s.Received)); err != nil {
+		return err
+	}
+
+	if s.Failures > 0 {
+		if _, err := tty.FprintKV(wri, "failures", s.Failures); err != nil {
+			return err
+		}
+	}
+
+	if s.LastError != "" {
+		if _, err := tty.FprintKV(wri, "latest error", fmt.Sprintf("%s (%s)", s.LastError, tty.Ago(s.LastErrorTimestamp.Time()))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -6,6 +6,8 @@ package signaling
 import (
 	"errors"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/crypto/nacl/box"
 	"google.golang.org/protobuf/proto"
@@ -19,6 +21,26 @@ var (
 	errFailedToDecrypt    = errors.New("failed to open")
 )
 
+var lastSequence atomic.Uint64
+
+// NextSequence returns a new sequence number for an outgoing message.
+// Sequence numbers are derived from the current time in nanoseconds
+// since the Unix epoch and are strictly increasing within the process.
+func NextSequence() uint64 {
+	for {
+		last := lastSequence.Load()
+
+		next := uint64(time.Now().UnixNano()) //nolint:gosec
+		if next <= last {
+			next = last + 1
+		}
+
+		if lastSequence.CompareAndSwap(last, next) {
+			return next
+		}
+	}
+}
+
 func (e *Envelope) PublicKeyPair() (crypto.PublicKeyPair, error) {
 	sender, err := crypto.ParseKeyBytes(e.Sender)
 	if err != nil {
@@ -54,7 +76,13 @@ func (e *Envelope) Decrypt(kp *crypto.KeyPair) (*Message, error) {
 	return msg, nil
 }
 
+// Encrypt seals the message into an envelope for kp.Theirs.
+// A sequence number is assigned to the message if it has none yet.
 func (e *Message) Encrypt(kp *crypto.KeyPair) (*Envelope, error) {
+	if e.Sequence == 0 {
+		e.Sequence = NextSequence()
+	}
+
 	envp := &Envelope{
 		Sender:    kp.Ours.PublicKey().Bytes(),
 		Recipient: kp.Theirs.Bytes(),
@@ -34,6 +34,10 @@ const (
 	BackendType_GRPC      BackendType = 1
 	BackendType_INPROCESS BackendType = 2
 	BackendType_MCAST     BackendType = 3
+	BackendType_MQTT      BackendType = 4
+	BackendType_NATS      BackendType = 5
+	BackendType_WEBSOCKET BackendType = 6
+	BackendType_FILE      BackendType = 7
 )
 
 // Enum value maps for BackendType.
@@ -43,12 +47,20 @@ var (
 		1: "GRPC",
 		2: "INPROCESS",
 		3: "MCAST",
+		4: "MQTT",
+		5: "NATS",
+		6: "WEBSOCKET",
+		7: "FILE",
 	}
 	BackendType_value = map[string]int32{
 		"MULTI":     0,
 		"GRPC":      1,
 		"INPROCESS": 2,
 		"MCAST":     3,
+		"MQTT":      4,
+		"NATS":      5,
+		"WEBSOCKET": 6,
+		"FILE":      7,
 	}
 )
 
@@ -79,6 +91,175 @@ func (BackendType) EnumDescriptor() ([]byte, []int) {
 	return file_signaling_signaling_proto_rawDescGZIP(), []int{0}
 }
 
+type BackendState int32
+
+const (
+	BackendState_CONNECTING BackendState = 0
+	BackendState_READY      BackendState = 1
+	BackendState_FAILED     BackendState = 2
+)
+
+// Enum value maps for BackendState.
+var (
+	BackendState_name = map[int32]string{
+		0: "CONNECTING",
+		1: "READY",
+		2: "FAILED",
+	}
+	BackendState_value = map[string]int32{
+		"CONNECTING": 0,
+		"READY":      1,
+		"FAILED":     2,
+	}
+)
+
+func (x BackendState) Enum() *BackendState {
+	p := new(BackendState)
+	*p = x
+	return p
+}
+
+func (x BackendState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BackendState) Descriptor() protoreflect.EnumDescriptor {
+	return file_signaling_signaling_proto_enumTypes[1].Descriptor()
+}
+
+func (BackendState) Type() protoreflect.EnumType {
+	return &file_signaling_signaling_proto_enumTypes[1]
+}
+
+func (x BackendState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BackendState.Descriptor instead.
+func (BackendState) EnumDescriptor() ([]byte, []int) {
+	return file_signaling_signaling_proto_rawDescGZIP(), []int{1}
+}
+
+// Status of a signaling backend as tracked by the multi backend
+type BackendStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  BackendType            `protobuf:"varint,1,opt,name=type,proto3,enum=cunicu.signaling.BackendType" json:"type,omitempty"`
+	// URI of the backend with any passwords redacted
+	Uri string `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	// Backends with a lower value are preferred for publishing
+	Priority int32        `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	State    BackendState `protobuf:"varint,4,opt,name=state,proto3,enum=cunicu.signaling.BackendState" json:"state,omitempty"`
+	// Messages are currently published via this backend
+	Active             bool             `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	Published          uint64           `protobuf:"varint,6,opt,name=published,proto3" json:"published,omitempty"`
+	Received           uint64           `protobuf:"varint,7,opt,name=received,proto3" json:"received,omitempty"`
+	Failures           uint64           `protobuf:"varint,8,opt,name=failures,proto3" json:"failures,omitempty"`
+	LastError          string           `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastErrorTimestamp *proto.Timestamp `protobuf:"bytes,10,opt,name=last_error_timestamp,json=lastErrorTimestamp,proto3" json:"last_error_timestamp,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BackendStatus) Reset() {
+	*x = BackendStatus{}
+	mi := &file_signaling_signaling_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackendStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackendStatus) ProtoMessage() {}
+
+func (x *BackendStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_signaling_signaling_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackendStatus.ProtoReflect.Descriptor instead.
+func (*BackendStatus) Descriptor() ([]byte, []int) {
+	return file_signaling_signaling_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BackendStatus) GetType() BackendType {
+	if x != nil {
+		return x.Type
+	}
+	return BackendType_MULTI
+}
+
+func (x *BackendStatus) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *BackendStatus) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *BackendStatus) GetState() BackendState {
+	if x != nil {
+		return x.State
+	}
+	return BackendState_CONNECTING
+}
+
+func (x *BackendStatus) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *BackendStatus) GetPublished() uint64 {
+	if x != nil {
+		return x.Published
+	}
+	return 0
+}
+
+func (x *BackendStatus) GetReceived() uint64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *BackendStatus) GetFailures() uint64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *BackendStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *BackendStatus) GetLastErrorTimestamp() *proto.Timestamp {
+	if x != nil {
+		return x.LastErrorTimestamp
+	}
+	return nil
+}
+
 type Envelope struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sender        []byte                 `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
@@ -90,7 +271,7 @@ type Envelope struct {
 
 func (x *Envelope) Reset() {
 	*x = Envelope{}
-	mi := &file_signaling_signaling_proto_msgTypes[0]
+	mi := &file_signaling_signaling_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -102,7 +283,7 @@ func (x *Envelope) String() string {
 func (*Envelope) ProtoMessage() {}
 
 func (x *Envelope) ProtoReflect() protoreflect.Message {
-	mi := &file_signaling_signaling_proto_msgTypes[0]
+	mi := &file_signaling_signaling_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -115,7 +296,7 @@ func (x *Envelope) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
 func (*Envelope) Descriptor() ([]byte, []int) {
-	return file_signaling_signaling_proto_rawDescGZIP(), []int{0}
+	return file_signaling_signaling_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Envelope) GetSender() []byte {
@@ -140,18 +321,22 @@ func (x *Envelope) GetContents() *EncryptedMessage {
 }
 
 type Message struct {
-	state         protoimpl.MessageState          `protogen:"open.v1"`
-	Credentials   *epdisc.Credentials             `protobuf:"bytes,1,opt,name=credentials,proto3" json:"credentials,omitempty"`
-	Candidate     *epdisc.Candidate               `protobuf:"bytes,2,opt,name=candidate,proto3" json:"candidate,omitempty"`
-	Peer          *pdisc.PeerDescription          `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
-	Pske          *pske.PresharedKeyEstablishment `protobuf:"bytes,4,opt,name=pske,proto3" json:"pske,omitempty"`
+	state       protoimpl.MessageState          `protogen:"open.v1"`
+	Credentials *epdisc.Credentials             `protobuf:"bytes,1,opt,name=credentials,proto3" json:"credentials,omitempty"`
+	Candidate   *epdisc.Candidate               `protobuf:"bytes,2,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	Peer        *pdisc.PeerDescription          `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+	Pske        *pske.PresharedKeyEstablishment `protobuf:"bytes,4,opt,name=pske,proto3" json:"pske,omitempty"`
+	// A per-sender monotonically increasing sequence number.
+	// It is derived from the senders clock (nanoseconds since the Unix epoch)
+	// and used by receivers to reject stale or replayed messages.
+	Sequence      uint64 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Message) Reset() {
 	*x = Message{}
-	mi := &file_signaling_signaling_proto_msgTypes[1]
+	mi := &file_signaling_signaling_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -163,7 +348,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_signaling_signaling_proto_msgTypes[1]
+	mi := &file_signaling_signaling_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -176,7 +361,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_signaling_signaling_proto_rawDescGZIP(), []int{1}
+	return file_signaling_signaling_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Message) GetCredentials() *epdisc.Credentials {
@@ -207,6 +392,13 @@ func (x *Message) GetPske() *pske.PresharedKeyEstablishment {
 	return nil
 }
 
+func (x *Message) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
 // A container for an encrypted protobuf message
 type EncryptedMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -218,7 +410,7 @@ type EncryptedMessage struct {
 
 func (x *EncryptedMessage) Reset() {
 	*x = EncryptedMessage{}
-	mi := &file_signaling_signaling_proto_msgTypes[2]
+	mi := &file_signaling_signaling_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -230,7 +422,7 @@ func (x *EncryptedMessage) String() string {
 func (*EncryptedMessage) ProtoMessage() {}
 
 func (x *EncryptedMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_signaling_signaling_proto_msgTypes[2]
+	mi := &file_signaling_signaling_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -243,7 +435,7 @@ func (x *EncryptedMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EncryptedMessage.ProtoReflect.Descriptor instead.
 func (*EncryptedMessage) Descriptor() ([]byte, []int) {
-	return file_signaling_signaling_proto_rawDescGZIP(), []int{2}
+	return file_signaling_signaling_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *EncryptedMessage) GetBody() []byte {
@@ -269,7 +461,7 @@ type SubscribeParams struct {
 
 func (x *SubscribeParams) Reset() {
 	*x = SubscribeParams{}
-	mi := &file_signaling_signaling_proto_msgTypes[3]
+	mi := &file_signaling_signaling_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -281,7 +473,7 @@ func (x *SubscribeParams) String() string {
 func (*SubscribeParams) ProtoMessage() {}
 
 func (x *SubscribeParams) ProtoReflect() protoreflect.Message {
-	mi := &file_signaling_signaling_proto_msgTypes[3]
+	mi := &file_signaling_signaling_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -294,7 +486,7 @@ func (x *SubscribeParams) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeParams.ProtoReflect.Descriptor instead.
 func (*SubscribeParams) Descriptor() ([]byte, []int) {
-	return file_signaling_signaling_proto_rawDescGZIP(), []int{3}
+	return file_signaling_signaling_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SubscribeParams) GetKey() []byte {
@@ -316,56 +508,88 @@ var file_signaling_signaling_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x2f, 0x65, 0x70,
 	0x64, 0x69, 0x73, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x66, 0x65, 0x61, 0x74,
 	0x75, 0x72, 0x65, 0x2f, 0x65, 0x70, 0x64, 0x69, 0x73, 0x63, 0x5f, 0x63, 0x61, 0x6e, 0x64, 0x69,
-	0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x80, 0x01, 0x0a, 0x08, 0x45,
-	0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12,
-	0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x3e, 0x0a,
-	0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x22, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69,
-	0x6e, 0x67, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xee, 0x01,
-	0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x63, 0x72, 0x65,
-	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x65, 0x70, 0x64, 0x69, 0x73, 0x63, 0x2e, 0x43,
-	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x36, 0x0a, 0x09, 0x63, 0x61, 0x6e, 0x64, 0x69,
-	0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x75, 0x6e,
-	0x69, 0x63, 0x75, 0x2e, 0x65, 0x70, 0x64, 0x69, 0x73, 0x63, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x09, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12,
-	0x31, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
-	0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x70, 0x64, 0x69, 0x73, 0x63, 0x2e, 0x50, 0x65, 0x65,
-	0x72, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x70, 0x65,
-	0x65, 0x72, 0x12, 0x3a, 0x0a, 0x04, 0x70, 0x73, 0x6b, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x26, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x70, 0x73, 0x6b, 0x65, 0x2e, 0x50,
-	0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x45, 0x73, 0x74, 0x61, 0x62,
-	0x6c, 0x69, 0x73, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x04, 0x70, 0x73, 0x6b, 0x65, 0x22, 0x3c,
-	0x0a, 0x10, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x23, 0x0a, 0x0f,
-	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x2a, 0x3c, 0x0a, 0x0b, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x09, 0x0a, 0x05, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x47,
-	0x52, 0x50, 0x43, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x4e, 0x50, 0x52, 0x4f, 0x43, 0x45,
-	0x53, 0x53, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x43, 0x41, 0x53, 0x54, 0x10, 0x03, 0x32,
-	0xc7, 0x01, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x32, 0x0a,
-	0x0c, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0d, 0x2e,
-	0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x63,
-	0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x22,
-	0x00, 0x12, 0x4e, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x21,
-	0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e,
-	0x67, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x1a, 0x1a, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x61,
-	0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x22, 0x00, 0x30,
-	0x01, 0x12, 0x36, 0x0a, 0x07, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x12, 0x1a, 0x2e, 0x63,
+	0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf8, 0x02, 0x0a, 0x0d, 0x42,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x31, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x63, 0x75, 0x6e,
+	0x69, 0x63, 0x75, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x42, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x69, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x34, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x63,
+	0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x2e,
+	0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x43, 0x0a, 0x14, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x80, 0x01, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f,
+	0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x75, 0x6e,
+	0x69, 0x63, 0x75, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x8a, 0x02, 0x0a, 0x07, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x75, 0x6e, 0x69,
+	0x63, 0x75, 0x2e, 0x65, 0x70, 0x64, 0x69, 0x73, 0x63, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x73, 0x12, 0x36, 0x0a, 0x09, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x65,
+	0x70, 0x64, 0x69, 0x73, 0x63, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x09, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x70, 0x65,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63,
+	0x75, 0x2e, 0x70, 0x64, 0x69, 0x73, 0x63, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x44, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x3a, 0x0a,
+	0x04, 0x70, 0x73, 0x6b, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x75,
+	0x6e, 0x69, 0x63, 0x75, 0x2e, 0x70, 0x73, 0x6b, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x45, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x04, 0x70, 0x73, 0x6b, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x3c, 0x0a, 0x10, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f,
+	0x6e, 0x63, 0x65, 0x22, 0x23, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x2a, 0x69, 0x0a, 0x0b, 0x42, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x55, 0x4c, 0x54, 0x49,
+	0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x52, 0x50, 0x43, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09,
+	0x49, 0x4e, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x4d,
+	0x43, 0x41, 0x53, 0x54, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x4d, 0x51, 0x54, 0x54, 0x10, 0x04,
+	0x12, 0x08, 0x0a, 0x04, 0x4e, 0x41, 0x54, 0x53, 0x10, 0x05, 0x12, 0x0d, 0x0a, 0x09, 0x57, 0x45,
+	0x42, 0x53, 0x4f, 0x43, 0x4b, 0x45, 0x54, 0x10, 0x06, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x49, 0x4c,
+	0x45, 0x10, 0x07, 0x2a, 0x35, 0x0a, 0x0c, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4e,
+	0x47, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x01, 0x12, 0x0a,
+	0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x32, 0xc7, 0x01, 0x0a, 0x09, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x32, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0d, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63,
+	0x75, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75,
+	0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x09,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x21, 0x2e, 0x63, 0x75, 0x6e, 0x69,
+	0x63, 0x75, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1a, 0x2e, 0x63,
 	0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x2e,
-	0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x1a, 0x0d, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63,
-	0x75, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42, 0x26, 0x5a, 0x24, 0x63, 0x75, 0x6e,
-	0x69, 0x63, 0x75, 0x2e, 0x6c, 0x69, 0x2f, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e,
-	0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x36, 0x0a, 0x07,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x12, 0x1a, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75,
+	0x2e, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x6e, 0x76, 0x65, 0x6c,
+	0x6f, 0x70, 0x65, 0x1a, 0x0d, 0x2e, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x00, 0x42, 0x26, 0x5a, 0x24, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2e, 0x6c,
+	0x69, 0x2f, 0x63, 0x75, 0x6e, 0x69, 0x63, 0x75, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -380,38 +604,44 @@ func file_signaling_signaling_proto_rawDescGZIP() []byte {
 	return file_signaling_signaling_proto_rawDescData
 }
 
-var file_signaling_signaling_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_signaling_signaling_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_signaling_signaling_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_signaling_signaling_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_signaling_signaling_proto_goTypes = []any{
 	(BackendType)(0),                       // 0: cunicu.signaling.BackendType
-	(*Envelope)(nil),                       // 1: cunicu.signaling.Envelope
-	(*Message)(nil),                        // 2: cunicu.signaling.Message
-	(*EncryptedMessage)(nil),               // 3: cunicu.signaling.EncryptedMessage
-	(*SubscribeParams)(nil),                // 4: cunicu.signaling.SubscribeParams
-	(*epdisc.Credentials)(nil),             // 5: cunicu.epdisc.Credentials
-	(*epdisc.Candidate)(nil),               // 6: cunicu.epdisc.Candidate
-	(*pdisc.PeerDescription)(nil),          // 7: cunicu.pdisc.PeerDescription
-	(*pske.PresharedKeyEstablishment)(nil), // 8: cunicu.pske.PresharedKeyEstablishment
-	(*proto.Empty)(nil),                    // 9: cunicu.Empty
-	(*proto.BuildInfo)(nil),                // 10: cunicu.BuildInfo
+	(BackendState)(0),                      // 1: cunicu.signaling.BackendState
+	(*BackendStatus)(nil),                  // 2: cunicu.signaling.BackendStatus
+	(*Envelope)(nil),                       // 3: cunicu.signaling.Envelope
+	(*Message)(nil),                        // 4: cunicu.signaling.Message
+	(*EncryptedMessage)(nil),               // 5: cunicu.signaling.EncryptedMessage
+	(*SubscribeParams)(nil),                // 6: cunicu.signaling.SubscribeParams
+	(*proto.Timestamp)(nil),                // 7: cunicu.Timestamp
+	(*epdisc.Credentials)(nil),             // 8: cunicu.epdisc.Credentials
+	(*epdisc.Candidate)(nil),               // 9: cunicu.epdisc.Candidate
+	(*pdisc.PeerDescription)(nil),          // 10: cunicu.pdisc.PeerDescription
+	(*pske.PresharedKeyEstablishment)(nil), // 11: cunicu.pske.PresharedKeyEstablishment
+	(*proto.Empty)(nil),                    // 12: cunicu.Empty
+	(*proto.BuildInfo)(nil),                // 13: cunicu.BuildInfo
 }
 var file_signaling_signaling_proto_depIdxs = []int32{
-	3,  // 0: cunicu.signaling.Envelope.contents:type_name -> cunicu.signaling.EncryptedMessage
-	5,  // 1: cunicu.signaling.Message.credentials:type_name -> cunicu.epdisc.Credentials
-	6,  // 2: cunicu.signaling.Message.candidate:type_name -> cunicu.epdisc.Candidate
-	7,  // 3: cunicu.signaling.Message.peer:type_name -> cunicu.pdisc.PeerDescription
-	8,  // 4: cunicu.signaling.Message.pske:type_name -> cunicu.pske.PresharedKeyEstablishment
-	9,  // 5: cunicu.signaling.Signaling.GetBuildInfo:input_type -> cunicu.Empty
-	4,  // 6: cunicu.signaling.Signaling.Subscribe:input_type -> cunicu.signaling.SubscribeParams
-	1,  // 7: cunicu.signaling.Signaling.Publish:input_type -> cunicu.signaling.Envelope
-	10, // 8: cunicu.signaling.Signaling.GetBuildInfo:output_type -> cunicu.BuildInfo
-	1,  // 9: cunicu.signaling.Signaling.Subscribe:output_type -> cunicu.signaling.Envelope
-	9,  // 10: cunicu.signaling.Signaling.Publish:output_type -> cunicu.Empty
-	8,  // [8:11] is the sub-list for method output_type
-	5,  // [5:8] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	0,  // 0: cunicu.signaling.BackendStatus.type:type_name -> cunicu.signaling.BackendType
+	1,  // 1: cunicu.signaling.BackendStatus.state:type_name -> cunicu.signaling.BackendState
+	7,  // 2: cunicu.signaling.BackendStatus.last_error_timestamp:type_name -> cunicu.Timestamp
+	5,  // 3: cunicu.signaling.Envelope.contents:type_name -> cunicu.signaling.EncryptedMessage
+	8,  // 4: cunicu.signaling.Message.credentials:type_name -> cunicu.epdisc.Credentials
+	9,  // 5: cunicu.signaling.Message.candidate:type_name -> cunicu.epdisc.Candidate
+	10, // 6: cunicu.signaling.Message.peer:type_name -> cunicu.pdisc.PeerDescription
+	11, // 7: cunicu.signaling.Message.pske:type_name -> cunicu.pske.PresharedKeyEstablishment
+	12, // 8: cunicu.signaling.Signaling.GetBuildInfo:input_type -> cunicu.Empty
+	6,  // 9: cunicu.signaling.Signaling.Subscribe:input_type -> cunicu.signaling.SubscribeParams
+	3,  // 10: cunicu.signaling.Signaling.Publish:input_type -> cunicu.signaling.Envelope
+	13, // 11: cunicu.signaling.Signaling.GetBuildInfo:output_type -> cunicu.BuildInfo
+	3,  // 12: cunicu.signaling.Signaling.Subscribe:output_type -> cunicu.signaling.Envelope
+	12, // 13: cunicu.signaling.Signaling.Publish:output_type -> cunicu.Empty
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_signaling_signaling_proto_init() }
@@ -424,8 +654,8 @@ func file_signaling_signaling_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_signaling_signaling_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   4,
+			NumEnums:      2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
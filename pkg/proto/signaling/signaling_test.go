@@ -60,3 +60,30 @@ var _ = Describe("message encryption", func() {
 		Expect(err).To(HaveOccurred(), "Decrypted invalid message: %s", err)
 	})
 })
+
+var _ = Describe("sequence numbers", func() {
+	It("are strictly increasing", func() {
+		last := signalingproto.NextSequence()
+
+		for range 1000 {
+			seq := signalingproto.NextSequence()
+			Expect(seq).To(BeNumerically(">", last))
+			last = seq
+		}
+	})
+
+	It("are assigned when encrypting a message", func() {
+		ourKP, _, err := test.GenerateKeyPairs()
+		Expect(err).To(Succeed())
+
+		msg := &signalingproto.Message{}
+		_, err = msg.Encrypt(ourKP)
+		Expect(err).To(Succeed())
+		Expect(msg.Sequence).NotTo(BeZero())
+
+		seq := msg.Sequence
+		_, err = msg.Encrypt(ourKP)
+		Expect(err).To(Succeed())
+		Expect(msg.Sequence).To(Equal(seq), "Sequence number must be retained")
+	})
+})
@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package relay
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"cunicu.li/cunicu/pkg/crypto"
+)
+
+// CommunitySecret derives the shared secret which members of a community use
+// to authenticate at the relays offered by other members.
+func CommunitySecret(community crypto.Key) string {
+	digest := hmac.New(sha256.New, community[:])
+	digest.Write([]byte("cunicu-peer-relay"))
+
+	return base64.StdEncoding.EncodeToString(digest.Sum(nil))
+}
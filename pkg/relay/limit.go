@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package relay
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// tokenBucket limits the rate of relayed bytes.
+// The bucket holds up to one second worth of traffic.
+type tokenBucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+	mu     sync.Mutex
+}
+
+func newTokenBucket(rate int) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(rate),
+		tokens: float64(rate),
+		last:   time.Now(),
+	}
+}
+
+// allow consumes n tokens if available.
+func (b *tokenBucket) allow(n int, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.rate, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+
+	if b.tokens < float64(n) {
+		return false
+	}
+
+	b.tokens -= float64(n)
+
+	return true
+}
+
+// allocationConn is the relayed transport address of an allocation.
+// Packets exceeding the bandwidth limit of the allocation are dropped.
+type allocationConn struct {
+	net.PacketConn
+
+	limiter *tokenBucket
+
+	release     func()
+	releaseOnce sync.Once
+}
+
+func (c *allocationConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	for {
+		n, addr, err := c.PacketConn.ReadFrom(p)
+		if err != nil || c.limiter == nil || c.limiter.allow(n, time.Now()) {
+			return n, addr, err
+		}
+	}
+}
+
+func (c *allocationConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	if c.limiter != nil && !c.limiter.allow(len(p), time.Now()) {
+		return len(p), nil
+	}
+
+	return c.PacketConn.WriteTo(p, addr)
+}
+
+func (c *allocationConn) Close() error {
+	c.releaseOnce.Do(c.release)
+
+	return c.PacketConn.Close()
+}
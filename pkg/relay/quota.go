@@ -0,0 +1,286 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package relay
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pion/stun/v3"
+	"github.com/pion/turn/v4"
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/log"
+)
+
+// Time after which a reservation for an allocate request without response is dropped.
+const reservationTimeout = 30 * time.Second
+
+var errTotalQuotaReached = errors.New("total allocation quota reached")
+
+//nolint:gochecknoglobals
+var (
+	typeAllocateRequest = stun.NewType(stun.MethodAllocate, stun.ClassRequest)
+	typeAllocateSuccess = stun.NewType(stun.MethodAllocate, stun.ClassSuccessResponse)
+	typeAllocateError   = stun.NewType(stun.MethodAllocate, stun.ClassErrorResponse)
+
+	allocQuotaReached = stun.ErrorCodeAttribute{
+		Code:   stun.CodeAllocQuotaReached,
+		Reason: []byte("Allocation Quota Reached"),
+	}
+)
+
+type reservation struct {
+	user    string
+	expires time.Time
+}
+
+// quota limits the number of concurrent allocations per user and in total.
+//
+// pion/turn does not pass the requesting user to the relay address generator.
+// Hence, the user quota observes the allocate transactions on the TURN socket:
+// An allocate request reserves an allocation for the user of the request,
+// keyed by its transaction ID. The success response confirms the reservation
+// and binds it to the relayed transport address of the new allocation.
+type quota struct {
+	user  int
+	total int
+
+	reservations map[[stun.TransactionIDSize]byte]reservation
+	relays       map[string]string
+	users        map[string]int
+	count        int
+	mu           sync.Mutex
+}
+
+func newQuota(user, total int) *quota {
+	return &quota{
+		user:         user,
+		total:        total,
+		reservations: map[[stun.TransactionIDSize]byte]reservation{},
+		relays:       map[string]string{},
+		users:        map[string]int{},
+	}
+}
+
+// reserve reserves an allocation for the user of an allocate request.
+// It returns false if the user quota has been reached.
+func (q *quota) reserve(id [stun.TransactionIDSize]byte, user string, now time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for rid, r := range q.reservations {
+		if now.After(r.expires) {
+			q.cancelLocked(rid)
+		}
+	}
+
+	// Retransmission of a pending request
+	if _, ok := q.reservations[id]; ok {
+		return true
+	}
+
+	if q.user > 0 && q.users[user] >= q.user {
+		return false
+	}
+
+	q.reservations[id] = reservation{
+		user:    user,
+		expires: now.Add(reservationTimeout),
+	}
+	q.users[user]++
+
+	return true
+}
+
+// confirm binds a reservation to the relayed transport address of the allocation.
+func (q *quota) confirm(id [stun.TransactionIDSize]byte, relay string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	r, ok := q.reservations[id]
+	if !ok {
+		return
+	}
+
+	delete(q.reservations, id)
+
+	q.relays[relay] = r.user
+}
+
+// cancel drops the reservation of a failed allocate request.
+func (q *quota) cancel(id [stun.TransactionIDSize]byte) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.cancelLocked(id)
+}
+
+func (q *quota) cancelLocked(id [stun.TransactionIDSize]byte) {
+	r, ok := q.reservations[id]
+	if !ok {
+		return
+	}
+
+	delete(q.reservations, id)
+
+	q.releaseUserLocked(r.user)
+}
+
+// acquire reserves a slot of the total quota.
+func (q *quota) acquire() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.total > 0 && q.count >= q.total {
+		return errTotalQuotaReached
+	}
+
+	q.count++
+
+	return nil
+}
+
+// release frees the slot of an allocation identified by its relayed transport address.
+func (q *quota) release(relay string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.count--
+
+	if user, ok := q.relays[relay]; ok {
+		delete(q.relays, relay)
+		q.releaseUserLocked(user)
+	}
+}
+
+func (q *quota) releaseUserLocked(user string) {
+	if q.users[user]--; q.users[user] <= 0 {
+		delete(q.users, user)
+	}
+}
+
+// quotaConn observes the allocate transactions on the listening socket of the TURN server
+// in order to enforce the user quota.
+type quotaConn struct {
+	net.PacketConn
+
+	quota *quota
+
+	// user returns the user to which an allocate request is charged.
+	// It returns false if the request can not be attributed to a user.
+	user func(username string, src net.Addr) (string, bool)
+
+	logger *log.Logger
+}
+
+func (c *quotaConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	for {
+		n, addr, err := c.PacketConn.ReadFrom(p)
+		if err != nil || c.quota.user <= 0 || c.allowed(p[:n], addr) {
+			return n, addr, err
+		}
+	}
+}
+
+func (c *quotaConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	if c.quota.user > 0 && stun.IsMessage(p) {
+		m := &stun.Message{Raw: append([]byte{}, p...)}
+		if err := m.Decode(); err == nil {
+			switch m.Type {
+			case typeAllocateSuccess:
+				var relayed stun.XORMappedAddress
+				if err := relayed.GetFromAs(m, stun.AttrXORRelayedAddress); err == nil {
+					c.quota.confirm(m.TransactionID, (&net.UDPAddr{IP: relayed.IP, Port: relayed.Port}).String())
+				}
+
+			case typeAllocateError:
+				c.quota.cancel(m.TransactionID)
+			}
+		}
+	}
+
+	return c.PacketConn.WriteTo(p, addr)
+}
+
+// allowed reserves an allocation for authenticated allocate requests
+// and rejects them if the quota of the user has been reached.
+func (c *quotaConn) allowed(p []byte, addr net.Addr) bool {
+	if !stun.IsMessage(p) {
+		return true
+	}
+
+	m := &stun.Message{Raw: append([]byte{}, p...)}
+	if err := m.Decode(); err != nil || m.Type != typeAllocateRequest {
+		return true
+	}
+
+	var username stun.Username
+	if err := username.GetFrom(m); err != nil {
+		return true // Unauthenticated requests are challenged by pion/turn
+	}
+
+	user, ok := c.user(username.String(), addr)
+	if !ok || c.quota.reserve(m.TransactionID, user, time.Now()) {
+		return true
+	}
+
+	c.logger.Debug("Rejected allocation exceeding user quota",
+		zap.String("user", user),
+		zap.Any("source", addr))
+
+	resp, err := stun.Build(
+		stun.NewTransactionIDSetter(m.TransactionID),
+		typeAllocateError,
+		allocQuotaReached,
+		stun.Fingerprint,
+	)
+	if err == nil {
+		c.PacketConn.WriteTo(resp.Raw, addr) //nolint:errcheck
+	}
+
+	return false
+}
+
+// relayAddressGenerator enforces the total allocation quota and bandwidth limits
+// on the relayed transport addresses allocated by the wrapped generator.
+type relayAddressGenerator struct {
+	turn.RelayAddressGenerator
+
+	quota     *quota
+	bandwidth int
+}
+
+func (g *relayAddressGenerator) AllocatePacketConn(network string, requestedPort int) (net.PacketConn, net.Addr, error) {
+	if err := g.quota.acquire(); err != nil {
+		return nil, nil, err
+	}
+
+	conn, addr, err := g.RelayAddressGenerator.AllocatePacketConn(network, requestedPort)
+	if err != nil {
+		g.quota.release("")
+
+		return nil, nil, err
+	}
+
+	relay := addr.String()
+	if ua, ok := addr.(*net.UDPAddr); ok {
+		relay = (&net.UDPAddr{IP: ua.IP, Port: ua.Port}).String()
+	}
+
+	ac := &allocationConn{
+		PacketConn: conn,
+		release: func() {
+			g.quota.release(relay)
+		},
+	}
+
+	if g.bandwidth > 0 {
+		ac.limiter = newTokenBucket(g.bandwidth)
+	}
+
+	return ac, addr, nil
+}
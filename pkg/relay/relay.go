@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package relay implements an embedded TURN server which accepts the credentials issued by the relay API server.
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/pion/turn/v4"
+	"go.uber.org/zap"
+
+	"cunicu.li/cunicu/pkg/log"
+	netx "cunicu.li/cunicu/pkg/net"
+	grpcx "cunicu.li/cunicu/pkg/signaling/grpc"
+)
+
+const DefaultRealm = "cunicu.li"
+
+var (
+	errMissingRelayAddress  = errors.New("missing relay address")
+	errInvalidListenAddress = errors.New("invalid listen address")
+)
+
+type Config struct {
+	// Address of the UDP socket on which the TURN server listens
+	ListenAddress string
+
+	// Public IP address at which relayed transport addresses are reachable
+	RelayAddress net.IP
+
+	// Relay whose credentials are accepted
+	Relay grpcx.RelayInfo
+
+	// Maximum number of concurrent allocations per user and in total (zero for no limit)
+	UserQuota  int
+	TotalQuota int
+
+	// Maximum bandwidth of each allocation in bytes per second (zero for no limit)
+	Bandwidth int
+
+	// QuotaByAddress charges the user quota per client IP address instead of per user.
+	// This is required if clients can issue credentials for arbitrary users themselves.
+	QuotaByAddress bool
+
+	// PermissionHandler restricts the peers to which clients may relay traffic (nil permits all peers)
+	PermissionHandler turn.PermissionHandler
+}
+
+type Server struct {
+	*turn.Server
+
+	conn  net.PacketConn
+	quota *quota
+
+	logger *log.Logger
+}
+
+func NewServer(cfg Config) (*Server, error) {
+	logger := log.Global.Named("relay")
+
+	conn, err := netx.ListenConfig().ListenPacket(context.Background(), "udp", cfg.ListenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	laddr, ok := conn.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		conn.Close()
+
+		return nil, fmt.Errorf("%w: %s", errInvalidListenAddress, conn.LocalAddr())
+	}
+
+	relayAddr := cfg.RelayAddress
+	if relayAddr == nil {
+		if laddr.IP.IsUnspecified() {
+			conn.Close()
+
+			return nil, errMissingRelayAddress
+		}
+
+		relayAddr = laddr.IP
+	}
+
+	if cfg.Relay.Realm == "" {
+		cfg.Relay.Realm = DefaultRealm
+	}
+
+	s := &Server{
+		conn:   conn,
+		quota:  newQuota(cfg.UserQuota, cfg.TotalQuota),
+		logger: logger,
+	}
+
+	tnet, err := netx.NewNet()
+	if err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("failed to create network: %w", err)
+	}
+
+	if s.Server, err = turn.NewServer(turn.ServerConfig{
+		Realm:         cfg.Relay.Realm,
+		AuthHandler:   s.authHandler(&cfg.Relay),
+		LoggerFactory: log.NewPionLoggerFactory(logger),
+		PacketConnConfigs: []turn.PacketConnConfig{
+			{
+				PacketConn: &quotaConn{
+					PacketConn: conn,
+					quota:      s.quota,
+					user: func(username string, src net.Addr) (string, bool) {
+						_, user, ok := cfg.Relay.VerifyCredentials(username, time.Now())
+						if ok && cfg.QuotaByAddress {
+							if ua, isUDP := src.(*net.UDPAddr); isUDP {
+								return ua.IP.String(), true
+							}
+						}
+
+						return user, ok
+					},
+					logger: logger,
+				},
+				RelayAddressGenerator: &relayAddressGenerator{
+					RelayAddressGenerator: &turn.RelayAddressGeneratorStatic{
+						RelayAddress: relayAddr,
+						Address:      laddr.IP.String(),
+						Net:          tnet,
+					},
+					quota:     s.quota,
+					bandwidth: cfg.Bandwidth,
+				},
+				PermissionHandler: s.permissionHandler(cfg.PermissionHandler),
+			},
+		},
+	}); err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("failed to create TURN server: %w", err)
+	}
+
+	logger.Info("Started TURN server",
+		zap.Any("address", laddr),
+		zap.Any("relay_address", relayAddr),
+		zap.String("realm", cfg.Relay.Realm))
+
+	return s, nil
+}
+
+// LocalAddr returns the address on which the TURN server listens.
+func (s *Server) LocalAddr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+// permissionHandler logs denied permissions of the wrapped handler.
+func (s *Server) permissionHandler(h turn.PermissionHandler) turn.PermissionHandler {
+	if h == nil {
+		return turn.DefaultPermissionHandler
+	}
+
+	return func(clientAddr net.Addr, peerIP net.IP) bool {
+		if h(clientAddr, peerIP) {
+			return true
+		}
+
+		s.logger.Debug("Denied permission",
+			zap.Any("client", clientAddr),
+			zap.Any("peer", peerIP))
+
+		return false
+	}
+}
+
+// authHandler accepts exactly the credentials which are handed out by the relay API server.
+func (s *Server) authHandler(r *grpcx.RelayInfo) turn.AuthHandler {
+	return func(user, realm string, srcAddr net.Addr) ([]byte, bool) {
+		pass, _, ok := r.VerifyCredentials(user, time.Now())
+		if !ok {
+			s.logger.Debug("Rejected invalid credentials",
+				zap.String("user", user),
+				zap.Any("source", srcAddr))
+
+			return nil, false
+		}
+
+		return turn.GenerateAuthKey(user, realm, pass), true
+	}
+}
@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package relay_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pion/turn/v4"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/relay"
+	grpcx "cunicu.li/cunicu/pkg/signaling/grpc"
+	"cunicu.li/cunicu/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSuite(t *testing.T) {
+	test.SetupLogging()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Relay Suite")
+}
+
+var _ = Describe("TURN server", func() {
+	var (
+		svr     *relay.Server
+		info    grpcx.RelayInfo
+		cfg     relay.Config
+		clients []*turn.Client
+	)
+
+	newClient := func(user, pass string) *turn.Client {
+		conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+		Expect(err).To(Succeed())
+
+		c, err := turn.NewClient(&turn.ClientConfig{
+			TURNServerAddr: svr.LocalAddr().String(),
+			STUNServerAddr: svr.LocalAddr().String(),
+			Username:       user,
+			Password:       pass,
+			Realm:          relay.DefaultRealm,
+			Conn:           conn,
+			RTO:            100 * time.Millisecond,
+		})
+		Expect(err).To(Succeed())
+		Expect(c.Listen()).To(Succeed())
+
+		clients = append(clients, c)
+
+		return c
+	}
+
+	BeforeEach(func() {
+		var err error
+		info, err = grpcx.NewRelayInfo("turn:127.0.0.1?secret=mysecret")
+		Expect(err).To(Succeed())
+
+		cfg = relay.Config{
+			ListenAddress: "127.0.0.1:0",
+			Relay:         info,
+		}
+
+		clients = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		svr, err = relay.NewServer(cfg)
+		Expect(err).To(Succeed())
+	})
+
+	AfterEach(func() {
+		for _, c := range clients {
+			c.Close()
+		}
+
+		Expect(svr.Close()).To(Succeed())
+	})
+
+	It("accepts credentials issued by the relay API", func() {
+		user, pass, _ := info.GetCredentials("peer1")
+
+		c := newClient(user, pass)
+
+		conn, err := c.Allocate()
+		Expect(err).To(Succeed())
+		Expect(conn.Close()).To(Succeed())
+	})
+
+	It("rejects invalid credentials", func() {
+		user, _, _ := info.GetCredentials("peer1")
+
+		c := newClient(user, "wrong-password")
+
+		_, err := c.Allocate()
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("with a community secret", func() {
+		var community crypto.Key
+
+		BeforeEach(func() {
+			var err error
+			community, err = crypto.GeneratePrivateKey()
+			Expect(err).To(Succeed())
+
+			info = grpcx.RelayInfo{
+				Secret: relay.CommunitySecret(community),
+				TTL:    time.Hour,
+			}
+
+			cfg.Relay = info
+		})
+
+		It("accepts credentials of other community members", func() {
+			member := grpcx.RelayInfo{
+				Secret: relay.CommunitySecret(community),
+				TTL:    time.Hour,
+			}
+
+			user, pass, _ := member.GetCredentials("peer1")
+
+			conn, err := newClient(user, pass).Allocate()
+			Expect(err).To(Succeed())
+			Expect(conn.Close()).To(Succeed())
+		})
+
+		It("rejects credentials of other communities", func() {
+			other, err := crypto.GeneratePrivateKey()
+			Expect(err).To(Succeed())
+
+			stranger := grpcx.RelayInfo{
+				Secret: relay.CommunitySecret(other),
+				TTL:    time.Hour,
+			}
+
+			user, pass, _ := stranger.GetCredentials("peer1")
+
+			_, err = newClient(user, pass).Allocate()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("with quotas", func() {
+		BeforeEach(func() {
+			cfg.UserQuota = 1
+			cfg.TotalQuota = 2
+		})
+
+		It("limits the number of allocations", func() {
+			user1, pass1, _ := info.GetCredentials("peer1")
+			user2, pass2, _ := info.GetCredentials("peer2")
+			user3, pass3, _ := info.GetCredentials("peer3")
+
+			_, err := newClient(user1, pass1).Allocate()
+			Expect(err).To(Succeed())
+
+			_, err = newClient(user1, pass1).Allocate()
+			Expect(err).To(HaveOccurred(), "Exceeded user quota")
+
+			_, err = newClient(user2, pass2).Allocate()
+			Expect(err).To(Succeed())
+
+			_, err = newClient(user3, pass3).Allocate()
+			Expect(err).To(HaveOccurred(), "Exceeded total quota")
+		})
+
+		It("does not charge failed allocations", func() {
+			user, pass, _ := info.GetCredentials("peer1")
+
+			_, err := newClient(user, "wrong-password").Allocate()
+			Expect(err).To(HaveOccurred())
+
+			_, err = newClient(user, pass).Allocate()
+			Expect(err).To(Succeed())
+		})
+
+		It("charges allocations to the user of the allocate request", func() {
+			user1, pass1, _ := info.GetCredentials("peer1")
+			user2, pass2, _ := info.GetCredentials("peer2")
+
+			c1 := newClient(user1, pass1)
+
+			conn1, err := c1.Allocate()
+			Expect(err).To(Succeed())
+
+			// Authenticated requests of other users must not affect the accounting
+			peer, err := net.ListenPacket("udp4", "127.0.0.1:0")
+			Expect(err).To(Succeed())
+
+			defer peer.Close()
+
+			_, err = conn1.WriteTo([]byte("hello"), peer.LocalAddr())
+			Expect(err).To(Succeed())
+
+			_, err = newClient(user2, pass2).Allocate()
+			Expect(err).To(Succeed())
+			Expect(svr.AllocationCount()).To(Equal(2))
+		})
+
+		It("releases allocations", func() {
+			user, pass, _ := info.GetCredentials("peer1")
+
+			c := newClient(user, pass)
+
+			conn, err := c.Allocate()
+			Expect(err).To(Succeed())
+			Expect(conn.Close()).To(Succeed())
+
+			Eventually(svr.AllocationCount).Should(BeZero())
+
+			_, err = newClient(user, pass).Allocate()
+			Expect(err).To(Succeed())
+		})
+	})
+
+	Context("with quotas by address", func() {
+		BeforeEach(func() {
+			cfg.UserQuota = 1
+			cfg.QuotaByAddress = true
+		})
+
+		It("charges allocations to the client address", func() {
+			user1, pass1, _ := info.GetCredentials("peer1")
+			user2, pass2, _ := info.GetCredentials("peer2")
+
+			_, err := newClient(user1, pass1).Allocate()
+			Expect(err).To(Succeed())
+
+			_, err = newClient(user2, pass2).Allocate()
+			Expect(err).To(HaveOccurred(), "Exceeded quota of address")
+		})
+	})
+
+	Context("with permission handler", func() {
+		var peer net.PacketConn
+
+		BeforeEach(func() {
+			var err error
+			peer, err = net.ListenPacket("udp4", "127.0.0.1:0")
+			Expect(err).To(Succeed())
+
+			DeferCleanup(peer.Close)
+
+			cfg.PermissionHandler = func(_ net.Addr, peerIP net.IP) bool {
+				return !peerIP.Equal(net.IPv4(127, 0, 0, 1))
+			}
+		})
+
+		It("does not relay to denied peers", func() {
+			user, pass, _ := info.GetCredentials("peer1")
+
+			conn, err := newClient(user, pass).Allocate()
+			Expect(err).To(Succeed())
+
+			_, err = conn.WriteTo([]byte("hello"), peer.LocalAddr())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("with bandwidth limit", func() {
+		BeforeEach(func() {
+			cfg.Bandwidth = 1000
+		})
+
+		It("drops packets exceeding the limit", func() {
+			user, pass, _ := info.GetCredentials("peer1")
+
+			conn, err := newClient(user, pass).Allocate()
+			Expect(err).To(Succeed())
+
+			peer, err := net.ListenPacket("udp4", "127.0.0.1:0")
+			Expect(err).To(Succeed())
+
+			defer peer.Close()
+
+			buf := make([]byte, 500)
+			for range 10 {
+				_, err := conn.WriteTo(buf, peer.LocalAddr())
+				Expect(err).To(Succeed())
+			}
+
+			received := 0
+
+			for {
+				Expect(peer.SetReadDeadline(time.Now().Add(200 * time.Millisecond))).To(Succeed())
+
+				if _, _, err := peer.ReadFrom(buf); err != nil {
+					break
+				}
+
+				received++
+			}
+
+			Expect(received).To(BeNumerically(">", 0))
+			Expect(received).To(BeNumerically("<=", 3))
+		})
+	})
+})
@@ -37,6 +37,7 @@ type Client struct {
 	io.Closer
 
 	rpcproto.EndpointDiscoverySocketClient
+	rpcproto.PeerDiscoverySocketClient
 	rpcproto.SignalingClient
 	rpcproto.DaemonClient
 
@@ -82,6 +83,7 @@ func Connect(path string) (*Client, error) {
 
 	c := &Client{
 		EndpointDiscoverySocketClient: rpcproto.NewEndpointDiscoverySocketClient(conn),
+		PeerDiscoverySocketClient:     rpcproto.NewPeerDiscoverySocketClient(conn),
 		SignalingClient:               rpcproto.NewSignalingClient(conn),
 		DaemonClient:                  rpcproto.NewDaemonClient(conn),
 
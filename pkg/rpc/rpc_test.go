@@ -6,11 +6,14 @@ package rpc_test
 import (
 	"testing"
 
+	"cunicu.li/cunicu/test"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
 func TestSuite(t *testing.T) {
+	test.SetupLogging()
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "RPC Suite")
 }
@@ -22,6 +22,7 @@ import (
 type Server struct {
 	daemon    *DaemonServer
 	epdisc    *EndpointDiscoveryServer
+	pdisc     *PeerDiscoveryServer
 	signaling *SignalingServer
 
 	grpc *grpc.Server
@@ -50,6 +51,7 @@ func NewServer(d *daemon.Daemon, socket string) (*Server, error) {
 	s.daemon = NewDaemonServer(s, d)
 	s.signaling = NewSignalingServer(s, d.Backend)
 	s.epdisc = NewEndpointDiscoveryServer(s)
+	s.pdisc = NewPeerDiscoveryServer(s)
 
 	l, err := xnet.Listen(socket)
 	if err != nil {
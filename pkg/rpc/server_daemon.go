@@ -25,6 +25,7 @@ import (
 	"cunicu.li/cunicu/pkg/crypto"
 	"cunicu.li/cunicu/pkg/daemon"
 	"cunicu.li/cunicu/pkg/daemon/feature/epdisc"
+	"cunicu.li/cunicu/pkg/daemon/feature/pdisc"
 	osx "cunicu.li/cunicu/pkg/os"
 	"cunicu.li/cunicu/pkg/proto"
 	coreproto "cunicu.li/cunicu/pkg/proto/core"
@@ -133,6 +134,7 @@ func (s *DaemonServer) GetStatus(_ context.Context, p *rpcproto.GetStatusParams)
 
 	if err := s.daemon.ForEachInterface(func(i *daemon.Interface) error {
 		epi := epdisc.Get(i)
+		pdi := pdisc.Get(i)
 
 		if p.Interface == "" || i.Name() == p.Interface {
 			qi := i.MarshalWithPeers(func(cp *daemon.Peer) *coreproto.Peer {
@@ -149,6 +151,12 @@ func (s *DaemonServer) GetStatus(_ context.Context, p *rpcproto.GetStatusParams)
 					}
 				}
 
+				if pdi != nil {
+					if _, ok := pdi.Route(cp); ok {
+						qp.Reachability = coreproto.ReachabilityType_ROUTED
+					}
+				}
+
 				return qp
 			})
 
@@ -171,9 +179,16 @@ func (s *DaemonServer) GetStatus(_ context.Context, p *rpcproto.GetStatusParams)
 		return nil, status.Errorf(codes.NotFound, "no such peer '%s' for interface '%s'", pk, p.Interface)
 	}
 
-	return &rpcproto.GetStatusResp{
+	resp := &rpcproto.GetStatusResp{
 		Interfaces: qis,
-	}, nil
+	}
+
+	// Signaling backends are not specific to an interface
+	if p.Interface == "" {
+		resp.Backends = s.Backend.Status()
+	}
+
+	return resp, nil
 }
 
 func (s *DaemonServer) SetConfig(_ context.Context, p *rpcproto.SetConfigParams) (*proto.Empty, error) {
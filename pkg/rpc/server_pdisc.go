@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"cunicu.li/cunicu/pkg/config"
+	"cunicu.li/cunicu/pkg/daemon"
+	"cunicu.li/cunicu/pkg/daemon/feature/pdisc"
+	"cunicu.li/cunicu/pkg/proto"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+	rpcproto "cunicu.li/cunicu/pkg/proto/rpc"
+)
+
+type PeerDiscoveryServer struct {
+	rpcproto.UnimplementedPeerDiscoverySocketServer
+
+	*Server
+
+	filterMu sync.Mutex
+}
+
+func NewPeerDiscoveryServer(s *Server) *PeerDiscoveryServer {
+	pds := &PeerDiscoveryServer{
+		Server: s,
+	}
+
+	rpcproto.RegisterPeerDiscoverySocketServer(s.grpc, pds)
+
+	return pds
+}
+
+func (s *PeerDiscoveryServer) RevokePeer(_ context.Context, params *rpcproto.RevokePeerParams) (*proto.Empty, error) {
+	if params.Revocation == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing revocation")
+	}
+
+	is, err := s.interfaces(params.Intf)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range is {
+		if err := i.Revoke(params.Revocation); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to revoke peer on interface %s: %s", i.Name(), err)
+		}
+	}
+
+	return &proto.Empty{}, nil
+}
+
+func (s *PeerDiscoveryServer) UseExitNode(_ context.Context, params *rpcproto.UseExitNodeParams) (*proto.Empty, error) {
+	is, err := s.interfaces(params.Intf)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range is {
+		if err := i.UseExitNode(params.Peer); err != nil {
+			code := codes.NotFound
+			if errors.Is(err, pdiscproto.ErrAmbiguousExitNode) {
+				code = codes.FailedPrecondition
+			}
+
+			return nil, status.Errorf(code, "failed to select exit node on interface %s: %s", i.Name(), err)
+		}
+	}
+
+	return &proto.Empty{}, nil
+}
+
+func (s *PeerDiscoveryServer) AllowPeer(_ context.Context, params *rpcproto.PeerFilterParams) (*proto.Empty, error) {
+	return s.updateFilter(params, true)
+}
+
+func (s *PeerDiscoveryServer) DenyPeer(_ context.Context, params *rpcproto.PeerFilterParams) (*proto.Empty, error) {
+	return s.updateFilter(params, false)
+}
+
+// updateFilter adds or removes a selector to the whitelist or blacklist,
+// persists the lists in the runtime configuration and applies them to the interfaces.
+func (s *PeerDiscoveryServer) updateFilter(params *rpcproto.PeerFilterParams, allow bool) (*proto.Empty, error) {
+	sel, err := config.ParsePeerSelector(params.Selector)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	is, err := s.interfaces(params.Intf)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize concurrent updates as they modify the filter based on the current settings
+	s.filterMu.Lock()
+	defer s.filterMu.Unlock()
+
+	cfg := s.daemon.Config
+	settings := &cfg.DefaultInterfaceSettings
+	prefix := ""
+
+	if params.Intf != "" {
+		if settings = cfg.InterfaceSettings(params.Intf); settings == nil {
+			return nil, status.Errorf(codes.NotFound, "interface %s is not configured", params.Intf)
+		}
+
+		prefix = "interfaces." + params.Intf + "."
+	}
+
+	f := settings.PeerFilter()
+
+	// Adding the first selector to the whitelist rejects all other peers
+	if allow && !params.Remove && len(f.Whitelist) == 0 && !params.EnableWhitelist {
+		return nil, status.Error(codes.FailedPrecondition, "whitelist is empty: adding a selector rejects all other peers unless the whitelist is enabled explicitly")
+	}
+
+	switch {
+	case allow && params.Remove:
+		f.Whitelist = f.Whitelist.Remove(sel)
+	case allow:
+		f = f.Allow(sel)
+	case params.Remove:
+		f.Blacklist = f.Blacklist.Remove(sel)
+	default:
+		f = f.Deny(sel)
+	}
+
+	if _, err := cfg.Update(map[string]any{
+		prefix + "whitelist": f.Whitelist.Strings(),
+		prefix + "blacklist": f.Blacklist.Strings(),
+	}); err != nil {
+		return nil, decodeError(err)
+	}
+
+	for _, i := range is {
+		icfg := cfg.InterfaceSettings(i.Name())
+		if icfg == nil {
+			continue
+		}
+
+		if err := i.SetFilter(icfg.PeerFilter()); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update filter of interface %s: %s", i.Name(), err)
+		}
+	}
+
+	return &proto.Empty{}, nil
+}
+
+// interfaces returns the interface with the given name or all interfaces
+// if the name is empty which have peer discovery enabled.
+func (s *PeerDiscoveryServer) interfaces(name string) ([]*pdisc.Interface, error) {
+	is := []*pdisc.Interface{}
+
+	if name != "" {
+		di := s.daemon.InterfaceByName(name)
+		if di == nil {
+			return nil, status.Errorf(codes.NotFound, "unknown interface %s", name)
+		}
+
+		i := pdisc.Get(di)
+		if i == nil {
+			return nil, status.Errorf(codes.NotFound, "interface %s has peer discovery not enabled", name)
+		}
+
+		return append(is, i), nil
+	}
+
+	if err := s.daemon.ForEachInterface(func(di *daemon.Interface) error {
+		if i := pdisc.Get(di); i != nil {
+			is = append(is, i)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(is) == 0 {
+		return nil, status.Error(codes.NotFound, "no interface has peer discovery enabled")
+	}
+
+	return is, nil
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"cunicu.li/cunicu/pkg/config"
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/daemon"
+	"cunicu.li/cunicu/pkg/daemon/feature/pdisc"
+	pdiscproto "cunicu.li/cunicu/pkg/proto/feature/pdisc"
+	rpcproto "cunicu.li/cunicu/pkg/proto/rpc"
+	"cunicu.li/cunicu/pkg/rpc"
+
+	_ "cunicu.li/cunicu/pkg/signaling/inprocess"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("peer discovery server", Ordered, func() {
+	const intfName = "wg-rpc-test"
+
+	var (
+		cfg *config.Config
+		d   *daemon.Daemon
+		s   *rpc.Server
+		c   *rpc.Client
+		pi  *pdisc.Interface
+	)
+
+	BeforeAll(func() {
+		dir := GinkgoT().TempDir()
+		config.RuntimeConfigFile = filepath.Join(dir, "runtime.yaml")
+
+		flags := pflag.NewFlagSet("", pflag.ContinueOnError)
+		cfg = config.New(flags)
+
+		err := flags.Parse([]string{
+			"--wg-userspace",
+			"--backend", "inprocess:",
+			"--community", "rpc-test",
+			"--discover-endpoints=false",
+			"--sync-config=false",
+			"--sync-hosts=false",
+			"--sync-routes=false",
+			"--port-forwarding=false",
+			intfName,
+		})
+		Expect(err).To(Succeed())
+		Expect(cfg.Init(flags.Args())).To(Succeed())
+
+		d, err = daemon.NewDaemon(cfg)
+		Expect(err).To(Succeed())
+
+		go d.Start() //nolint:errcheck
+
+		Eventually(func() *pdisc.Interface {
+			if di := d.InterfaceByName(intfName); di != nil {
+				return pdisc.Get(di)
+			}
+
+			return nil
+		}).ShouldNot(BeNil())
+
+		pi = pdisc.Get(d.InterfaceByName(intfName))
+
+		socket := filepath.Join(dir, "cunicu.sock")
+
+		s, err = rpc.NewServer(d, socket)
+		Expect(err).To(Succeed())
+
+		c, err = rpc.Connect(socket)
+		Expect(err).To(Succeed())
+	})
+
+	AfterAll(func() {
+		Expect(c.Close()).To(Succeed())
+		Expect(s.Close()).To(Succeed())
+
+		d.Shutdown(false)
+		Expect(d.Close()).To(Succeed())
+	})
+
+	addPeer := func(name string) crypto.Key {
+		sk, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		pk := sk.PublicKey()
+
+		err = pi.OnPeerDescription(&pdiscproto.PeerDescription{
+			Change:    pdiscproto.PeerDescriptionChange_ADD,
+			Name:      name,
+			PublicKey: pk.Bytes(),
+		})
+		Expect(err).To(Succeed())
+
+		Eventually(func() *daemon.Peer {
+			return pi.Peer(pk)
+		}).ShouldNot(BeNil(), fmt.Sprintf("peer %s has not been added", name))
+
+		return pk
+	}
+
+	It("refuses to enable the whitelist implicitly", func() {
+		pk := addPeer("laptop")
+
+		_, err := c.AllowPeer(context.Background(), &rpcproto.PeerFilterParams{
+			Selector: "*.office.example.com",
+		})
+		Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+		Expect(cfg.DefaultInterfaceSettings.Whitelist).To(BeEmpty())
+		Consistently(func() *daemon.Peer {
+			return pi.Peer(pk)
+		}).ShouldNot(BeNil())
+	})
+
+	It("removes peers rejected by an explicitly enabled whitelist", func() {
+		pkOffice := addPeer("desktop.office.example.com")
+		pkOther := addPeer("phone")
+
+		_, err := c.AllowPeer(context.Background(), &rpcproto.PeerFilterParams{
+			Selector:        "*.office.example.com",
+			EnableWhitelist: true,
+		})
+		Expect(err).To(Succeed())
+
+		Expect(cfg.DefaultInterfaceSettings.Whitelist.Strings()).To(ConsistOf("*.office.example.com"))
+
+		Eventually(func() *daemon.Peer {
+			return pi.Peer(pkOther)
+		}).Should(BeNil())
+		Expect(pi.Peer(pkOffice)).NotTo(BeNil())
+
+		// Further selectors can be added to the non-empty whitelist
+		_, err = c.AllowPeer(context.Background(), &rpcproto.PeerFilterParams{
+			Selector: "phone",
+		})
+		Expect(err).To(Succeed())
+	})
+
+	It("removes blacklisted peers", func() {
+		_, err := c.AllowPeer(context.Background(), &rpcproto.PeerFilterParams{
+			Selector: "guest-*",
+		})
+		Expect(err).To(Succeed())
+
+		pk := addPeer("guest-1")
+
+		_, err = c.DenyPeer(context.Background(), &rpcproto.PeerFilterParams{
+			Selector: "guest-*",
+		})
+		Expect(err).To(Succeed())
+
+		Expect(cfg.DefaultInterfaceSettings.Blacklist.Strings()).To(ConsistOf("guest-*"))
+		Expect(cfg.DefaultInterfaceSettings.Whitelist.Strings()).NotTo(ContainElement("guest-*"))
+
+		Eventually(func() *daemon.Peer {
+			return pi.Peer(pk)
+		}).Should(BeNil())
+	})
+
+	It("keeps all selectors added concurrently", func() {
+		sels := []string{}
+		for n := range 10 {
+			sels = append(sels, fmt.Sprintf("concurrent-%d", n))
+		}
+
+		wg := sync.WaitGroup{}
+		for _, sel := range sels {
+			wg.Add(1)
+
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				_, err := c.DenyPeer(context.Background(), &rpcproto.PeerFilterParams{
+					Selector: sel,
+				})
+				Expect(err).To(Succeed())
+			}()
+		}
+
+		wg.Wait()
+
+		Expect(cfg.DefaultInterfaceSettings.Blacklist.Strings()).To(ContainElements(sels))
+	})
+
+	It("rejects invalid selectors", func() {
+		_, err := c.DenyPeer(context.Background(), &rpcproto.PeerFilterParams{
+			Selector: "[",
+		})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+})
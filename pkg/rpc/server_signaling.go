@@ -5,33 +5,28 @@ package rpc
 
 import (
 	"context"
+	"errors"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"cunicu.li/cunicu/pkg/crypto"
 	proto "cunicu.li/cunicu/pkg/proto"
 	rpcproto "cunicu.li/cunicu/pkg/proto/rpc"
-	signalingproto "cunicu.li/cunicu/pkg/proto/signaling"
 	"cunicu.li/cunicu/pkg/signaling"
-	"cunicu.li/cunicu/pkg/signaling/grpc"
 )
 
 type SignalingServer struct {
 	rpcproto.UnimplementedSignalingServer
 
 	*Server
-	*grpc.Backend
+	*signaling.MultiBackend
 }
 
 func NewSignalingServer(s *Server, b *signaling.MultiBackend) *SignalingServer {
-	gb, ok := b.ByType(signalingproto.BackendType_GRPC).(*grpc.Backend)
-	if !ok {
-		return nil
-	}
-
 	ss := &SignalingServer{
-		Server:  s,
-		Backend: gb,
+		Server:       s,
+		MultiBackend: b,
 	}
 
 	rpcproto.RegisterSignalingServer(s.grpc, ss)
@@ -39,10 +34,44 @@ func NewSignalingServer(s *Server, b *signaling.MultiBackend) *SignalingServer {
 	return ss
 }
 
-func (s *SignalingServer) GetSignalingMessage(_ context.Context, _ *rpcproto.GetSignalingMessageParams) (*rpcproto.GetSignalingMessageResp, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+func (s *SignalingServer) GetSignalingMessage(_ context.Context, params *rpcproto.GetSignalingMessageParams) (*rpcproto.GetSignalingMessageResp, error) {
+	di := s.daemon.InterfaceByName(params.Intf)
+	if di == nil {
+		return nil, status.Errorf(codes.NotFound, "unknown interface %s", params.Intf)
+	}
+
+	pk, err := crypto.ParseKeyBytes(params.Peer)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse peer public key: %s", err)
+	}
+
+	pkp := &crypto.PublicKeyPair{
+		Ours:   di.PublicKey(),
+		Theirs: pk,
+	}
+
+	env := s.LastEnvelope(pkp)
+	if env == nil {
+		return nil, status.Errorf(codes.NotFound, "no envelope received from peer %s/%s", params.Intf, pk)
+	}
+
+	return &rpcproto.GetSignalingMessageResp{
+		Envelope: env,
+	}, nil
 }
 
-func (s *SignalingServer) PutSignalingMessage(_ context.Context, _ *rpcproto.PutSignalingMessageParams) (*proto.Empty, error) {
-	return &proto.Empty{}, status.Error(codes.Unimplemented, "not implemented yet")
+func (s *SignalingServer) PutSignalingMessage(_ context.Context, params *rpcproto.PutSignalingMessageParams) (*proto.Empty, error) {
+	if params.Envelope == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing envelope")
+	}
+
+	if err := s.InjectEnvelope(params.Envelope); err != nil {
+		if errors.Is(err, signaling.ErrNotSubscribed) {
+			return nil, status.Errorf(codes.NotFound, "no subscription for recipient: %s", err)
+		}
+
+		return nil, status.Errorf(codes.InvalidArgument, "failed to inject envelope: %s", err)
+	}
+
+	return &proto.Empty{}, nil
 }
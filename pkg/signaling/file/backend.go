@@ -0,0 +1,356 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/log"
+	signalingproto "cunicu.li/cunicu/pkg/proto/signaling"
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+func init() { //nolint:gochecknoinits
+	signaling.Backends["file"] = &signaling.BackendPlugin{
+		New:         NewBackend,
+		Description: "Shared directory",
+	}
+}
+
+// directory contains the envelopes addressed to a single recipient.
+type directory struct {
+	path string
+
+	// seen contains the names of envelope files which have already been processed
+	seen map[string]any
+}
+
+type Backend struct {
+	signaling.SubscriptionsRegistry
+
+	watcher *fsnotify.Watcher
+
+	dirs   map[crypto.Key]*directory
+	dirsMu sync.Mutex
+
+	config BackendConfig
+	stop   chan struct{}
+
+	logger *log.Logger
+}
+
+func NewBackend(cfg *signaling.BackendConfig, logger *log.Logger) (signaling.Backend, error) {
+	b := &Backend{
+		SubscriptionsRegistry: signaling.NewSubscriptionsRegistry(),
+		dirs:                  map[crypto.Key]*directory{},
+		stop:                  make(chan struct{}),
+		logger:                logger,
+	}
+
+	if err := b.config.Parse(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse backend configuration: %w", err)
+	}
+
+	if err := os.MkdirAll(b.config.Options.Path, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if b.config.Options.Watch {
+		var err error
+		if b.watcher, err = fsnotify.NewWatcher(); err != nil {
+			return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+		}
+	}
+
+	go b.run()
+
+	for _, h := range cfg.OnReady {
+		h.OnSignalingBackendReady(b)
+	}
+
+	return b, nil
+}
+
+func (b *Backend) Type() signalingproto.BackendType {
+	return signalingproto.BackendType_FILE
+}
+
+func (b *Backend) Subscribe(_ context.Context, kp *crypto.KeyPair, h signaling.MessageHandler) (bool, error) {
+	first, err := b.SubscriptionsRegistry.Subscribe(kp, h)
+	if err != nil {
+		return false, err
+	} else if first {
+		pk := kp.Ours.PublicKey()
+
+		return first, b.subscribeDirectory(pk)
+	}
+
+	return first, nil
+}
+
+// Unsubscribe from messages send by a specific peer.
+func (b *Backend) Unsubscribe(_ context.Context, kp *crypto.KeyPair, h signaling.MessageHandler) (bool, error) {
+	last, err := b.SubscriptionsRegistry.Unsubscribe(kp, h)
+	if err != nil {
+		return false, err
+	} else if last {
+		pk := kp.Ours.PublicKey()
+
+		b.dirsMu.Lock()
+		defer b.dirsMu.Unlock()
+
+		if d, ok := b.dirs[pk]; ok {
+			delete(b.dirs, pk)
+
+			if b.watcher != nil {
+				if err := b.watcher.Remove(d.path); err != nil {
+					return last, fmt.Errorf("failed to stop watching directory: %w", err)
+				}
+			}
+		}
+	}
+
+	return last, nil
+}
+
+func (b *Backend) Publish(_ context.Context, kp *crypto.KeyPair, msg *signaling.Message) error {
+	env, err := msg.Encrypt(kp)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt message: %w", err)
+	}
+
+	data, err := proto.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to marshal protobuf: %w", err)
+	}
+
+	dir := b.directoryPath(kp.Theirs)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	rnd := make([]byte, 4)
+	if _, err := rand.Read(rnd); err != nil {
+		return fmt.Errorf("failed to generate file name: %w", err)
+	}
+
+	name := fmt.Sprintf("%d-%s-%s%s", time.Now().UnixNano(), signaling.EncodeKey(kp.Ours.PublicKey()), hex.EncodeToString(rnd), envelopeSuffix)
+
+	// Write to a hidden temporary file first and rename it afterwards so that
+	// readers never observe partially written envelopes.
+	tmp := filepath.Join(dir, "."+name)
+	if err := os.WriteFile(tmp, data, 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to write envelope: %w", err)
+	}
+
+	if err := os.Rename(tmp, filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("failed to rename envelope: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Backend) Close() error {
+	close(b.stop)
+
+	if b.watcher != nil {
+		if err := b.watcher.Close(); err != nil {
+			return fmt.Errorf("failed to close watcher: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (b *Backend) directoryPath(pk crypto.Key) string {
+	return filepath.Join(b.config.Options.Path, signaling.EncodeKey(pk))
+}
+
+func (b *Backend) subscribeDirectory(pk crypto.Key) error {
+	d := &directory{
+		path: b.directoryPath(pk),
+		seen: map[string]any{},
+	}
+
+	if err := os.MkdirAll(d.path, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if b.watcher != nil {
+		if err := b.watcher.Add(d.path); err != nil {
+			return fmt.Errorf("failed to watch directory: %w", err)
+		}
+	}
+
+	b.dirsMu.Lock()
+	b.dirs[pk] = d
+	paths := b.scan(d)
+	b.dirsMu.Unlock()
+
+	// Process envelopes which have been published before we subscribed
+	b.process(paths)
+
+	b.logger.Debug("Created new subscription", zap.Any("pk", pk), zap.String("path", d.path))
+
+	return nil
+}
+
+func (b *Backend) run() {
+	ticker := time.NewTicker(b.config.Options.PollInterval)
+	defer ticker.Stop()
+
+	var (
+		events <-chan fsnotify.Event
+		errs   <-chan error
+	)
+
+	if b.watcher != nil {
+		events = b.watcher.Events
+		errs = b.watcher.Errors
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if event.Op&(fsnotify.Create|fsnotify.Write) == 0 {
+				continue
+			}
+
+			b.scanPath(filepath.Dir(event.Name))
+
+		case err, ok := <-errs:
+			if !ok {
+				return
+			}
+
+			b.logger.Error("Error while watching directory", zap.Error(err))
+
+		case <-ticker.C:
+			paths := []string{}
+
+			b.dirsMu.Lock()
+			for _, d := range b.dirs {
+				paths = append(paths, b.scan(d)...)
+			}
+			b.dirsMu.Unlock()
+
+			b.process(paths)
+
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+func (b *Backend) scanPath(path string) {
+	paths := []string{}
+
+	b.dirsMu.Lock()
+	for _, d := range b.dirs {
+		if d.path == path {
+			paths = append(paths, b.scan(d)...)
+		}
+	}
+	b.dirsMu.Unlock()
+
+	b.process(paths)
+}
+
+// scan returns the paths of new envelopes in the directory and removes expired ones.
+// The caller must hold dirsMu.
+func (b *Backend) scan(d *directory) []string {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		b.logger.Error("Failed to read directory", zap.Error(err), zap.String("path", d.path))
+
+		return nil
+	}
+
+	present := map[string]any{}
+	paths := []string{}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || !strings.HasSuffix(name, envelopeSuffix) {
+			continue
+		}
+
+		path := filepath.Join(d.path, name)
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		if ttl := b.config.Options.TTL; ttl > 0 && time.Since(info.ModTime()) > ttl {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				b.logger.Warn("Failed to remove expired envelope", zap.Error(err), zap.String("path", path))
+			}
+
+			continue
+		}
+
+		present[name] = nil
+
+		if _, ok := d.seen[name]; ok {
+			continue
+		}
+
+		d.seen[name] = nil
+		paths = append(paths, path)
+	}
+
+	// Forget about envelopes which have been removed
+	for name := range d.seen {
+		if _, ok := present[name]; !ok {
+			delete(d.seen, name)
+		}
+	}
+
+	return paths
+}
+
+func (b *Backend) process(paths []string) {
+	for _, path := range paths {
+		if err := b.processEnvelope(path); err != nil {
+			b.logger.Error("Failed to process envelope", zap.Error(err), zap.String("path", path))
+		}
+	}
+}
+
+func (b *Backend) processEnvelope(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read envelope: %w", err)
+	}
+
+	env := &signalingproto.Envelope{}
+	if err := proto.Unmarshal(data, env); err != nil {
+		return fmt.Errorf("failed to unmarshal envelope: %w", err)
+	}
+
+	if err := b.SubscriptionsRegistry.NewMessage(env); err != nil && !errors.Is(err, signaling.ErrNotSubscribed) {
+		return fmt.Errorf("failed to decrypt message: %w", err)
+	}
+
+	return nil
+}
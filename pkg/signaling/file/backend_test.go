@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package file_test
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	epdiscproto "cunicu.li/cunicu/pkg/proto/feature/epdisc"
+	"cunicu.li/cunicu/pkg/signaling"
+	"cunicu.li/cunicu/pkg/signaling/file"
+	"cunicu.li/cunicu/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSuite(t *testing.T) {
+	test.SetupLogging()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "File Backend Suite")
+}
+
+var _ = Describe("file backend", func() {
+	u := &url.URL{
+		Scheme: "file",
+	}
+
+	Context("inotify", func() {
+		BeforeEach(func() {
+			u.Path = GinkgoT().TempDir()
+			u.RawQuery = ""
+		})
+
+		test.BackendTest(u, 10)
+	})
+
+	Context("polling", func() {
+		BeforeEach(func() {
+			u.Path = GinkgoT().TempDir()
+			u.RawQuery = "watch=false&poll_interval=10ms"
+		})
+
+		test.BackendTest(u, 4)
+	})
+
+	It("creates the directory", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "a", "b")
+
+		b, err := signaling.NewBackend(&signaling.BackendConfig{
+			URI: &url.URL{
+				Scheme: "file",
+				Path:   dir,
+			},
+		})
+		Expect(err).To(Succeed())
+		Expect(b.Close()).To(Succeed())
+
+		Expect(os.Stat(dir)).To(Satisfy(os.FileInfo.IsDir))
+	})
+
+	It("replays and expires envelopes", func() {
+		dir := GinkgoT().TempDir()
+
+		b, err := signaling.NewBackend(&signaling.BackendConfig{
+			URI: &url.URL{
+				Scheme:   "file",
+				Path:     dir,
+				RawQuery: "ttl=100ms&poll_interval=10ms",
+			},
+		})
+		Expect(err).To(Succeed())
+
+		defer b.Close()
+
+		sk1, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		sk2, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		// Publish before subscribing
+		err = b.Publish(context.Background(), &crypto.KeyPair{
+			Ours:   sk1,
+			Theirs: sk2.PublicKey(),
+		}, &signaling.Message{
+			Candidate: &epdiscproto.Candidate{
+				Port: 1234,
+			},
+		})
+		Expect(err).To(Succeed())
+
+		h := &messageHandler{
+			messages: make(chan *signaling.Message, 1),
+		}
+
+		_, err = b.Subscribe(context.Background(), &crypto.KeyPair{
+			Ours:   sk2,
+			Theirs: sk1.PublicKey(),
+		}, h)
+		Expect(err).To(Succeed())
+
+		var msg *signaling.Message
+		Eventually(h.messages).Should(Receive(&msg))
+		Expect(msg.Candidate.Port).To(BeNumerically("==", 1234))
+
+		Eventually(func() ([]string, error) {
+			return filepath.Glob(filepath.Join(dir, "*", "*.env"))
+		}).Should(BeEmpty())
+
+		Consistently(h.messages).ShouldNot(Receive())
+	})
+})
+
+type messageHandler struct {
+	messages chan *signaling.Message
+}
+
+func (h *messageHandler) OnSignalingMessage(_ *crypto.PublicKeyPair, msg *signaling.Message) {
+	h.messages <- msg
+}
+
+var _ = DescribeTable("rejects invalid intervals",
+	func(query string) {
+		_, err := file.ParseURL("file:///tmp/cunicu?" + query)
+		Expect(err).To(HaveOccurred())
+	},
+	Entry("zero poll interval", "poll_interval=0s"),
+	Entry("negative poll interval", "poll_interval=-1s"),
+	Entry("zero TTL", "ttl=0s"),
+)
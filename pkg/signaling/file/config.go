@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"fmt"
+
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+type BackendConfig struct {
+	signaling.BackendConfig
+
+	Options BackendOptions
+}
+
+func (c *BackendConfig) Parse(cfg *signaling.BackendConfig) (err error) {
+	c.BackendConfig = *cfg
+
+	c.Options, err = ParseURL(c.BackendConfig.URI.String())
+	if err != nil {
+		return fmt.Errorf("failed to parse file URL: %w", err)
+	}
+
+	return nil
+}
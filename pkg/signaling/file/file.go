@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package file implements a signaling backend using a shared directory
+package file
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultPollInterval = 5 * time.Second
+	DefaultTTL          = 10 * time.Minute
+
+	// envelopeSuffix is the file name extension of envelopes.
+	envelopeSuffix = ".env"
+)
+
+var (
+	errMissingPath         = errors.New("missing directory path")
+	errInvalidPollInterval = errors.New("poll interval must be positive")
+	errInvalidTTL          = errors.New("TTL must be positive")
+)
+
+type BackendOptions struct {
+	// Path of the shared directory
+	Path string
+
+	// Watch the directory for changes via inotify.
+	// Some network filesystems like NFS do not support inotify.
+	Watch bool
+
+	// Interval in which the directory is scanned for new envelopes
+	PollInterval time.Duration
+
+	// Duration after which envelopes are removed from the directory
+	TTL time.Duration
+}
+
+func ParseURL(urlStr string) (BackendOptions, error) {
+	o := BackendOptions{
+		Watch:        true,
+		PollInterval: DefaultPollInterval,
+		TTL:          DefaultTTL,
+	}
+
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return o, err
+	}
+
+	if u.Path == "" {
+		return o, errMissingPath
+	}
+
+	o.Path = u.Path
+
+	q := u.Query()
+
+	if q.Has("watch") {
+		if o.Watch, err = strconv.ParseBool(q.Get("watch")); err != nil {
+			return o, fmt.Errorf("failed to parse 'watch' option: %w", err)
+		}
+	}
+
+	if q.Has("poll_interval") {
+		if o.PollInterval, err = time.ParseDuration(q.Get("poll_interval")); err != nil {
+			return o, fmt.Errorf("failed to parse 'poll_interval' option: %w", err)
+		}
+
+		if o.PollInterval <= 0 {
+			return o, fmt.Errorf("%w: %s", errInvalidPollInterval, o.PollInterval)
+		}
+	}
+
+	if q.Has("ttl") {
+		if o.TTL, err = time.ParseDuration(q.Get("ttl")); err != nil {
+			return o, fmt.Errorf("failed to parse 'ttl' option: %w", err)
+		}
+
+		if o.TTL <= 0 {
+			return o, fmt.Errorf("%w: %s", errInvalidTTL, o.TTL)
+		}
+	}
+
+	return o, nil
+}
@@ -34,7 +34,8 @@ var _ = Describe("gRPC backend", func() {
 		Expect(err).To(Succeed(), "Failed to listen: %s", err)
 
 		// Start local dummy gRPC server
-		svr = grpc.NewSignalingServer()
+		svr, err = grpc.NewSignalingServer(&grpc.ServerConfig{})
+		Expect(err).To(Succeed())
 		go svr.Serve(l) //nolint:errcheck
 
 		u = url.URL{
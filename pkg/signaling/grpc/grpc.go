@@ -5,9 +5,11 @@
 package grpc
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"strconv"
@@ -17,6 +19,7 @@ import (
 	credsinsecure "google.golang.org/grpc/credentials/insecure"
 
 	"cunicu.li/cunicu/pkg/buildinfo"
+	netx "cunicu.li/cunicu/pkg/net"
 )
 
 var errInvalidServerHostname = errors.New("missing gRPC server url")
@@ -74,6 +77,9 @@ func ParseURL(urlStr string) (string, []grpc.DialOption, error) {
 	opts = append(opts,
 		grpc.WithTransportCredentials(creds),
 		grpc.WithUserAgent(buildinfo.UserAgent()),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return netx.Dialer().DialContext(ctx, "tcp", addr)
+		}),
 	)
 
 	if u.Host == "" {
@@ -9,8 +9,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"slices"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -25,6 +27,39 @@ import (
 	"cunicu.li/cunicu/pkg/signaling"
 )
 
+const (
+	DefaultRetainCount   = 16
+	DefaultRetainTTL     = 10 * time.Minute
+	DefaultRetainSenders = 256
+)
+
+// ServerConfig configures the gRPC signaling server.
+type ServerConfig struct {
+	// RetainCount is the number of envelopes which are retained per pair of sender and recipient
+	// and replayed to new subscribers. Zero disables the retention of envelopes.
+	RetainCount int
+
+	// RetainTTL is the duration after which a retained envelope expires.
+	// Zero keeps envelopes until they are replaced by newer ones.
+	RetainTTL time.Duration
+
+	// RetainSenders limits the number of senders per recipient whose envelopes are retained.
+	// The envelopes of the sender which published least recently are evicted first. Zero disables the limit.
+	RetainSenders int
+
+	// Peers is a list of gRPC URLs of other signaling servers to which published envelopes are forwarded.
+	Peers []url.URL
+
+	// PeerSRVs is a list of gRPC URLs whose host names are resolved via DNS SRV records
+	// to discover other signaling servers to which published envelopes are forwarded.
+	PeerSRVs []url.URL
+
+	// FederationSecret authenticates envelopes forwarded between federated servers.
+	// Envelopes are only considered forwarded, and hence not forwarded again, if they carry this secret.
+	// Without a secret, loops between servers are only broken by the suppression of duplicated envelopes.
+	FederationSecret string
+}
+
 type Server struct {
 	signalingproto.UnimplementedSignalingServer
 
@@ -32,24 +67,39 @@ type Server struct {
 
 	*grpc.Server
 
+	federation *federation
+	duplicates duplicateFilter
+
 	logger *log.Logger
 }
 
-func NewSignalingServer(opts ...grpc.ServerOption) *Server {
+func NewSignalingServer(cfg *ServerConfig, opts ...grpc.ServerOption) (*Server, error) {
+	var err error
+
 	logger := log.Global.Named("grpc.server")
 
 	s := &Server{
 		topicRegistry: topicRegistry{
 			topics: map[crypto.Key]*Topic{},
+			config: cfg,
+		},
+		duplicates: duplicateFilter{
+			seen: map[string]time.Time{},
 		},
 		Server: grpc.NewServer(opts...),
 		logger: logger,
 	}
 
+	if len(cfg.Peers) > 0 || len(cfg.PeerSRVs) > 0 {
+		if s.federation, err = newFederation(cfg, logger.Named("federation")); err != nil {
+			return nil, fmt.Errorf("failed to setup federation: %w", err)
+		}
+	}
+
 	reflection.Register(s)
 	signalingproto.RegisterSignalingServer(s, s)
 
-	return s
+	return s, nil
 }
 
 func NewServer(opts ...grpc.ServerOption) (*grpc.Server, error) {
@@ -85,7 +135,7 @@ func (s *Server) Subscribe(params *signalingproto.SubscribeParams, stream signal
 
 	top := s.getTopic(&pk)
 
-	ch := top.Subscribe()
+	ch, retained := top.Subscribe()
 	defer top.Unsubscribe(ch)
 
 	// We send an empty envelope to signal the subscriber that the subscription
@@ -96,7 +146,15 @@ func (s *Server) Subscribe(params *signalingproto.SubscribeParams, stream signal
 	}
 
 	s.logger.Debug("Subscription stream opened",
-		zap.Any("recipient", pk))
+		zap.Any("recipient", pk),
+		zap.Int("retained", len(retained)))
+
+	// Replay envelopes which have been published before the subscriber joined
+	for _, env := range retained {
+		if err := stream.Send(env); err != nil {
+			return fmt.Errorf("failed to replay retained envelope: %w", err)
+		}
+	}
 
 out:
 	for {
@@ -127,7 +185,7 @@ out:
 	return nil
 }
 
-func (s *Server) Publish(_ context.Context, env *signaling.Envelope) (*proto.Empty, error) {
+func (s *Server) Publish(ctx context.Context, env *signaling.Envelope) (*proto.Empty, error) {
 	var (
 		err                   error
 		pkRecipient, pkSender crypto.Key
@@ -141,6 +199,14 @@ func (s *Server) Publish(_ context.Context, env *signaling.Envelope) (*proto.Emp
 		return &proto.Empty{}, fmt.Errorf("invalid sender key: %w", err)
 	}
 
+	if !s.duplicates.Add(env) {
+		s.logger.Debug("Ignoring duplicated envelope",
+			zap.Any("recipient", pkRecipient),
+			zap.Any("sender", pkSender))
+
+		return &proto.Empty{}, nil
+	}
+
 	t := s.getTopic(&pkRecipient)
 
 	s.logger.Debug("Start publishing envelope",
@@ -149,6 +215,10 @@ func (s *Server) Publish(_ context.Context, env *signaling.Envelope) (*proto.Emp
 
 	t.Publish(env)
 
+	if s.federation != nil && !s.federation.isForwarded(ctx) {
+		s.federation.Forward(env)
+	}
+
 	s.logger.Debug("Published envelope",
 		zap.Any("recipient", pkRecipient),
 		zap.Any("sender", pkSender))
@@ -156,7 +226,19 @@ func (s *Server) Publish(_ context.Context, env *signaling.Envelope) (*proto.Emp
 	return &proto.Empty{}, nil
 }
 
+// Topic returns the topic of envelopes addressed to the recipient pk.
+// It allows other transports to share the topics of the gRPC signaling server.
+func (s *Server) Topic(pk *crypto.Key) *Topic {
+	return s.getTopic(pk)
+}
+
 func (s *Server) Close() error {
+	if s.federation != nil {
+		if err := s.federation.Close(); err != nil {
+			return fmt.Errorf("failed to close federation: %w", err)
+		}
+	}
+
 	if err := s.topicRegistry.Close(); err != nil {
 		return err
 	}
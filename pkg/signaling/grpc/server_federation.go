@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"cunicu.li/cunicu/pkg/log"
+	signalingproto "cunicu.li/cunicu/pkg/proto/signaling"
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+const (
+	// forwardedMetadataKey marks envelopes which have been forwarded by another signaling server.
+	// Its value is the federation secret shared by all servers of the federation.
+	// Authenticated envelopes are not forwarded again to avoid loops between servers.
+	forwardedMetadataKey = "cunicu-forwarded"
+
+	federationQueueSize   = 1000
+	federationSRVInterval = 1 * time.Minute
+
+	// duplicateTTL is the duration for which we remember envelopes to suppress duplicates.
+	duplicateTTL = 1 * time.Minute
+)
+
+// federationPeer is another signaling server to which we forward published envelopes.
+type federationPeer struct {
+	target string
+	secret string
+
+	conn   *grpc.ClientConn
+	client signalingproto.SignalingClient
+	queue  chan *signaling.Envelope
+
+	logger *log.Logger
+}
+
+func newFederationPeer(u *url.URL, secret string, logger *log.Logger) (*federationPeer, error) {
+	target, opts, err := ParseURL(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	p := &federationPeer{
+		target: target,
+		secret: secret,
+		queue:  make(chan *signaling.Envelope, federationQueueSize),
+		logger: logger.With(zap.String("peer", target)),
+	}
+
+	if p.conn, err = grpc.NewClient(target, opts...); err != nil {
+		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
+	}
+
+	p.client = signalingproto.NewSignalingClient(p.conn)
+
+	go p.run()
+
+	return p, nil
+}
+
+func (p *federationPeer) run() {
+	ctx := context.Background()
+	if p.secret != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, forwardedMetadataKey, p.secret)
+	}
+
+	for env := range p.queue {
+		if _, err := p.client.Publish(ctx, env); err != nil {
+			p.logger.Warn("Failed to forward envelope", zap.Error(err))
+		}
+	}
+}
+
+func (p *federationPeer) Forward(env *signaling.Envelope) {
+	select {
+	case p.queue <- env:
+	default:
+		p.logger.Warn("Dropping envelope as forwarding queue is full")
+	}
+}
+
+func (p *federationPeer) Close() error {
+	close(p.queue)
+
+	return p.conn.Close()
+}
+
+// federation forwards envelopes published at this server to other servers.
+type federation struct {
+	// static peers configured by URL
+	static []*federationPeer
+
+	// dynamic peers discovered via DNS SRV records, keyed by the URL of the SRV record
+	dynamic map[string]map[string]*federationPeer
+
+	secret string
+	closed bool
+
+	mu   sync.RWMutex
+	stop chan struct{}
+
+	logger *log.Logger
+}
+
+func newFederation(cfg *ServerConfig, logger *log.Logger) (*federation, error) {
+	f := &federation{
+		dynamic: map[string]map[string]*federationPeer{},
+		secret:  cfg.FederationSecret,
+		stop:    make(chan struct{}),
+		logger:  logger,
+	}
+
+	for _, u := range cfg.Peers {
+		p, err := newFederationPeer(&u, f.secret, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		f.static = append(f.static, p)
+	}
+
+	if len(cfg.PeerSRVs) > 0 {
+		go f.discover(cfg.PeerSRVs)
+	}
+
+	return f, nil
+}
+
+// discover periodically resolves SRV records to discover other signaling servers.
+func (f *federation) discover(srvs []url.URL) {
+	ticker := time.NewTicker(federationSRVInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, u := range srvs {
+			if err := f.resolve(&u); err != nil {
+				f.logger.Error("Failed to discover signaling servers", zap.Error(err), zap.String("srv", u.Host))
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-f.stop:
+			return
+		}
+	}
+}
+
+func (f *federation) resolve(srv *url.URL) error {
+	_, addrs, err := net.LookupSRV("", "", srv.Hostname())
+	if err != nil {
+		return fmt.Errorf("failed to lookup SRV record: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Do not connect to new peers once the federation has been closed during the lookup
+	if f.closed {
+		return nil
+	}
+
+	old := f.dynamic[srv.String()]
+	peers := map[string]*federationPeer{}
+
+	for _, addr := range addrs {
+		u := *srv
+		u.Host = net.JoinHostPort(addr.Target, strconv.Itoa(int(addr.Port)))
+
+		if p, ok := old[u.Host]; ok {
+			peers[u.Host] = p
+			delete(old, u.Host)
+
+			continue
+		}
+
+		p, err := newFederationPeer(&u, f.secret, f.logger)
+		if err != nil {
+			f.logger.Error("Failed to add signaling server", zap.Error(err), zap.String("peer", u.Host))
+
+			continue
+		}
+
+		f.logger.Info("Discovered signaling server", zap.String("peer", u.Host))
+
+		peers[u.Host] = p
+	}
+
+	// Close peers which are gone
+	for host, p := range old {
+		f.logger.Info("Removing signaling server", zap.String("peer", host))
+
+		if err := p.Close(); err != nil {
+			f.logger.Error("Failed to close connection", zap.Error(err))
+		}
+	}
+
+	f.dynamic[srv.String()] = peers
+
+	return nil
+}
+
+func (f *federation) Forward(env *signaling.Envelope) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for _, p := range f.static {
+		p.Forward(env)
+	}
+
+	for _, peers := range f.dynamic {
+		for _, p := range peers {
+			p.Forward(env)
+		}
+	}
+}
+
+func (f *federation) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return nil
+	}
+
+	f.closed = true
+	close(f.stop)
+
+	for _, p := range f.static {
+		if err := p.Close(); err != nil {
+			return err
+		}
+	}
+
+	for _, peers := range f.dynamic {
+		for _, p := range peers {
+			if err := p.Close(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isForwarded checks if an envelope has been forwarded by another signaling server
+// which authenticated itself with the federation secret.
+func (f *federation) isForwarded(ctx context.Context) bool {
+	if f.secret == "" {
+		return false
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, v := range md.Get(forwardedMetadataKey) {
+		if subtle.ConstantTimeCompare([]byte(v), []byte(f.secret)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// duplicateFilter remembers recently seen envelopes to suppress duplicates
+// which can arrive via multiple federated servers.
+type duplicateFilter struct {
+	seen      map[string]time.Time
+	lastPurge time.Time
+	mu        sync.Mutex
+}
+
+// Add returns false if the envelope has already been seen recently.
+func (d *duplicateFilter) Add(env *signaling.Envelope) bool {
+	if env.Contents == nil || len(env.Contents.Nonce) == 0 {
+		return true
+	}
+
+	// The nonce is randomly generated for each envelope
+	id := string(env.Sender) + string(env.Recipient) + string(env.Contents.Nonce)
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if t, ok := d.seen[id]; ok && now.Sub(t) < duplicateTTL {
+		return false
+	}
+
+	d.seen[id] = now
+
+	// Purge expired entries
+	if now.Sub(d.lastPurge) >= duplicateTTL {
+		for id, t := range d.seen {
+			if now.Sub(t) >= duplicateTTL {
+				delete(d.seen, id)
+			}
+		}
+
+		d.lastPurge = now
+	}
+
+	return true
+}
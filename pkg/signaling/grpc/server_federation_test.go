@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"google.golang.org/grpc/metadata"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	epdiscproto "cunicu.li/cunicu/pkg/proto/feature/epdisc"
+	"cunicu.li/cunicu/pkg/signaling"
+	"cunicu.li/cunicu/pkg/signaling/grpc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type messageHandler struct {
+	messages chan *signaling.Message
+}
+
+func (h *messageHandler) OnSignalingMessage(_ *crypto.PublicKeyPair, msg *signaling.Message) {
+	h.messages <- msg
+}
+
+var _ = Describe("federated servers", func() {
+	var (
+		svrs [2]*grpc.Server
+		urls [2]url.URL
+		bes  [2]signaling.Backend
+	)
+
+	BeforeEach(func() {
+		var ls [2]*net.TCPListener
+
+		for i := range ls {
+			var err error
+
+			ls[i], err = net.ListenTCP("tcp", &net.TCPAddr{
+				IP: net.IPv6loopback,
+			})
+			Expect(err).To(Succeed())
+
+			urls[i] = url.URL{
+				Scheme:   "grpc",
+				Host:     ls[i].Addr().String(),
+				RawQuery: "insecure=true",
+			}
+		}
+
+		for i := range svrs {
+			var err error
+
+			svrs[i], err = grpc.NewSignalingServer(&grpc.ServerConfig{
+				Peers:            []url.URL{urls[1-i]},
+				FederationSecret: "s3cr3t",
+			})
+			Expect(err).To(Succeed())
+
+			go svrs[i].Serve(ls[i]) //nolint:errcheck
+
+			bes[i], err = signaling.NewBackend(&signaling.BackendConfig{
+				URI: &urls[i],
+			})
+			Expect(err).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		for i := range svrs {
+			Expect(bes[i].Close()).To(Succeed())
+			Expect(svrs[i].Close()).To(Succeed())
+		}
+	})
+
+	It("forwards envelopes between servers", func() {
+		sk1, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		sk2, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		h := &messageHandler{
+			messages: make(chan *signaling.Message, 10),
+		}
+
+		_, err = bes[1].Subscribe(context.Background(), &crypto.KeyPair{
+			Ours:   sk2,
+			Theirs: sk1.PublicKey(),
+		}, h)
+		Expect(err).To(Succeed())
+
+		kp := &crypto.KeyPair{
+			Ours:   sk1,
+			Theirs: sk2.PublicKey(),
+		}
+
+		msg := &signaling.Message{
+			Candidate: &epdiscproto.Candidate{
+				Port: 1234,
+			},
+		}
+
+		env, err := msg.Encrypt(kp)
+		Expect(err).To(Succeed())
+
+		// Publish to the first server
+		_, err = svrs[0].Publish(context.Background(), env)
+		Expect(err).To(Succeed())
+
+		var recvMsg *signaling.Message
+		Eventually(h.messages).Should(Receive(&recvMsg))
+		Expect(recvMsg.Candidate.Port).To(BeNumerically("==", 1234))
+
+		// Publishing the same envelope to the second server is suppressed
+		_, err = svrs[1].Publish(context.Background(), env)
+		Expect(err).To(Succeed())
+
+		Consistently(h.messages).ShouldNot(Receive())
+	})
+
+	Context("forwarded envelopes", func() {
+		var (
+			h  *messageHandler
+			kp *crypto.KeyPair
+		)
+
+		BeforeEach(func() {
+			sk1, err := crypto.GeneratePrivateKey()
+			Expect(err).To(Succeed())
+
+			sk2, err := crypto.GeneratePrivateKey()
+			Expect(err).To(Succeed())
+
+			h = &messageHandler{
+				messages: make(chan *signaling.Message, 10),
+			}
+
+			_, err = bes[1].Subscribe(context.Background(), &crypto.KeyPair{
+				Ours:   sk2,
+				Theirs: sk1.PublicKey(),
+			}, h)
+			Expect(err).To(Succeed())
+
+			kp = &crypto.KeyPair{
+				Ours:   sk1,
+				Theirs: sk2.PublicKey(),
+			}
+		})
+
+		publish := func(secret string) {
+			msg := &signaling.Message{
+				Candidate: &epdiscproto.Candidate{
+					Port: 1234,
+				},
+			}
+
+			env, err := msg.Encrypt(kp)
+			Expect(err).To(Succeed())
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("cunicu-forwarded", secret))
+
+			_, err = svrs[0].Publish(ctx, env)
+			Expect(err).To(Succeed())
+		}
+
+		It("are not forwarded again if authenticated by the federation secret", func() {
+			publish("s3cr3t")
+
+			Consistently(h.messages).ShouldNot(Receive())
+		})
+
+		It("are forwarded if not authenticated by the federation secret", func() {
+			publish("forged")
+
+			Eventually(h.messages).Should(Receive())
+		})
+	})
+})
@@ -9,6 +9,8 @@ import (
 	"crypto/sha1" //nolint:gosec
 	"encoding/base64"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
@@ -90,16 +92,44 @@ func (s *RelayInfo) GetCredentials(username string) (string, string, time.Time)
 		exp := time.Now().Add(s.TTL)
 		user := fmt.Sprintf("%d:%s", exp.Unix(), username)
 
-		digest := hmac.New(sha1.New, []byte(s.Secret))
-		digest.Write([]byte(user))
+		return user, s.password(user), exp
+	}
+
+	return "", "", time.Time{}
+}
 
-		passRaw := digest.Sum(nil)
-		pass := base64.StdEncoding.EncodeToString(passRaw)
+// VerifyCredentials checks if the username has been issued by GetCredentials and has not expired yet.
+// It returns the corresponding password and the username without its expiry timestamp.
+func (s *RelayInfo) VerifyCredentials(user string, now time.Time) (string, string, bool) {
+	if s.Username != "" && s.Password != "" {
+		return s.Password, s.Username, user == s.Username
+	} else if s.Secret != "" {
+		expStr, username, ok := strings.Cut(user, ":")
+		if !ok {
+			return "", "", false
+		}
 
-		return user, pass, exp
+		exp, err := strconv.ParseInt(expStr, 10, 64)
+		if err != nil || now.Unix() > exp {
+			return "", "", false
+		}
+
+		if s.Username != "" && username != s.Username {
+			return "", "", false
+		}
+
+		return s.password(user), username, true
 	}
 
-	return "", "", time.Time{}
+	return "", "", false
+}
+
+// password derives the password for a time-limited username as proposed by the TURN REST API.
+func (s *RelayInfo) password(user string) string {
+	digest := hmac.New(sha1.New, []byte(s.Secret))
+	digest.Write([]byte(user))
+
+	return base64.StdEncoding.EncodeToString(digest.Sum(nil))
 }
 
 type RelayAPIServer struct {
@@ -39,4 +39,60 @@ var _ = Context("relay server", func() {
 			TTL: 2 * time.Hour,
 		}),
 	)
+
+	Describe("credentials", func() {
+		It("accepts credentials issued with a secret", func() {
+			relay, err := grpc.NewRelayInfo("turn:turn.cunicu.li?secret=mysecret")
+			Expect(err).To(Succeed())
+
+			user, pass, exp := relay.GetCredentials("peer1")
+			Expect(user).To(HaveSuffix(":peer1"))
+
+			verifiedPass, username, ok := relay.VerifyCredentials(user, time.Now())
+			Expect(ok).To(BeTrue())
+			Expect(verifiedPass).To(Equal(pass))
+			Expect(username).To(Equal("peer1"))
+
+			_, _, ok = relay.VerifyCredentials(user, exp.Add(time.Second))
+			Expect(ok).To(BeFalse(), "Accepted expired credentials")
+		})
+
+		It("rejects credentials issued with another secret", func() {
+			relay1, err := grpc.NewRelayInfo("turn:turn.cunicu.li?secret=mysecret")
+			Expect(err).To(Succeed())
+
+			relay2, err := grpc.NewRelayInfo("turn:turn.cunicu.li?secret=othersecret")
+			Expect(err).To(Succeed())
+
+			user, pass, _ := relay1.GetCredentials("peer1")
+
+			verifiedPass, _, ok := relay2.VerifyCredentials(user, time.Now())
+			Expect(ok).To(BeTrue())
+			Expect(verifiedPass).NotTo(Equal(pass))
+		})
+
+		It("rejects malformed usernames", func() {
+			relay, err := grpc.NewRelayInfo("turn:turn.cunicu.li?secret=mysecret")
+			Expect(err).To(Succeed())
+
+			_, _, ok := relay.VerifyCredentials("peer1", time.Now())
+			Expect(ok).To(BeFalse())
+
+			_, _, ok = relay.VerifyCredentials("tomorrow:peer1", time.Now())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("accepts static credentials", func() {
+			relay, err := grpc.NewRelayInfo("turn:user1:pass1@turn.cunicu.li")
+			Expect(err).To(Succeed())
+
+			pass, username, ok := relay.VerifyCredentials("user1", time.Now())
+			Expect(ok).To(BeTrue())
+			Expect(pass).To(Equal("pass1"))
+			Expect(username).To(Equal("user1"))
+
+			_, _, ok = relay.VerifyCredentials("user2", time.Now())
+			Expect(ok).To(BeFalse())
+		})
+	})
 })
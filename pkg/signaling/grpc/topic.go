@@ -4,27 +4,64 @@
 package grpc
 
 import (
+	"sync"
+	"time"
+
+	"cunicu.li/cunicu/pkg/crypto"
 	"cunicu.li/cunicu/pkg/signaling"
 	"cunicu.li/cunicu/pkg/types"
 )
 
+type retainedEnvelope struct {
+	*signaling.Envelope
+
+	expires time.Time
+	seq     uint64
+}
+
 type Topic struct {
 	subs *types.FanOut[*signaling.Envelope]
+
+	// retained holds the most recent envelopes of each sender
+	retained      map[crypto.Key][]retainedEnvelope
+	retainCount   int
+	retainTTL     time.Duration
+	retainSenders int
+	seq           uint64
+
+	mu sync.Mutex
 }
 
-func NewTopic() *Topic {
+func NewTopic(cfg *ServerConfig) *Topic {
 	return &Topic{
 		// TODO: Make smaller again
 		subs: types.NewFanOut[*signaling.Envelope](10000),
+
+		retained:      map[crypto.Key][]retainedEnvelope{},
+		retainCount:   cfg.RetainCount,
+		retainTTL:     cfg.RetainTTL,
+		retainSenders: cfg.RetainSenders,
 	}
 }
 
 func (t *Topic) Publish(env *signaling.Envelope) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.retainCount > 0 {
+		t.retain(env)
+	}
+
 	t.subs.Send(env)
 }
 
-func (t *Topic) Subscribe() chan *signaling.Envelope {
-	return t.subs.Add()
+// Subscribe returns a channel for newly published envelopes as well as a list of retained envelopes
+// which have been published before the subscription has been created.
+func (t *Topic) Subscribe() (chan *signaling.Envelope, []*signaling.Envelope) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.subs.Add(), t.replay()
 }
 
 func (t *Topic) Unsubscribe(ch chan *signaling.Envelope) {
@@ -34,3 +71,82 @@ func (t *Topic) Unsubscribe(ch chan *signaling.Envelope) {
 func (t *Topic) Close() {
 	t.subs.Close()
 }
+
+func (t *Topic) retain(env *signaling.Envelope) {
+	pk, err := crypto.ParseKeyBytes(env.Sender)
+	if err != nil {
+		return
+	}
+
+	t.expire()
+
+	if _, ok := t.retained[pk]; !ok && t.retainSenders > 0 && len(t.retained) >= t.retainSenders {
+		t.evict()
+	}
+
+	t.seq++
+
+	envs := append(t.retained[pk], retainedEnvelope{
+		Envelope: env,
+		expires:  time.Now().Add(t.retainTTL),
+		seq:      t.seq,
+	})
+
+	if len(envs) > t.retainCount {
+		envs = envs[len(envs)-t.retainCount:]
+	}
+
+	t.retained[pk] = envs
+}
+
+func (t *Topic) replay() []*signaling.Envelope {
+	t.expire()
+
+	envs := []*signaling.Envelope{}
+
+	for _, renvs := range t.retained {
+		for _, renv := range renvs {
+			envs = append(envs, renv.Envelope)
+		}
+	}
+
+	return envs
+}
+
+// expire removes retained envelopes whose TTL has been exceeded.
+func (t *Topic) expire() {
+	if t.retainTTL <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	for pk, renvs := range t.retained {
+		i := 0
+		for i < len(renvs) && renvs[i].expires.Before(now) {
+			i++
+		}
+
+		if i == len(renvs) {
+			delete(t.retained, pk)
+		} else {
+			t.retained[pk] = renvs[i:]
+		}
+	}
+}
+
+// evict removes the retained envelopes of the sender which published least recently.
+func (t *Topic) evict() {
+	var (
+		oldest    crypto.Key
+		oldestSeq uint64
+	)
+
+	for pk, renvs := range t.retained {
+		if seq := renvs[len(renvs)-1].seq; oldestSeq == 0 || seq < oldestSeq {
+			oldest, oldestSeq = pk, seq
+		}
+	}
+
+	delete(t.retained, oldest)
+}
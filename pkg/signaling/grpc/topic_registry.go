@@ -12,6 +12,8 @@ import (
 type topicRegistry struct {
 	topics     map[crypto.Key]*Topic
 	topicsLock sync.RWMutex
+
+	config *ServerConfig
 }
 
 func (r *topicRegistry) getTopic(pk *crypto.Key) *Topic {
@@ -23,11 +25,16 @@ func (r *topicRegistry) getTopic(pk *crypto.Key) *Topic {
 		return top
 	}
 
-	top = NewTopic()
-
 	r.topicsLock.Lock()
+	defer r.topicsLock.Unlock()
+
+	// Check again as another goroutine might have created the topic in the meantime
+	if top, ok := r.topics[*pk]; ok {
+		return top
+	}
+
+	top = NewTopic(r.config)
 	r.topics[*pk] = top
-	r.topicsLock.Unlock()
 
 	return top
 }
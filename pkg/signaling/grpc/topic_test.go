@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc_test
+
+import (
+	"time"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/signaling"
+	"cunicu.li/cunicu/pkg/signaling/grpc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("topic", func() {
+	var sender1, sender2 crypto.Key
+
+	newEnvelope := func(sender crypto.Key) *signaling.Envelope {
+		return &signaling.Envelope{
+			Sender: sender.Bytes(),
+		}
+	}
+
+	BeforeEach(func() {
+		sk1, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		sk2, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		sender1 = sk1.PublicKey()
+		sender2 = sk2.PublicKey()
+	})
+
+	It("does not retain envelopes by default", func() {
+		t := grpc.NewTopic(&grpc.ServerConfig{})
+
+		t.Publish(newEnvelope(sender1))
+
+		_, retained := t.Subscribe()
+		Expect(retained).To(BeEmpty())
+	})
+
+	It("replays the last envelopes of each sender", func() {
+		t := grpc.NewTopic(&grpc.ServerConfig{
+			RetainCount: 2,
+		})
+
+		envs := []*signaling.Envelope{
+			newEnvelope(sender1),
+			newEnvelope(sender1),
+			newEnvelope(sender1),
+			newEnvelope(sender2),
+		}
+
+		for _, env := range envs {
+			t.Publish(env)
+		}
+
+		ch, retained := t.Subscribe()
+		Expect(retained).To(ConsistOf(envs[1], envs[2], envs[3]))
+
+		env := newEnvelope(sender2)
+		t.Publish(env)
+
+		Eventually(ch).Should(Receive(Equal(env)))
+	})
+
+	It("expires retained envelopes", func() {
+		t := grpc.NewTopic(&grpc.ServerConfig{
+			RetainCount: 1,
+			RetainTTL:   10 * time.Millisecond,
+		})
+
+		t.Publish(newEnvelope(sender1))
+
+		time.Sleep(20 * time.Millisecond)
+
+		_, retained := t.Subscribe()
+		Expect(retained).To(BeEmpty())
+	})
+
+	It("limits the number of retained senders", func() {
+		t := grpc.NewTopic(&grpc.ServerConfig{
+			RetainCount:   1,
+			RetainSenders: 2,
+		})
+
+		sk3, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		sender3 := sk3.PublicKey()
+
+		env1 := newEnvelope(sender1)
+		env2 := newEnvelope(sender2)
+		env3 := newEnvelope(sender3)
+
+		t.Publish(env1)
+		t.Publish(env2)
+
+		// Further envelopes of a retained sender do not evict others
+		env1 = newEnvelope(sender1)
+		t.Publish(env1)
+
+		_, retained := t.Subscribe()
+		Expect(retained).To(ConsistOf(env1, env2))
+
+		// The sender which published least recently is evicted first
+		t.Publish(env3)
+
+		_, retained = t.Subscribe()
+		Expect(retained).To(ConsistOf(env1, env3))
+	})
+})
@@ -59,6 +59,14 @@ func (b *Backend) Publish(_ context.Context, kp *crypto.KeyPair, msg *signaling.
 	return subs.NewMessage(env)
 }
 
+func (b *Backend) AddEnvelopeHandler(h signaling.EnvelopeHandler) {
+	subs.AddEnvelopeHandler(h)
+}
+
+func (b *Backend) RemoveEnvelopeHandler(h signaling.EnvelopeHandler) {
+	subs.RemoveEnvelopeHandler(h)
+}
+
 func (b *Backend) Close() error {
 	return nil
 }
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package signaling
+
+import (
+	"encoding/base64"
+
+	"cunicu.li/cunicu/pkg/crypto"
+)
+
+// EncodeKey encodes a public key so that backends can use it in topic names, paths and file names.
+// The standard Base64 alphabet can not be used as it contains the '/' separator and the '+' wildcard.
+func EncodeKey(k crypto.Key) string {
+	return base64.RawURLEncoding.EncodeToString(k.Bytes())
+}
+
+// DecodeKey decodes a public key encoded by EncodeKey.
+func DecodeKey(s string) (crypto.Key, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return crypto.Key{}, err
+	}
+
+	return crypto.ParseKeyBytes(buf)
+}
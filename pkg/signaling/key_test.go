@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package signaling_test
+
+import (
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/signaling"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("key encoding", func() {
+	It("can encode and decode keys", func() {
+		sk, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		pk := sk.PublicKey()
+		enc := signaling.EncodeKey(pk)
+		Expect(enc).NotTo(ContainSubstring("/"))
+		Expect(enc).NotTo(ContainSubstring("+"))
+
+		dec, err := signaling.DecodeKey(enc)
+		Expect(err).To(Succeed())
+		Expect(dec).To(Equal(pk))
+	})
+
+	It("fails to decode invalid keys", func() {
+		_, err := signaling.DecodeKey("not/a+key")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/log"
+	signalingproto "cunicu.li/cunicu/pkg/proto/signaling"
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+const disconnectTimeout = 250 // in milliseconds
+
+func init() { //nolint:gochecknoinits
+	signaling.Backends["mqtt"] = &signaling.BackendPlugin{
+		New:         NewBackend,
+		Description: "MQTT",
+	}
+
+	signaling.Backends["mqtts"] = &signaling.BackendPlugin{
+		New:         NewBackend,
+		Description: "MQTT over TLS",
+	}
+}
+
+type Backend struct {
+	signaling.SubscriptionsRegistry
+
+	client pahomqtt.Client
+	config BackendConfig
+
+	// topics contains the public keys of all recipients for which we have subscribed
+	topics   map[crypto.Key]any
+	topicsMu sync.Mutex
+
+	logger *log.Logger
+}
+
+func NewBackend(cfg *signaling.BackendConfig, logger *log.Logger) (signaling.Backend, error) {
+	b := &Backend{
+		SubscriptionsRegistry: signaling.NewSubscriptionsRegistry(),
+		topics:                map[crypto.Key]any{},
+		logger:                logger,
+	}
+
+	if err := b.config.Parse(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse backend configuration: %w", err)
+	}
+
+	opts := b.config.ClientOptions
+	opts.SetCleanSession(true)
+	opts.SetAutoReconnect(true)
+	opts.SetConnectRetry(true)
+	opts.SetOrderMatters(false)
+	opts.SetOnConnectHandler(b.onConnect)
+	opts.SetConnectionLostHandler(b.onConnectionLost)
+
+	b.client = pahomqtt.NewClient(opts)
+
+	// With ConnectRetry enabled, the client keeps trying to connect in the background.
+	// Subscriptions are (re-)established by onConnect() once the connection is up.
+	b.client.Connect()
+
+	return b, nil
+}
+
+func (b *Backend) Type() signalingproto.BackendType {
+	return signalingproto.BackendType_MQTT
+}
+
+func (b *Backend) Subscribe(ctx context.Context, kp *crypto.KeyPair, h signaling.MessageHandler) (bool, error) {
+	first, err := b.SubscriptionsRegistry.Subscribe(kp, h)
+	if err != nil {
+		return false, err
+	} else if first {
+		pk := kp.Ours.PublicKey()
+
+		b.topicsMu.Lock()
+		b.topics[pk] = nil
+		b.topicsMu.Unlock()
+
+		// Otherwise, the subscription will be created by onConnect()
+		if b.client.IsConnectionOpen() {
+			return first, b.subscribeFromBroker(ctx, pk)
+		}
+	}
+
+	return first, nil
+}
+
+// Unsubscribe from messages send by a specific peer.
+func (b *Backend) Unsubscribe(ctx context.Context, kp *crypto.KeyPair, h signaling.MessageHandler) (bool, error) {
+	last, err := b.SubscriptionsRegistry.Unsubscribe(kp, h)
+	if err != nil {
+		return false, err
+	} else if last {
+		pk := kp.Ours.PublicKey()
+
+		b.topicsMu.Lock()
+		delete(b.topics, pk)
+		b.topicsMu.Unlock()
+
+		if b.client.IsConnectionOpen() {
+			return last, b.unsubscribeFromBroker(ctx, pk)
+		}
+	}
+
+	return last, nil
+}
+
+func (b *Backend) Publish(ctx context.Context, kp *crypto.KeyPair, msg *signaling.Message) error {
+	env, err := msg.Encrypt(kp)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt message: %w", err)
+	}
+
+	payload, err := proto.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to marshal protobuf: %w", err)
+	}
+
+	topic := b.config.Options.topic(kp.Theirs, kp.Ours.PublicKey())
+
+	tok := b.client.Publish(topic, b.config.Options.QoS, b.config.Options.Retain, payload)
+	if err := wait(ctx, tok); err != nil {
+		if errors.Is(err, pahomqtt.ErrNotConnected) {
+			return signaling.ErrClosed
+		}
+
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Backend) Close() error {
+	b.client.Disconnect(disconnectTimeout)
+
+	return nil
+}
+
+func (b *Backend) subscribeFromBroker(ctx context.Context, pk crypto.Key) error {
+	topic := b.config.Options.subscriptionTopic(pk)
+
+	if err := wait(ctx, b.client.Subscribe(topic, b.config.Options.QoS, b.onMessage)); err != nil {
+		return fmt.Errorf("failed to subscribe to topic: %w", err)
+	}
+
+	b.logger.Debug("Created new subscription", zap.Any("pk", pk), zap.String("topic", topic))
+
+	return nil
+}
+
+func (b *Backend) unsubscribeFromBroker(ctx context.Context, pk crypto.Key) error {
+	topic := b.config.Options.subscriptionTopic(pk)
+
+	if err := wait(ctx, b.client.Unsubscribe(topic)); err != nil {
+		return fmt.Errorf("failed to unsubscribe from topic: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Backend) onConnect(_ pahomqtt.Client) {
+	b.logger.Debug("Connected to MQTT broker")
+
+	// We are using a clean session and hence need to restore our subscriptions after each reconnect
+	b.topicsMu.Lock()
+	pks := make([]crypto.Key, 0, len(b.topics))
+	for pk := range b.topics {
+		pks = append(pks, pk)
+	}
+	b.topicsMu.Unlock()
+
+	for _, pk := range pks {
+		if err := b.subscribeFromBroker(context.Background(), pk); err != nil {
+			b.logger.Error("Failed to restore subscription", zap.Error(err))
+		}
+	}
+
+	// Report readiness after each reconnect so that a failed backend is used again right away
+	for _, h := range b.config.OnReady {
+		h.OnSignalingBackendReady(b)
+	}
+}
+
+func (b *Backend) onConnectionLost(_ pahomqtt.Client, err error) {
+	b.logger.Warn("Lost connection to MQTT broker. Reconnecting..", zap.Error(err))
+}
+
+func (b *Backend) onMessage(_ pahomqtt.Client, m pahomqtt.Message) {
+	env := &signalingproto.Envelope{}
+	if err := proto.Unmarshal(m.Payload(), env); err != nil {
+		b.logger.Error("Failed to unmarshal envelope", zap.Error(err), zap.String("topic", m.Topic()))
+
+		return
+	}
+
+	if err := b.SubscriptionsRegistry.NewMessage(env); err != nil {
+		if !errors.Is(err, signaling.ErrNotSubscribed) {
+			b.logger.Error("Failed to decrypt message", zap.Error(err))
+		}
+	}
+}
+
+// wait blocks until the token has completed or the context has been canceled.
+func wait(ctx context.Context, tok pahomqtt.Token) error {
+	select {
+	case <-tok.Done():
+		return tok.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
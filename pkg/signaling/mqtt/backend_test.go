@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt_test
+
+import (
+	"net/url"
+	"testing"
+
+	"cunicu.li/cunicu/pkg/signaling/mqtt"
+	"cunicu.li/cunicu/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSuite(t *testing.T) {
+	test.SetupLogging()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MQTT Backend Suite")
+}
+
+var _ = Describe("MQTT backend", func() {
+	var b *broker
+
+	u := &url.URL{
+		Scheme: "mqtt",
+	}
+
+	BeforeEach(func() {
+		var err error
+
+		b, err = newBroker()
+		Expect(err).To(Succeed())
+
+		u.Host = b.Addr()
+	})
+
+	AfterEach(func() {
+		Expect(b.Close()).To(Succeed())
+	})
+
+	test.BackendTest(u, 10)
+})
+
+var _ = DescribeTable("parses URLs",
+	func(urlStr string, broker string, opts mqtt.BackendOptions) {
+		copts, o, err := mqtt.ParseURL(urlStr)
+		Expect(err).To(Succeed())
+		Expect(copts.Servers).To(HaveLen(1))
+		Expect(copts.Servers[0].String()).To(Equal(broker))
+		Expect(o).To(Equal(opts))
+	},
+	Entry("default", "mqtt://broker", "tcp://broker:1883", mqtt.BackendOptions{TopicPrefix: "cunicu", QoS: 1}),
+	Entry("tls", "mqtts://broker", "ssl://broker:8883", mqtt.BackendOptions{TopicPrefix: "cunicu", QoS: 1}),
+	Entry("options", "mqtt://broker:1234/site/a?qos=2&retain=true", "tcp://broker:1234", mqtt.BackendOptions{TopicPrefix: "site/a", QoS: 2, Retain: true}),
+)
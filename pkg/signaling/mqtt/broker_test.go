@@ -0,0 +1,292 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+)
+
+// MQTT 3.1.1 control packet types.
+const (
+	packetConnect     = 1
+	packetConnAck     = 2
+	packetPublish     = 3
+	packetPubAck      = 4
+	packetSubscribe   = 8
+	packetSubAck      = 9
+	packetUnsubscribe = 10
+	packetUnsubAck    = 11
+	packetPingReq     = 12
+	packetPingResp    = 13
+	packetDisconnect  = 14
+)
+
+var errMalformedPacket = errors.New("malformed packet")
+
+// broker is a minimal MQTT 3.1.1 broker used for testing.
+// It delivers all messages with QoS 0 and does not support retained messages or sessions.
+type broker struct {
+	listener net.Listener
+
+	clients map[*brokerClient]any
+	mu      sync.Mutex
+}
+
+type brokerClient struct {
+	conn    net.Conn
+	filters map[string]any
+	mu      sync.Mutex
+}
+
+func newBroker() (*broker, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	b := &broker{
+		listener: l,
+		clients:  map[*brokerClient]any{},
+	}
+
+	go b.run()
+
+	return b, nil
+}
+
+func (b *broker) Addr() string {
+	return b.listener.Addr().String()
+}
+
+func (b *broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for c := range b.clients {
+		c.conn.Close()
+	}
+
+	return b.listener.Close()
+}
+
+func (b *broker) run() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c := &brokerClient{
+			conn:    conn,
+			filters: map[string]any{},
+		}
+
+		b.mu.Lock()
+		b.clients[c] = nil
+		b.mu.Unlock()
+
+		go b.handle(c)
+	}
+}
+
+func (b *broker) handle(c *brokerClient) {
+	defer func() {
+		b.mu.Lock()
+		delete(b.clients, c)
+		b.mu.Unlock()
+
+		c.conn.Close()
+	}()
+
+	rd := bufio.NewReader(c.conn)
+
+	for {
+		hdr, body, err := readPacket(rd)
+		if err != nil {
+			return
+		}
+
+		switch hdr >> 4 {
+		case packetConnect:
+			c.write(packetConnAck<<4, []byte{0, 0})
+
+		case packetPublish:
+			topic, n, err := readString(body)
+			if err != nil {
+				return
+			}
+
+			payload := body[n:]
+
+			if qos := (hdr >> 1) & 0x3; qos > 0 {
+				if len(payload) < 2 {
+					return
+				}
+
+				c.write(packetPubAck<<4, payload[:2])
+				payload = payload[2:]
+			}
+
+			b.publish(topic, payload)
+
+		case packetSubscribe, packetUnsubscribe:
+			if len(body) < 2 {
+				return
+			}
+
+			id, rest := body[:2], body[2:]
+			granted := []byte{}
+
+			for len(rest) > 0 {
+				filter, n, err := readString(rest)
+				if err != nil {
+					return
+				}
+
+				rest = rest[n:]
+
+				c.mu.Lock()
+				if hdr>>4 == packetSubscribe {
+					c.filters[filter] = nil
+					rest = rest[1:] // Requested QoS
+					granted = append(granted, 0)
+				} else {
+					delete(c.filters, filter)
+				}
+				c.mu.Unlock()
+			}
+
+			if hdr>>4 == packetSubscribe {
+				c.write(packetSubAck<<4, append(id, granted...))
+			} else {
+				c.write(packetUnsubAck<<4, id)
+			}
+
+		case packetPingReq:
+			c.write(packetPingResp<<4, nil)
+
+		case packetDisconnect:
+			return
+		}
+	}
+}
+
+func (b *broker) publish(topic string, payload []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	body := binary.BigEndian.AppendUint16(nil, uint16(len(topic))) //nolint:gosec
+	body = append(body, topic...)
+	body = append(body, payload...)
+
+	for c := range b.clients {
+		if c.matches(topic) {
+			c.write(packetPublish<<4, body)
+		}
+	}
+}
+
+func (c *brokerClient) matches(topic string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for filter := range c.filters {
+		if topicMatches(filter, topic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *brokerClient) write(hdr byte, body []byte) {
+	pkt := []byte{hdr}
+
+	// Remaining length is encoded as a variable length integer
+	for n := len(body); ; {
+		d := byte(n % 128)
+		if n /= 128; n > 0 {
+			d |= 0x80
+		}
+
+		pkt = append(pkt, d)
+
+		if n == 0 {
+			break
+		}
+	}
+
+	pkt = append(pkt, body...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conn.Write(pkt) //nolint:errcheck
+}
+
+func readPacket(rd *bufio.Reader) (byte, []byte, error) {
+	hdr, err := rd.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	n, mul := 0, 1
+
+	for {
+		d, err := rd.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		n += int(d&0x7f) * mul
+		mul *= 128
+
+		if d&0x80 == 0 {
+			break
+		}
+	}
+
+	body := make([]byte, n)
+	if _, err := io.ReadFull(rd, body); err != nil {
+		return 0, nil, err
+	}
+
+	return hdr, body, nil
+}
+
+func readString(b []byte) (string, int, error) {
+	if len(b) < 2 {
+		return "", 0, errMalformedPacket
+	}
+
+	n := int(binary.BigEndian.Uint16(b))
+	if len(b) < 2+n {
+		return "", 0, errMalformedPacket
+	}
+
+	return string(b[2 : 2+n]), 2 + n, nil
+}
+
+func topicMatches(filter, topic string) bool {
+	fs, ts := strings.Split(filter, "/"), strings.Split(topic, "/")
+
+	for i, f := range fs {
+		switch {
+		case f == "#":
+			return true
+		case i >= len(ts):
+			return false
+		case f != "+" && f != ts[i]:
+			return false
+		}
+	}
+
+	return len(fs) == len(ts)
+}
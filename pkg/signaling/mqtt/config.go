@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"fmt"
+
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+type BackendConfig struct {
+	signaling.BackendConfig
+
+	ClientOptions *pahomqtt.ClientOptions
+	Options       BackendOptions
+}
+
+func (c *BackendConfig) Parse(cfg *signaling.BackendConfig) (err error) {
+	c.BackendConfig = *cfg
+
+	c.ClientOptions, c.Options, err = ParseURL(c.BackendConfig.URI.String())
+	if err != nil {
+		return fmt.Errorf("failed to parse MQTT URL: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mqtt implements a signaling backend using an MQTT broker
+package mqtt
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	netx "cunicu.li/cunicu/pkg/net"
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+const (
+	DefaultTopicPrefix = "cunicu"
+
+	defaultPort    = "1883"
+	defaultTLSPort = "8883"
+)
+
+var (
+	errInvalidBrokerHostname = errors.New("missing MQTT broker url")
+	errInvalidQoS            = errors.New("QoS must be 0, 1 or 2")
+)
+
+type BackendOptions struct {
+	// Prefix of all topics used by this backend
+	TopicPrefix string
+
+	// Quality of service level for published and subscribed messages
+	QoS byte
+
+	// Retain the last message published by each sender
+	Retain bool
+}
+
+func ParseURL(urlStr string) (*pahomqtt.ClientOptions, BackendOptions, error) {
+	o := BackendOptions{
+		TopicPrefix: DefaultTopicPrefix,
+		QoS:         1,
+	}
+
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, o, err
+	}
+
+	if u.Host == "" {
+		return nil, o, errInvalidBrokerHostname
+	}
+
+	q := u.Query()
+
+	if prefix := strings.Trim(u.Path, "/"); prefix != "" {
+		o.TopicPrefix = prefix
+	}
+
+	if q.Has("qos") {
+		qos, err := strconv.ParseUint(q.Get("qos"), 10, 8)
+		if err != nil {
+			return nil, o, fmt.Errorf("failed to parse 'qos' option: %w", err)
+		} else if qos > 2 {
+			return nil, o, errInvalidQoS
+		}
+
+		o.QoS = byte(qos)
+	}
+
+	if q.Has("retain") {
+		if o.Retain, err = strconv.ParseBool(q.Get("retain")); err != nil {
+			return nil, o, fmt.Errorf("failed to parse 'retain' option: %w", err)
+		}
+	}
+
+	skipVerify := false
+
+	if q.Has("skip_verify") {
+		if skipVerify, err = strconv.ParseBool(q.Get("skip_verify")); err != nil {
+			return nil, o, fmt.Errorf("failed to parse 'skip_verify' option: %w", err)
+		}
+	}
+
+	opts := pahomqtt.NewClientOptions()
+	opts.Dialer.Control = netx.MarkControl
+
+	host, port := u.Hostname(), u.Port()
+
+	if u.Scheme == "mqtts" {
+		if port == "" {
+			port = defaultTLSPort
+		}
+
+		opts.AddBroker("ssl://" + net.JoinHostPort(host, port))
+		opts.SetTLSConfig(&tls.Config{
+			// Users should have the freedom to disable verification for self-signed certificates
+			//nolint:gosec
+			InsecureSkipVerify: skipVerify,
+		})
+	} else {
+		if port == "" {
+			port = defaultPort
+		}
+
+		opts.AddBroker("tcp://" + net.JoinHostPort(host, port))
+	}
+
+	if u.User != nil {
+		opts.SetUsername(u.User.Username())
+
+		if pw, ok := u.User.Password(); ok {
+			opts.SetPassword(pw)
+		}
+	}
+
+	if q.Has("client_id") {
+		opts.SetClientID(q.Get("client_id"))
+	}
+
+	return opts, o, nil
+}
+
+// topic returns the topic to which a sender publishes messages for a recipient.
+func (o *BackendOptions) topic(recipient, sender crypto.Key) string {
+	return fmt.Sprintf("%s/%s/%s", o.TopicPrefix, signaling.EncodeKey(recipient), signaling.EncodeKey(sender))
+}
+
+// subscriptionTopic returns the topic filter matching all messages for a recipient.
+func (o *BackendOptions) subscriptionTopic(recipient crypto.Key) string {
+	return fmt.Sprintf("%s/%s/+", o.TopicPrefix, signaling.EncodeKey(recipient))
+}
@@ -4,30 +4,81 @@
 package signaling
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/log"
+	protox "cunicu.li/cunicu/pkg/proto"
 	signalingproto "cunicu.li/cunicu/pkg/proto/signaling"
 )
 
+// FailoverRetryInterval is the duration after which a failed backend is tried again for publishing.
+const FailoverRetryInterval = 30 * time.Second
+
+var errNoBackends = errors.New("no signaling backends")
+
 type MultiBackend struct {
 	Backends []Backend
+
+	// backends tracks the state of each of the backends above
+	backends []*multiBackendEntry
+
+	// injected dispatches envelopes which have been injected
+	// via InjectEnvelope() rather than received by a backend.
+	injected SubscriptionsRegistry
+
+	// handlers wraps the subscribed message handlers to de-duplicate messages received via multiple backends
+	handlers   map[MessageHandler]*dedupHandler
+	handlersMu sync.Mutex
+
+	// envelopes holds the most recently received envelope for each pair of recipient and sender.
+	envelopes   map[crypto.PublicKeyPair]*Envelope
+	envelopesMu sync.RWMutex
 }
 
 func NewMultiBackend(uris []url.URL, cfg *BackendConfig) (*MultiBackend, error) {
 	mb := &MultiBackend{
-		Backends: []Backend{},
+		Backends:  []Backend{},
+		injected:  NewSubscriptionsRegistry(),
+		handlers:  map[MessageHandler]*dedupHandler{},
+		envelopes: map[crypto.PublicKeyPair]*Envelope{},
 	}
 
 	for _, u := range uris {
-		cfg.URI = &u
+		e, err := newMultiBackendEntry(mb, &u)
+		if err != nil {
+			return nil, err
+		}
 
-		if b, err := NewBackend(cfg); err == nil {
-			mb.Backends = append(mb.Backends, b)
-		} else {
+		bcfg := *cfg
+		bcfg.URI = &u
+		bcfg.OnReady = append(slices.Clone(cfg.OnReady), e)
+
+		if e.Backend, err = NewBackend(&bcfg); err != nil {
 			return nil, err
 		}
+
+		mb.Backends = append(mb.Backends, e.Backend)
+		mb.backends = append(mb.backends, e)
+	}
+
+	mb.injected.AddEnvelopeHandler(mb)
+
+	for _, e := range mb.backends {
+		if s, ok := e.Backend.(EnvelopeSource); ok {
+			s.AddEnvelopeHandler(e)
+		}
 	}
 
 	return mb, nil
@@ -47,42 +98,343 @@ func (mb *MultiBackend) ByType(t signalingproto.BackendType) Backend {
 	return nil
 }
 
+// Publish publishes the message via the healthy backends with the highest priority.
+// If all of them fail, we fail over to the backends with the next lower priority.
 func (mb *MultiBackend) Publish(ctx context.Context, kp *crypto.KeyPair, msg *Message) error {
-	for _, b := range mb.Backends {
-		if err := b.Publish(ctx, kp, msg); err != nil {
-			return err
+	// All backends carry the same sequence number so that
+	// receivers can detect copies delivered via multiple backends.
+	if msg.Sequence == 0 {
+		msg.Sequence = signalingproto.NextSequence()
+	}
+
+	var errs []error
+
+	for _, es := range mb.preferred() {
+		published := false
+
+		for _, e := range es {
+			if err := e.publish(ctx, kp, msg); err != nil {
+				errs = append(errs, err)
+			} else {
+				published = true
+			}
+		}
+
+		if published {
+			return nil
 		}
 	}
 
-	return nil
+	if len(errs) == 0 {
+		return errNoBackends
+	}
+
+	return errors.Join(errs...)
+}
+
+// preferred returns the backends grouped by priority in the order in which they should be used for publishing.
+// Healthy backends come first. Unhealthy ones are only used as a last resort.
+func (mb *MultiBackend) preferred() [][]*multiBackendEntry {
+	now := time.Now()
+
+	var healthy, unhealthy [][]*multiBackendEntry
+
+	for _, e := range mb.sorted() {
+		groups := &unhealthy
+		if e.healthy(now) {
+			groups = &healthy
+		}
+
+		if n := len(*groups); n > 0 && (*groups)[n-1][0].priority == e.priority {
+			(*groups)[n-1] = append((*groups)[n-1], e)
+		} else {
+			*groups = append(*groups, []*multiBackendEntry{e})
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+func (mb *MultiBackend) sorted() []*multiBackendEntry {
+	return slices.SortedStableFunc(slices.Values(mb.backends), func(a, b *multiBackendEntry) int {
+		return cmp.Compare(a.priority, b.priority)
+	})
 }
 
 func (mb *MultiBackend) Subscribe(ctx context.Context, kp *crypto.KeyPair, h MessageHandler) (bool, error) {
+	dh := mb.handler(h, true)
+
 	for _, b := range mb.Backends {
-		if _, err := b.Subscribe(ctx, kp, h); err != nil {
+		if _, err := b.Subscribe(ctx, kp, dh); err != nil {
 			return false, err
 		}
 	}
 
+	if _, err := mb.injected.Subscribe(kp, dh); err != nil {
+		return false, err
+	}
+
 	return false, nil
 }
 
 func (mb *MultiBackend) Unsubscribe(ctx context.Context, kp *crypto.KeyPair, h MessageHandler) (bool, error) {
+	dh := mb.handler(h, false)
+	if dh == nil {
+		return false, ErrNotSubscribed
+	}
+
 	for _, b := range mb.Backends {
-		if _, err := b.Unsubscribe(ctx, kp, h); err != nil {
+		if _, err := b.Unsubscribe(ctx, kp, dh); err != nil {
 			return false, err
 		}
 	}
 
+	if _, err := mb.injected.Unsubscribe(kp, dh); err != nil {
+		return false, err
+	}
+
 	return false, nil
 }
 
+// handler returns the de-duplicating wrapper of a message handler.
+// The wrapper is created for the first and released after the last subscription of the handler.
+func (mb *MultiBackend) handler(h MessageHandler, subscribe bool) *dedupHandler {
+	mb.handlersMu.Lock()
+	defer mb.handlersMu.Unlock()
+
+	dh, ok := mb.handlers[h]
+
+	if subscribe {
+		if !ok {
+			dh = &dedupHandler{
+				MessageHandler: h,
+				seen:           NewReplayFilter(DefaultReplayWindow),
+			}
+			mb.handlers[h] = dh
+		}
+
+		dh.subscriptions++
+	} else if ok {
+		if dh.subscriptions--; dh.subscriptions == 0 {
+			delete(mb.handlers, h)
+		}
+	}
+
+	return dh
+}
+
 func (mb *MultiBackend) Close() error {
-	for _, b := range mb.Backends {
-		if err := b.Close(); err != nil {
+	for _, e := range mb.backends {
+		if s, ok := e.Backend.(EnvelopeSource); ok {
+			s.RemoveEnvelopeHandler(e)
+		}
+
+		if err := e.Close(); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// OnSignalingEnvelope is invoked by the backends for each received envelope.
+func (mb *MultiBackend) OnSignalingEnvelope(env *Envelope) {
+	pkp, err := env.PublicKeyPair()
+	if err != nil {
+		return
+	}
+
+	env, ok := proto.Clone(env).(*Envelope)
+	if !ok {
+		panic("type assertion failed")
+	}
+
+	mb.envelopesMu.Lock()
+	defer mb.envelopesMu.Unlock()
+
+	mb.envelopes[pkp] = env
+}
+
+// LastEnvelope returns the most recently received envelope which has been sent by pkp.Theirs to pkp.Ours.
+func (mb *MultiBackend) LastEnvelope(pkp *crypto.PublicKeyPair) *Envelope {
+	mb.envelopesMu.RLock()
+	defer mb.envelopesMu.RUnlock()
+
+	return mb.envelopes[*pkp]
+}
+
+// InjectEnvelope passes an envelope to the subscribed message handlers
+// as if it had been received by one of the backends.
+func (mb *MultiBackend) InjectEnvelope(env *Envelope) error {
+	if _, err := env.PublicKeyPair(); err != nil {
+		return fmt.Errorf("invalid envelope: %w", err)
+	}
+
+	return mb.injected.NewMessage(env)
+}
+
+// Status returns the status of all backends ordered by their priority.
+func (mb *MultiBackend) Status() []*signalingproto.BackendStatus {
+	active := map[*multiBackendEntry]bool{}
+	if groups := mb.preferred(); len(groups) > 0 {
+		for _, e := range groups[0] {
+			active[e] = true
+		}
+	}
+
+	sts := []*signalingproto.BackendStatus{}
+	for _, e := range mb.sorted() {
+		st := e.status()
+		st.Active = active[e]
+		sts = append(sts, st)
+	}
+
+	return sts
+}
+
+// dedupHandler suppresses messages which have already been passed to the handler
+// after being received via another backend.
+type dedupHandler struct {
+	MessageHandler
+
+	seen          *ReplayFilter
+	subscriptions int
+}
+
+func (h *dedupHandler) OnSignalingMessage(pkp *crypto.PublicKeyPair, msg *Message) {
+	if err := h.seen.Check(pkp, msg.Sequence); err != nil {
+		// Messages are expected to be received via multiple backends
+		log.Global.Named("backend").Debug("Suppressed signaling message",
+			zap.Error(err),
+			zap.Uint64("seq", msg.Sequence),
+			zap.Any("pkp", pkp))
+
+		return
+	}
+
+	h.MessageHandler.OnSignalingMessage(pkp, msg)
+}
+
+// multiBackendEntry tracks the health of a single backend of the multi backend.
+type multiBackendEntry struct {
+	Backend
+
+	mb       *MultiBackend
+	uri      string
+	priority int
+
+	state        signalingproto.BackendState
+	stateChanged time.Time
+	published    uint64
+	received     uint64
+	failures     uint64
+	lastError    error
+	lastErrorAt  time.Time
+	mu           sync.Mutex
+}
+
+func newMultiBackendEntry(mb *MultiBackend, u *url.URL) (*multiBackendEntry, error) {
+	e := &multiBackendEntry{
+		mb:           mb,
+		state:        signalingproto.BackendState_CONNECTING,
+		stateChanged: time.Now(),
+	}
+
+	// The priority is handled by us and not passed to the backend
+	q := u.Query()
+	if str := q.Get("priority"); str != "" {
+		prio, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority for backend %s: %w", u.Redacted(), err)
+		}
+
+		e.priority = prio
+
+		q.Del("priority")
+		u.RawQuery = q.Encode()
+	}
+
+	e.uri = u.Redacted()
+
+	return e, nil
+}
+
+func (e *multiBackendEntry) publish(ctx context.Context, kp *crypto.KeyPair, msg *Message) error {
+	err := e.Publish(ctx, kp, msg)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err != nil {
+		e.failures++
+		e.lastError = err
+		e.lastErrorAt = time.Now()
+		e.setState(signalingproto.BackendState_FAILED)
+
+		return fmt.Errorf("failed to publish via %s: %w", e.uri, err)
+	}
+
+	e.published++
+	e.setState(signalingproto.BackendState_READY)
+
+	return nil
+}
+
+// healthy returns true if the backend is ready or its last failure is long enough ago to try it again.
+func (e *multiBackendEntry) healthy(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch e.state {
+	case signalingproto.BackendState_READY:
+		return true
+	case signalingproto.BackendState_FAILED:
+		return now.Sub(e.stateChanged) > FailoverRetryInterval
+	default:
+		return false
+	}
+}
+
+func (e *multiBackendEntry) setState(state signalingproto.BackendState) {
+	if e.state != state {
+		e.state = state
+		e.stateChanged = time.Now()
+	}
+}
+
+func (e *multiBackendEntry) status() *signalingproto.BackendStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	st := &signalingproto.BackendStatus{
+		Type:      e.Type(),
+		Uri:       e.uri,
+		Priority:  int32(e.priority), //nolint:gosec
+		State:     e.state,
+		Published: e.published,
+		Received:  e.received,
+		Failures:  e.failures,
+	}
+
+	if e.lastError != nil {
+		st.LastError = e.lastError.Error()
+		st.LastErrorTimestamp = protox.Time(e.lastErrorAt)
+	}
+
+	return st
+}
+
+func (e *multiBackendEntry) OnSignalingBackendReady(Backend) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.setState(signalingproto.BackendState_READY)
+}
+
+func (e *multiBackendEntry) OnSignalingEnvelope(env *Envelope) {
+	e.mu.Lock()
+	e.received++
+	e.mu.Unlock()
+
+	e.mb.OnSignalingEnvelope(env)
+}
@@ -0,0 +1,322 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package signaling_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync/atomic"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/log"
+	epdiscproto "cunicu.li/cunicu/pkg/proto/feature/epdisc"
+	signalingproto "cunicu.li/cunicu/pkg/proto/signaling"
+	"cunicu.li/cunicu/pkg/signaling"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type messageHandler struct {
+	messages chan *signaling.Message
+}
+
+func (h *messageHandler) OnSignalingMessage(_ *crypto.PublicKeyPair, msg *signaling.Message) {
+	h.messages <- msg
+}
+
+var errLoopbackFailed = errors.New("loopback failed")
+
+// loopbackBackend delivers published messages to its own subscribers.
+type loopbackBackend struct {
+	signaling.SubscriptionsRegistry
+
+	onReady   []signaling.BackendReadyHandler
+	published atomic.Int32
+	fail      atomic.Bool
+}
+
+var loopbackBackends = map[string]*loopbackBackend{} //nolint:gochecknoglobals
+
+func init() { //nolint:gochecknoinits
+	signaling.Backends["loopback"] = &signaling.BackendPlugin{
+		New: func(cfg *signaling.BackendConfig, _ *log.Logger) (signaling.Backend, error) {
+			b := &loopbackBackend{
+				SubscriptionsRegistry: signaling.NewSubscriptionsRegistry(),
+				onReady:               cfg.OnReady,
+			}
+
+			loopbackBackends[cfg.URI.Host] = b
+
+			b.ready()
+
+			return b, nil
+		},
+		Description: "Loopback",
+	}
+}
+
+func (b *loopbackBackend) ready() {
+	for _, h := range b.onReady {
+		h.OnSignalingBackendReady(b)
+	}
+}
+
+func (b *loopbackBackend) Type() signalingproto.BackendType {
+	return signalingproto.BackendType_INPROCESS
+}
+
+func (b *loopbackBackend) Subscribe(_ context.Context, kp *crypto.KeyPair, h signaling.MessageHandler) (bool, error) {
+	return b.SubscriptionsRegistry.Subscribe(kp, h)
+}
+
+func (b *loopbackBackend) Unsubscribe(_ context.Context, kp *crypto.KeyPair, h signaling.MessageHandler) (bool, error) {
+	return b.SubscriptionsRegistry.Unsubscribe(kp, h)
+}
+
+func (b *loopbackBackend) Publish(_ context.Context, kp *crypto.KeyPair, msg *signaling.Message) error {
+	if b.fail.Load() {
+		return errLoopbackFailed
+	}
+
+	env, err := msg.Encrypt(kp)
+	if err != nil {
+		return err
+	}
+
+	b.published.Add(1)
+
+	return b.NewMessage(env)
+}
+
+func (b *loopbackBackend) Close() error {
+	return nil
+}
+
+var _ = Context("multi backend", func() {
+	var (
+		err      error
+		mb       *signaling.MultiBackend
+		h        *messageHandler
+		ourSK    crypto.Key
+		theirSK  crypto.Key
+		ourKP    *crypto.KeyPair
+		theirKP  *crypto.KeyPair
+		theirMsg *signaling.Message
+	)
+
+	BeforeEach(func() {
+		mb, err = signaling.NewMultiBackend([]url.URL{{Scheme: "inprocess"}}, &signaling.BackendConfig{})
+		Expect(err).To(Succeed())
+
+		ourSK, err = crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		theirSK, err = crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		ourKP = &crypto.KeyPair{
+			Ours:   ourSK,
+			Theirs: theirSK.PublicKey(),
+		}
+
+		theirKP = &crypto.KeyPair{
+			Ours:   theirSK,
+			Theirs: ourSK.PublicKey(),
+		}
+
+		theirMsg = &signaling.Message{
+			Candidate: &epdiscproto.Candidate{
+				Port: 1234,
+			},
+		}
+
+		h = &messageHandler{
+			messages: make(chan *signaling.Message, 1),
+		}
+
+		_, err = mb.Subscribe(context.Background(), ourKP, h)
+		Expect(err).To(Succeed())
+	})
+
+	AfterEach(func() {
+		_, err = mb.Unsubscribe(context.Background(), ourKP, h)
+		Expect(err).To(Succeed())
+
+		err = mb.Close()
+		Expect(err).To(Succeed())
+	})
+
+	It("remembers the last received envelope", func() {
+		pkp := ourKP.Public()
+		Expect(mb.LastEnvelope(&pkp)).To(BeNil())
+
+		err = mb.Publish(context.Background(), theirKP, theirMsg)
+		Expect(err).To(Succeed())
+
+		Eventually(h.messages).Should(Receive())
+
+		env := mb.LastEnvelope(&pkp)
+		Expect(env).NotTo(BeNil())
+
+		msg, err := env.Decrypt(ourKP)
+		Expect(err).To(Succeed())
+		Expect(msg.Candidate.Port).To(BeNumerically("==", 1234))
+	})
+
+	It("can inject envelopes", func() {
+		env, err := theirMsg.Encrypt(theirKP)
+		Expect(err).To(Succeed())
+
+		err = mb.InjectEnvelope(env)
+		Expect(err).To(Succeed())
+
+		var msg *signaling.Message
+		Eventually(h.messages).Should(Receive(&msg))
+		Expect(msg.Candidate.Port).To(BeNumerically("==", 1234))
+
+		pkp := ourKP.Public()
+		Expect(mb.LastEnvelope(&pkp)).NotTo(BeNil())
+	})
+
+	It("rejects envelopes for unknown recipients", func() {
+		otherSK, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		env, err := theirMsg.Encrypt(&crypto.KeyPair{
+			Ours:   theirSK,
+			Theirs: otherSK.PublicKey(),
+		})
+		Expect(err).To(Succeed())
+
+		err = mb.InjectEnvelope(env)
+		Expect(err).To(MatchError(signaling.ErrNotSubscribed))
+	})
+})
+
+var _ = Context("multi backend with multiple backends", func() {
+	var (
+		err     error
+		mb      *signaling.MultiBackend
+		h       *messageHandler
+		kp      *crypto.KeyPair
+		primary *loopbackBackend
+		backup  *loopbackBackend
+		other   *loopbackBackend
+	)
+
+	publish := func() {
+		err := mb.Publish(context.Background(), kp, &signaling.Message{
+			Candidate: &epdiscproto.Candidate{
+				Port: 1234,
+			},
+		})
+		Expect(err).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		mb, err = signaling.NewMultiBackend([]url.URL{
+			{Scheme: "loopback", Host: "backup", RawQuery: "priority=10"},
+			{Scheme: "loopback", Host: "primary"},
+			{Scheme: "loopback", Host: "other"},
+		}, &signaling.BackendConfig{})
+		Expect(err).To(Succeed())
+
+		primary = loopbackBackends["primary"]
+		backup = loopbackBackends["backup"]
+		other = loopbackBackends["other"]
+
+		sk, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		// We send messages to ourself
+		kp = &crypto.KeyPair{
+			Ours:   sk,
+			Theirs: sk.PublicKey(),
+		}
+
+		h = &messageHandler{
+			messages: make(chan *signaling.Message, 10),
+		}
+
+		_, err = mb.Subscribe(context.Background(), kp, h)
+		Expect(err).To(Succeed())
+	})
+
+	AfterEach(func() {
+		_, err = mb.Unsubscribe(context.Background(), kp, h)
+		Expect(err).To(Succeed())
+
+		err = mb.Close()
+		Expect(err).To(Succeed())
+	})
+
+	It("publishes only via the preferred backends", func() {
+		publish()
+
+		Expect(primary.published.Load()).To(BeNumerically("==", 1))
+		Expect(other.published.Load()).To(BeNumerically("==", 1))
+		Expect(backup.published.Load()).To(BeZero())
+	})
+
+	It("de-duplicates messages received via multiple backends", func() {
+		publish()
+
+		Eventually(h.messages).Should(Receive())
+		Consistently(h.messages).ShouldNot(Receive())
+	})
+
+	It("fails over to backends with lower priority", func() {
+		primary.fail.Store(true)
+		other.fail.Store(true)
+
+		publish()
+
+		Expect(backup.published.Load()).To(BeNumerically("==", 1))
+		Eventually(h.messages).Should(Receive())
+
+		// Failed backends are avoided for subsequent messages
+		primary.fail.Store(false)
+		other.fail.Store(false)
+
+		publish()
+
+		Expect(primary.published.Load()).To(BeZero())
+		Expect(backup.published.Load()).To(BeNumerically("==", 2))
+
+		// Until they become ready again
+		primary.ready()
+
+		publish()
+
+		Expect(primary.published.Load()).To(BeNumerically("==", 1))
+		Expect(backup.published.Load()).To(BeNumerically("==", 2))
+	})
+
+	It("reports the status of the backends", func() {
+		primary.fail.Store(true)
+
+		publish()
+
+		sts := mb.Status()
+		Expect(sts).To(HaveLen(3))
+
+		Expect(sts[0].Uri).To(Equal("loopback://primary"))
+		Expect(sts[0].State).To(Equal(signalingproto.BackendState_FAILED))
+		Expect(sts[0].Failures).To(BeNumerically("==", 1))
+		Expect(sts[0].LastError).To(ContainSubstring(errLoopbackFailed.Error()))
+		Expect(sts[0].Active).To(BeFalse())
+
+		Expect(sts[1].Uri).To(Equal("loopback://other"))
+		Expect(sts[1].State).To(Equal(signalingproto.BackendState_READY))
+		Expect(sts[1].Published).To(BeNumerically("==", 1))
+		Expect(sts[1].Received).To(BeNumerically("==", 1))
+		Expect(sts[1].Active).To(BeTrue())
+
+		Expect(sts[2].Uri).To(Equal("loopback://backup"))
+		Expect(sts[2].Priority).To(BeNumerically("==", 10))
+		Expect(sts[2].Active).To(BeFalse())
+	})
+})
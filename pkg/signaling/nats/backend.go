@@ -0,0 +1,370 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/log"
+	signalingproto "cunicu.li/cunicu/pkg/proto/signaling"
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+var errNotConnected = errors.New("not connected to NATS server")
+
+func init() { //nolint:gochecknoinits
+	signaling.Backends["nats"] = &signaling.BackendPlugin{
+		New:         NewBackend,
+		Description: "NATS",
+	}
+}
+
+type Backend struct {
+	signaling.SubscriptionsRegistry
+
+	conn *nats.Conn
+	js   jetstream.JetStream
+
+	// unsubscribe contains functions for canceling the subscriptions of each recipient
+	unsubscribe   map[crypto.Key]func() error
+	unsubscribeMu sync.Mutex
+
+	// deferred contains recipients whose subscriptions are created once the JetStream stream has been created
+	deferred map[crypto.Key]struct{}
+
+	// stream is closed once the JetStream stream has been created
+	stream     chan struct{}
+	streamErr  error
+	streamOnce sync.Once
+
+	config BackendConfig
+
+	logger *log.Logger
+}
+
+func NewBackend(cfg *signaling.BackendConfig, logger *log.Logger) (signaling.Backend, error) {
+	b := &Backend{
+		SubscriptionsRegistry: signaling.NewSubscriptionsRegistry(),
+		unsubscribe:           map[crypto.Key]func() error{},
+		deferred:              map[crypto.Key]struct{}{},
+		logger:                logger,
+	}
+
+	if err := b.config.Parse(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse backend configuration: %w", err)
+	}
+
+	b.config.NATSOptions = append(b.config.NATSOptions,
+		nats.ConnectHandler(b.onConnect),
+		nats.ReconnectHandler(b.onConnect),
+		nats.DisconnectErrHandler(b.onDisconnect),
+	)
+
+	var err error
+	if b.conn, err = nats.Connect(b.config.Server, b.config.NATSOptions...); err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS server: %w", err)
+	}
+
+	if b.config.Options.JetStream {
+		if b.js, err = jetstream.New(b.conn); err != nil {
+			b.conn.Close()
+
+			return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+		}
+
+		b.stream = make(chan struct{})
+	}
+
+	// Create the stream right away if the initial connection attempt succeeded.
+	// Otherwise, it is created by the connect handler.
+	if b.conn.IsConnected() && b.js != nil {
+		if err := b.setupStream(context.Background()); err != nil {
+			b.conn.Close()
+
+			return nil, fmt.Errorf("failed to create JetStream stream: %w", err)
+		}
+	}
+
+	return b, nil
+}
+
+func (b *Backend) Type() signalingproto.BackendType {
+	return signalingproto.BackendType_NATS
+}
+
+func (b *Backend) Subscribe(ctx context.Context, kp *crypto.KeyPair, h signaling.MessageHandler) (bool, error) {
+	first, err := b.SubscriptionsRegistry.Subscribe(kp, h)
+	if err != nil {
+		return false, err
+	} else if first {
+		pk := kp.Ours.PublicKey()
+
+		return first, b.subscribeFromServer(ctx, pk)
+	}
+
+	return first, nil
+}
+
+// Unsubscribe from messages send by a specific peer.
+func (b *Backend) Unsubscribe(_ context.Context, kp *crypto.KeyPair, h signaling.MessageHandler) (bool, error) {
+	last, err := b.SubscriptionsRegistry.Unsubscribe(kp, h)
+	if err != nil {
+		return false, err
+	} else if last {
+		pk := kp.Ours.PublicKey()
+
+		return last, b.unsubscribeFromServer(pk)
+	}
+
+	return last, nil
+}
+
+func (b *Backend) Publish(ctx context.Context, kp *crypto.KeyPair, msg *signaling.Message) error {
+	env, err := msg.Encrypt(kp)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt message: %w", err)
+	}
+
+	data, err := proto.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to marshal protobuf: %w", err)
+	}
+
+	subject := b.config.Options.subject(kp.Theirs, kp.Ours.PublicKey())
+
+	if b.js != nil {
+		if created, err := b.streamCreated(); err != nil {
+			return err
+		} else if !created {
+			return errNotConnected
+		}
+
+		_, err = b.js.Publish(ctx, subject, data)
+	} else {
+		err = b.conn.Publish(subject, data)
+	}
+
+	if err != nil {
+		if errors.Is(err, nats.ErrConnectionClosed) {
+			return signaling.ErrClosed
+		}
+
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Backend) Close() error {
+	b.unsubscribeMu.Lock()
+	clear(b.deferred)
+
+	for pk, unsubscribe := range b.unsubscribe {
+		if err := unsubscribe(); err != nil && !errors.Is(err, nats.ErrConnectionClosed) {
+			b.logger.Error("Failed to unsubscribe", zap.Error(err))
+		}
+
+		delete(b.unsubscribe, pk)
+	}
+	b.unsubscribeMu.Unlock()
+
+	b.conn.Close()
+
+	return nil
+}
+
+func (b *Backend) subscribeFromServer(ctx context.Context, pk crypto.Key) error {
+	subject := b.config.Options.subscriptionSubject(pk)
+
+	var unsubscribe func() error
+
+	if b.js != nil {
+		// Subscriptions are deferred until the first connection has been established
+		// as the consumer can only be created for an existing stream.
+		b.unsubscribeMu.Lock()
+		created, err := b.streamCreated()
+		if err == nil && !created {
+			b.deferred[pk] = struct{}{}
+		}
+		b.unsubscribeMu.Unlock()
+
+		if err != nil {
+			return err
+		} else if !created {
+			b.logger.Debug("Deferring subscription until connected", zap.Any("pk", pk))
+
+			return nil
+		}
+
+		cons, err := b.js.OrderedConsumer(ctx, b.config.Options.Stream, jetstream.OrderedConsumerConfig{
+			FilterSubjects: []string{subject},
+			DeliverPolicy:  jetstream.DeliverAllPolicy,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create consumer: %w", err)
+		}
+
+		cc, err := cons.Consume(func(m jetstream.Msg) {
+			b.onMessage(m.Subject(), m.Data())
+		})
+		if err != nil {
+			return fmt.Errorf("failed to consume messages: %w", err)
+		}
+
+		unsubscribe = func() error {
+			cc.Stop()
+
+			return nil
+		}
+	} else {
+		sub, err := b.conn.Subscribe(subject, func(m *nats.Msg) {
+			b.onMessage(m.Subject, m.Data)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to subject: %w", err)
+		}
+
+		// Wait until the subscription has been created by the server.
+		// This avoids a race between Subscribe() / Publish() when two subscribers are subscribing
+		// to each other.
+		if err := b.conn.Flush(); err != nil {
+			return fmt.Errorf("failed to flush subscription: %w", err)
+		}
+
+		unsubscribe = sub.Unsubscribe
+	}
+
+	b.unsubscribeMu.Lock()
+	b.unsubscribe[pk] = unsubscribe
+	b.unsubscribeMu.Unlock()
+
+	b.logger.Debug("Created new subscription", zap.Any("pk", pk), zap.String("subject", subject))
+
+	return nil
+}
+
+func (b *Backend) unsubscribeFromServer(pk crypto.Key) error {
+	b.unsubscribeMu.Lock()
+	unsubscribe, ok := b.unsubscribe[pk]
+	delete(b.unsubscribe, pk)
+	delete(b.deferred, pk)
+	b.unsubscribeMu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	if err := unsubscribe(); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	return nil
+}
+
+// createStream creates or updates the JetStream stream which persists all messages
+// published with our subject prefix.
+func (b *Backend) createStream(ctx context.Context) error {
+	opts := b.config.Options
+
+	if _, err := b.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:              opts.Stream,
+		Description:       "cunīcu signaling messages",
+		Subjects:          []string{opts.SubjectPrefix + ".>"},
+		MaxMsgsPerSubject: opts.MaxMsgsPerSubject,
+		MaxAge:            opts.MaxAge,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// setupStream creates the JetStream stream once and unblocks pending publications and subscriptions.
+func (b *Backend) setupStream(ctx context.Context) error {
+	b.streamOnce.Do(func() {
+		b.streamErr = b.createStream(ctx)
+		close(b.stream)
+	})
+
+	return b.streamErr
+}
+
+// streamCreated checks whether the JetStream stream has been created without blocking.
+// This is the case after the first connection to the server has been established.
+func (b *Backend) streamCreated() (bool, error) {
+	select {
+	case <-b.stream:
+		if b.streamErr != nil {
+			return false, fmt.Errorf("failed to create JetStream stream: %w", b.streamErr)
+		}
+
+		return true, nil
+
+	default:
+		return false, nil
+	}
+}
+
+// subscribeDeferred creates the subscriptions which have been requested before the JetStream stream has been created.
+func (b *Backend) subscribeDeferred() {
+	b.unsubscribeMu.Lock()
+	pks := slices.Collect(maps.Keys(b.deferred))
+	clear(b.deferred)
+	b.unsubscribeMu.Unlock()
+
+	for _, pk := range pks {
+		if err := b.subscribeFromServer(context.Background(), pk); err != nil {
+			b.logger.Error("Failed to create deferred subscription", zap.Any("pk", pk), zap.Error(err))
+		}
+	}
+}
+
+func (b *Backend) onConnect(nc *nats.Conn) {
+	b.logger.Debug("Connected to NATS server", zap.String("server", nc.ConnectedUrlRedacted()))
+
+	if b.js != nil {
+		if err := b.setupStream(context.Background()); err != nil {
+			b.logger.Error("Failed to create JetStream stream", zap.Error(err))
+		} else {
+			b.subscribeDeferred()
+		}
+	}
+
+	// Report readiness after each reconnect so that a failed backend is used again right away
+	for _, h := range b.config.OnReady {
+		h.OnSignalingBackendReady(b)
+	}
+}
+
+func (b *Backend) onDisconnect(_ *nats.Conn, err error) {
+	if err != nil {
+		b.logger.Warn("Lost connection to NATS server. Reconnecting..", zap.Error(err))
+	}
+}
+
+func (b *Backend) onMessage(subject string, data []byte) {
+	env := &signalingproto.Envelope{}
+	if err := proto.Unmarshal(data, env); err != nil {
+		b.logger.Error("Failed to unmarshal envelope", zap.Error(err), zap.String("subject", subject))
+
+		return
+	}
+
+	if err := b.SubscriptionsRegistry.NewMessage(env); err != nil {
+		if !errors.Is(err, signaling.ErrNotSubscribed) {
+			b.logger.Error("Failed to decrypt message", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+type BackendConfig struct {
+	signaling.BackendConfig
+
+	Server      string
+	NATSOptions []nats.Option
+	Options     BackendOptions
+}
+
+func (c *BackendConfig) Parse(cfg *signaling.BackendConfig) (err error) {
+	c.BackendConfig = *cfg
+
+	c.Server, c.NATSOptions, c.Options, err = ParseURL(c.BackendConfig.URI.String())
+	if err != nil {
+		return fmt.Errorf("failed to parse NATS URL: %w", err)
+	}
+
+	return nil
+}
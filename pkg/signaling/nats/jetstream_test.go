@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package nats_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	natsclient "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/log"
+	"cunicu.li/cunicu/pkg/signaling"
+	"cunicu.li/cunicu/pkg/signaling/nats"
+	"cunicu.li/cunicu/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// newJetStreamServer starts an embedded NATS server with JetStream enabled.
+func newJetStreamServer(port int) *natsserver.Server {
+	s, err := natsserver.NewServer(&natsserver.Options{
+		Host:      "127.0.0.1",
+		Port:      port,
+		JetStream: true,
+		StoreDir:  GinkgoT().TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	Expect(err).To(Succeed())
+
+	go s.Start()
+
+	Expect(s.ReadyForConnections(5 * time.Second)).To(BeTrue())
+
+	DeferCleanup(func() {
+		s.Shutdown()
+		s.WaitForShutdown()
+	})
+
+	return s
+}
+
+var _ = Describe("NATS backend with JetStream", func() {
+	var s *natsserver.Server
+
+	u := &url.URL{
+		Scheme:   "nats",
+		RawQuery: "jetstream=true",
+	}
+
+	BeforeEach(func() {
+		s = newJetStreamServer(natsserver.RANDOM_PORT)
+
+		u.Host = s.Addr().String()
+	})
+
+	It("creates the stream before returning", func() {
+		b, err := nats.NewBackend(&signaling.BackendConfig{
+			URI: u,
+		}, log.Global)
+		Expect(err).To(Succeed())
+
+		defer b.Close()
+
+		nc, err := natsclient.Connect(s.ClientURL())
+		Expect(err).To(Succeed())
+
+		defer nc.Close()
+
+		js, err := jetstream.New(nc)
+		Expect(err).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		st, err := js.Stream(ctx, nats.DefaultStream)
+		Expect(err).To(Succeed())
+		Expect(st.CachedInfo().Config.Subjects).To(ConsistOf(nats.DefaultSubjectPrefix + ".>"))
+	})
+
+	It("defers subscriptions and fails publications before the first connection", func() {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).To(Succeed())
+
+		port := l.Addr().(*net.TCPAddr).Port //nolint:forcetypeassert
+		Expect(l.Close()).To(Succeed())
+
+		b, err := nats.NewBackend(&signaling.BackendConfig{
+			URI: &url.URL{
+				Scheme:   "nats",
+				Host:     fmt.Sprintf("127.0.0.1:%d", port),
+				RawQuery: "jetstream=true",
+			},
+		}, log.Global)
+		Expect(err).To(Succeed())
+
+		defer b.Close()
+
+		sk1, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		sk2, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		kp := &crypto.KeyPair{
+			Ours:   sk1,
+			Theirs: sk2.PublicKey(),
+		}
+
+		h := &messageHandler{
+			messages: make(chan *signaling.Message, 1),
+		}
+
+		// Neither call blocks while the server is unreachable
+		_, err = b.Subscribe(context.Background(), &crypto.KeyPair{
+			Ours:   sk2,
+			Theirs: sk1.PublicKey(),
+		}, h)
+		Expect(err).To(Succeed())
+
+		Expect(b.Publish(context.Background(), kp, &signaling.Message{})).NotTo(Succeed())
+
+		newJetStreamServer(port)
+
+		Eventually(func() error {
+			return b.Publish(context.Background(), kp, &signaling.Message{})
+		}).WithTimeout(10 * time.Second).Should(Succeed())
+
+		Eventually(h.messages).WithTimeout(10 * time.Second).Should(Receive())
+	})
+
+	It("reports to be ready again after reconnecting", func() {
+		h := &readyHandler{}
+
+		b, err := nats.NewBackend(&signaling.BackendConfig{
+			URI:     u,
+			OnReady: []signaling.BackendReadyHandler{h},
+		}, log.Global)
+		Expect(err).To(Succeed())
+
+		defer b.Close()
+
+		Eventually(h.count.Load).Should(BeNumerically("==", 1))
+
+		port := s.Addr().(*net.TCPAddr).Port //nolint:forcetypeassert
+
+		s.Shutdown()
+		s.WaitForShutdown()
+
+		newJetStreamServer(port)
+
+		Eventually(h.count.Load).WithTimeout(10 * time.Second).Should(BeNumerically("==", 2))
+	})
+
+	test.BackendTest(u, 10)
+})
+
+type messageHandler struct {
+	messages chan *signaling.Message
+}
+
+func (h *messageHandler) OnSignalingMessage(_ *crypto.PublicKeyPair, msg *signaling.Message) {
+	h.messages <- msg
+}
+
+type readyHandler struct {
+	count atomic.Uint32
+}
+
+func (h *readyHandler) OnSignalingBackendReady(signaling.Backend) {
+	h.count.Add(1)
+}
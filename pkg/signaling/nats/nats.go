@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nats implements a signaling backend using a NATS message bus
+package nats
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	netx "cunicu.li/cunicu/pkg/net"
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+const (
+	DefaultSubjectPrefix = "cunicu"
+	DefaultStream        = "CUNICU"
+
+	// Mirror the retention defaults of the gRPC signaling server.
+	DefaultMaxMsgsPerSubject = 16
+	DefaultMaxAge            = 10 * time.Minute
+)
+
+var errInvalidServerHostname = errors.New("missing NATS server url")
+
+type BackendOptions struct {
+	// Prefix of all subjects used by this backend
+	SubjectPrefix string
+
+	// Use a JetStream stream to persist messages for peers which are currently offline
+	JetStream bool
+
+	// Name of the JetStream stream
+	Stream string
+
+	// Number of messages retained by the stream per sender and recipient
+	MaxMsgsPerSubject int64
+
+	// Maximum age of messages retained by the stream
+	MaxAge time.Duration
+}
+
+func ParseURL(urlStr string) (string, []nats.Option, BackendOptions, error) {
+	o := BackendOptions{
+		SubjectPrefix:     DefaultSubjectPrefix,
+		Stream:            DefaultStream,
+		MaxMsgsPerSubject: DefaultMaxMsgsPerSubject,
+		MaxAge:            DefaultMaxAge,
+	}
+
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return "", nil, o, err
+	}
+
+	if u.Host == "" {
+		return "", nil, o, errInvalidServerHostname
+	}
+
+	q := u.Query()
+
+	if prefix := strings.Trim(u.Path, "/"); prefix != "" {
+		o.SubjectPrefix = strings.ReplaceAll(prefix, "/", ".")
+	}
+
+	if q.Has("jetstream") {
+		if o.JetStream, err = strconv.ParseBool(q.Get("jetstream")); err != nil {
+			return "", nil, o, fmt.Errorf("failed to parse 'jetstream' option: %w", err)
+		}
+	}
+
+	if q.Has("stream") {
+		o.Stream = q.Get("stream")
+	}
+
+	if q.Has("max_msgs") {
+		if o.MaxMsgsPerSubject, err = strconv.ParseInt(q.Get("max_msgs"), 10, 64); err != nil {
+			return "", nil, o, fmt.Errorf("failed to parse 'max_msgs' option: %w", err)
+		}
+	}
+
+	if q.Has("max_age") {
+		if o.MaxAge, err = time.ParseDuration(q.Get("max_age")); err != nil {
+			return "", nil, o, fmt.Errorf("failed to parse 'max_age' option: %w", err)
+		}
+	}
+
+	dialer := netx.Dialer()
+	dialer.Timeout = nats.DefaultTimeout
+
+	opts := []nats.Option{
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.SetCustomDialer(dialer),
+	}
+
+	secure := false
+
+	if q.Has("tls") {
+		if secure, err = strconv.ParseBool(q.Get("tls")); err != nil {
+			return "", nil, o, fmt.Errorf("failed to parse 'tls' option: %w", err)
+		}
+	}
+
+	skipVerify := false
+
+	if q.Has("skip_verify") {
+		if skipVerify, err = strconv.ParseBool(q.Get("skip_verify")); err != nil {
+			return "", nil, o, fmt.Errorf("failed to parse 'skip_verify' option: %w", err)
+		}
+	}
+
+	if secure {
+		opts = append(opts, nats.Secure(&tls.Config{
+			// Users should have the freedom to disable verification for self-signed certificates
+			//nolint:gosec
+			InsecureSkipVerify: skipVerify,
+		}))
+	}
+
+	// Credentials are passed to the NATS client as part of the server URL
+	server := url.URL{
+		Scheme: "nats",
+		User:   u.User,
+		Host:   u.Host,
+	}
+
+	return server.String(), opts, o, nil
+}
+
+// subject returns the subject to which a sender publishes messages for a recipient.
+func (o *BackendOptions) subject(recipient, sender crypto.Key) string {
+	return fmt.Sprintf("%s.%s.%s", o.SubjectPrefix, signaling.EncodeKey(recipient), signaling.EncodeKey(sender))
+}
+
+// subscriptionSubject returns the subject matching all messages for a recipient.
+func (o *BackendOptions) subscriptionSubject(recipient crypto.Key) string {
+	return fmt.Sprintf("%s.%s.*", o.SubjectPrefix, signaling.EncodeKey(recipient))
+}
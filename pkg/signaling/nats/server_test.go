@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package nats_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// server is a minimal NATS server used for testing.
+// It only supports the core publish/subscribe protocol without headers, queue groups or JetStream.
+type server struct {
+	listener net.Listener
+
+	clients map[*serverClient]any
+	mu      sync.Mutex
+}
+
+type serverClient struct {
+	conn net.Conn
+
+	// subjects by subscription ID
+	subs map[string]string
+	mu   sync.Mutex
+}
+
+func newServer() (*server, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &server{
+		listener: l,
+		clients:  map[*serverClient]any{},
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+func (s *server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for c := range s.clients {
+		c.conn.Close()
+	}
+
+	return s.listener.Close()
+}
+
+func (s *server) run() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c := &serverClient{
+			conn: conn,
+			subs: map[string]string{},
+		}
+
+		s.mu.Lock()
+		s.clients[c] = nil
+		s.mu.Unlock()
+
+		go s.handle(c)
+	}
+}
+
+func (s *server) handle(c *serverClient) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+
+		c.conn.Close()
+	}()
+
+	c.write(fmt.Sprintf(`INFO {"server_id":"test","version":"2.10.0","proto":1,"max_payload":1048576,"host":"127.0.0.1","port":%d}`+"\r\n",
+		s.listener.Addr().(*net.TCPAddr).Port)) //nolint:forcetypeassert
+
+	rd := bufio.NewReader(c.conn)
+
+	for {
+		line, err := rd.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			c.write("PONG\r\n")
+
+		case "SUB":
+			// SUB <subject> [queue group] <sid>
+			c.mu.Lock()
+			c.subs[args[len(args)-1]] = args[1]
+			c.mu.Unlock()
+
+		case "UNSUB":
+			c.mu.Lock()
+			delete(c.subs, args[1])
+			c.mu.Unlock()
+
+		case "PUB":
+			// PUB <subject> [reply-to] <#bytes>
+			n, err := strconv.Atoi(args[len(args)-1])
+			if err != nil {
+				return
+			}
+
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(rd, payload); err != nil {
+				return
+			}
+
+			s.publish(args[1], payload[:n])
+		}
+	}
+}
+
+func (s *server) publish(subject string, payload []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for c := range s.clients {
+		c.mu.Lock()
+		for sid, filter := range c.subs {
+			if subjectMatches(filter, subject) {
+				c.conn.Write([]byte(fmt.Sprintf("MSG %s %s %d\r\n%s\r\n", subject, sid, len(payload), payload))) //nolint:errcheck
+			}
+		}
+		c.mu.Unlock()
+	}
+}
+
+func (c *serverClient) write(s string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conn.Write([]byte(s)) //nolint:errcheck
+}
+
+func subjectMatches(filter, subject string) bool {
+	fs, ss := strings.Split(filter, "."), strings.Split(subject, ".")
+
+	for i, f := range fs {
+		switch {
+		case f == ">":
+			return i < len(ss)
+		case i >= len(ss):
+			return false
+		case f != "*" && f != ss[i]:
+			return false
+		}
+	}
+
+	return len(fs) == len(ss)
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package signaling
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cunicu.li/cunicu/pkg/crypto"
+)
+
+// DefaultReplayWindow is the maximum age of a signaling message which is still accepted.
+// It exceeds the retention period of the signaling servers so that retained messages are still delivered.
+const DefaultReplayWindow = 15 * time.Minute
+
+var (
+	ErrStaleMessage       = errors.New("stale message")
+	ErrDuplicateMessage   = errors.New("duplicate message")
+	ErrUnsequencedMessage = errors.New("message without sequence number")
+)
+
+type replayWindow struct {
+	highest uint64
+	seen    map[uint64]struct{}
+}
+
+// ReplayFilter rejects signaling messages which are either too old
+// or have already been received before.
+//
+// Each message carries a sequence number which is derived from the clock of its sender.
+// We keep track of the sequence numbers received from each sender within the replay window.
+// Hence, the clocks of sender and receiver must not deviate by more than the window.
+type ReplayFilter struct {
+	// Window is the maximum age of accepted messages.
+	Window time.Duration
+
+	windows map[crypto.PublicKeyPair]*replayWindow
+	mu      sync.Mutex
+
+	stale     atomic.Uint64
+	duplicate atomic.Uint64
+}
+
+func NewReplayFilter(window time.Duration) *ReplayFilter {
+	return &ReplayFilter{
+		Window:  window,
+		windows: map[crypto.PublicKeyPair]*replayWindow{},
+	}
+}
+
+// Check returns an error if the message with the sequence number seq
+// received via the key pair pkp is stale or a duplicate.
+func (f *ReplayFilter) Check(pkp *crypto.PublicKeyPair, seq uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w, ok := f.windows[*pkp]
+
+	// Messages of peers running older versions do not carry a sequence number.
+	// They can not be protected against replays and are hence rejected.
+	if seq == 0 {
+		f.stale.Add(1)
+
+		return ErrUnsequencedMessage
+	}
+
+	window := uint64(f.Window.Nanoseconds()) //nolint:gosec
+	now := uint64(time.Now().UnixNano())     //nolint:gosec
+
+	if seq+window < now {
+		f.stale.Add(1)
+
+		age := time.Duration(now - seq) //nolint:gosec
+
+		return fmt.Errorf("%w: sent %s ago", ErrStaleMessage, age.Round(time.Second))
+	}
+
+	if ok && seq+window < w.highest {
+		f.stale.Add(1)
+
+		return ErrStaleMessage
+	}
+
+	if !ok {
+		w = &replayWindow{
+			seen: map[uint64]struct{}{},
+		}
+		f.windows[*pkp] = w
+	}
+
+	if _, ok := w.seen[seq]; ok {
+		f.duplicate.Add(1)
+
+		return ErrDuplicateMessage
+	}
+
+	w.seen[seq] = struct{}{}
+
+	if seq > w.highest {
+		w.highest = seq
+
+		// Forget about sequence numbers which are out of the window anyway
+		for s := range w.seen {
+			if s+window < w.highest {
+				delete(w.seen, s)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Rejected returns the number of stale and duplicate messages which have been rejected so far.
+func (f *ReplayFilter) Rejected() (stale, duplicate uint64) {
+	return f.stale.Load(), f.duplicate.Load()
+}
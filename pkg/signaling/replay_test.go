@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package signaling_test
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	epdiscproto "cunicu.li/cunicu/pkg/proto/feature/epdisc"
+	signalingproto "cunicu.li/cunicu/pkg/proto/signaling"
+	"cunicu.li/cunicu/pkg/signaling"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("replay filter", func() {
+	var (
+		f   *signaling.ReplayFilter
+		pkp crypto.PublicKeyPair
+	)
+
+	BeforeEach(func() {
+		sk1, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		sk2, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		pkp = crypto.PublicKeyPair{
+			Ours:   sk1.PublicKey(),
+			Theirs: sk2.PublicKey(),
+		}
+
+		f = signaling.NewReplayFilter(time.Minute)
+	})
+
+	It("accepts fresh messages", func() {
+		Expect(f.Check(&pkp, signalingproto.NextSequence())).To(Succeed())
+		Expect(f.Check(&pkp, signalingproto.NextSequence())).To(Succeed())
+	})
+
+	It("accepts reordered messages within the window", func() {
+		seq1 := signalingproto.NextSequence()
+		seq2 := signalingproto.NextSequence()
+
+		Expect(f.Check(&pkp, seq2)).To(Succeed())
+		Expect(f.Check(&pkp, seq1)).To(Succeed())
+	})
+
+	It("rejects duplicate messages", func() {
+		seq := signalingproto.NextSequence()
+
+		Expect(f.Check(&pkp, seq)).To(Succeed())
+		Expect(f.Check(&pkp, seq)).To(MatchError(signaling.ErrDuplicateMessage))
+
+		_, duplicate := f.Rejected()
+		Expect(duplicate).To(BeNumerically("==", 1))
+	})
+
+	It("rejects messages older than the window", func() {
+		seq := uint64(time.Now().Add(-2 * time.Minute).UnixNano()) //nolint:gosec
+
+		Expect(f.Check(&pkp, seq)).To(MatchError(signaling.ErrStaleMessage))
+
+		stale, _ := f.Rejected()
+		Expect(stale).To(BeNumerically("==", 1))
+	})
+
+	It("rejects messages far behind the newest one", func() {
+		seq := uint64(time.Now().UnixNano()) //nolint:gosec
+
+		Expect(f.Check(&pkp, seq+uint64(2*time.Minute))).To(Succeed())
+		Expect(f.Check(&pkp, seq)).To(MatchError(signaling.ErrStaleMessage))
+	})
+
+	It("rejects unsequenced messages", func() {
+		Expect(f.Check(&pkp, 0)).To(MatchError(signaling.ErrUnsequencedMessage))
+		Expect(f.Check(&pkp, signalingproto.NextSequence())).To(Succeed())
+		Expect(f.Check(&pkp, 0)).To(MatchError(signaling.ErrUnsequencedMessage))
+
+		stale, _ := f.Rejected()
+		Expect(stale).To(BeNumerically("==", 2))
+	})
+
+	It("drops replayed envelopes", func() {
+		mb, err := signaling.NewMultiBackend([]url.URL{{Scheme: "inprocess"}}, &signaling.BackendConfig{})
+		Expect(err).To(Succeed())
+
+		DeferCleanup(mb.Close)
+
+		ourSK, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		theirSK, err := crypto.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		h := &messageHandler{
+			messages: make(chan *signaling.Message, 2),
+		}
+
+		_, err = mb.Subscribe(context.Background(), &crypto.KeyPair{
+			Ours:   ourSK,
+			Theirs: theirSK.PublicKey(),
+		}, h)
+		Expect(err).To(Succeed())
+
+		msg := &signaling.Message{
+			Candidate: &epdiscproto.Candidate{
+				Port: 1234,
+			},
+		}
+
+		env, err := msg.Encrypt(&crypto.KeyPair{
+			Ours:   theirSK,
+			Theirs: ourSK.PublicKey(),
+		})
+		Expect(err).To(Succeed())
+
+		Expect(mb.InjectEnvelope(env)).To(Succeed())
+		Eventually(h.messages).Should(Receive())
+
+		Expect(mb.InjectEnvelope(env)).To(Succeed())
+		Consistently(h.messages).ShouldNot(Receive())
+	})
+})
@@ -21,3 +21,10 @@ type MessageHandler interface {
 type EnvelopeHandler interface {
 	OnSignalingEnvelope(env *Envelope)
 }
+
+// EnvelopeSource is implemented by backends which can notify
+// about the raw envelopes they receive.
+type EnvelopeSource interface {
+	AddEnvelopeHandler(h EnvelopeHandler)
+	RemoveEnvelopeHandler(h EnvelopeHandler)
+}
@@ -22,23 +22,32 @@ var (
 
 type Subscription struct {
 	onMessages map[crypto.Key][]MessageHandler
+	replay     *ReplayFilter
 
 	mu sync.RWMutex
 	sk crypto.Key
 }
 
 type SubscriptionsRegistry struct {
-	subs map[crypto.Key]*Subscription
+	subs       map[crypto.Key]*Subscription
+	onEnvelope []EnvelopeHandler
+	replay     *ReplayFilter
 
 	mu sync.RWMutex
 }
 
 func NewSubscriptionsRegistry() SubscriptionsRegistry {
 	return SubscriptionsRegistry{
-		subs: map[crypto.Key]*Subscription{},
+		subs:   map[crypto.Key]*Subscription{},
+		replay: NewReplayFilter(DefaultReplayWindow),
 	}
 }
 
+// ReplayFilter returns the filter which rejects stale and duplicate messages of all subscriptions.
+func (s *SubscriptionsRegistry) ReplayFilter() *ReplayFilter {
+	return s.replay
+}
+
 func (s *SubscriptionsRegistry) NewMessage(env *Envelope) error {
 	pk, err := crypto.ParseKeyBytes(env.Recipient)
 	if err != nil {
@@ -50,9 +59,34 @@ func (s *SubscriptionsRegistry) NewMessage(env *Envelope) error {
 		return err
 	}
 
+	s.mu.RLock()
+	for _, h := range s.onEnvelope {
+		h.OnSignalingEnvelope(env)
+	}
+	s.mu.RUnlock()
+
 	return sub.NewMessage(env)
 }
 
+// AddEnvelopeHandler registers a handler which is called for each envelope received for one of our subscriptions.
+func (s *SubscriptionsRegistry) AddEnvelopeHandler(h EnvelopeHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !slices.Contains(s.onEnvelope, h) {
+		s.onEnvelope = append(s.onEnvelope, h)
+	}
+}
+
+func (s *SubscriptionsRegistry) RemoveEnvelopeHandler(h EnvelopeHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if idx := slices.Index(s.onEnvelope, h); idx > -1 {
+		s.onEnvelope = slices.Delete(s.onEnvelope, idx, idx+1)
+	}
+}
+
 func (s *SubscriptionsRegistry) NewSubscription(k *crypto.Key) (*Subscription, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -63,6 +97,7 @@ func (s *SubscriptionsRegistry) NewSubscription(k *crypto.Key) (*Subscription, e
 
 	sub := &Subscription{
 		onMessages: map[crypto.Key][]MessageHandler{},
+		replay:     s.replay,
 		sk:         *k,
 	}
 
@@ -137,7 +172,32 @@ func (s *Subscription) NewMessage(env *Envelope) error {
 		return err
 	}
 
-	log.Global.Named("backend").Debug("Received signaling message", zap.Reflect("msg", msg), zap.Any("pkp", pkp))
+	logger := log.Global.Named("backend")
+
+	if err := s.replay.Check(&pkp, msg.Sequence); err != nil {
+		stale, duplicate := s.replay.Rejected()
+		fields := []zap.Field{
+			zap.Error(err),
+			zap.Uint64("seq", msg.Sequence),
+			zap.Any("pkp", pkp),
+			zap.Uint64("stale", stale),
+			zap.Uint64("duplicate", duplicate),
+		}
+
+		// Duplicates are expected when signaling servers replay retained messages
+		switch {
+		case errors.Is(err, ErrDuplicateMessage):
+			logger.Debug("Rejected duplicate signaling message", fields...)
+		case errors.Is(err, ErrUnsequencedMessage):
+			logger.Warn("Rejected signaling message without sequence number. The sender runs an outdated version of cunīcu", fields...)
+		default:
+			logger.Warn("Rejected stale signaling message. Check that the clocks of the peers are synchronized", fields...)
+		}
+
+		return nil
+	}
+
+	logger.Debug("Received signaling message", zap.Reflect("msg", msg), zap.Any("pkp", pkp))
 
 	s.mu.RLock()
 	defer s.mu.RUnlock()
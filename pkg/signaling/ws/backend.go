@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"cunicu.li/cunicu/pkg/buildinfo"
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/log"
+	netx "cunicu.li/cunicu/pkg/net"
+	signalingproto "cunicu.li/cunicu/pkg/proto/signaling"
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+const (
+	handshakeTimeout = 10 * time.Second
+
+	minReconnectInterval = 1 * time.Second
+	maxReconnectInterval = 30 * time.Second
+)
+
+func init() { //nolint:gochecknoinits
+	signaling.Backends["ws"] = &signaling.BackendPlugin{
+		New:         NewBackend,
+		Description: "WebSocket",
+	}
+
+	signaling.Backends["wss"] = &signaling.BackendPlugin{
+		New:         NewBackend,
+		Description: "WebSocket over TLS",
+	}
+}
+
+type Backend struct {
+	signaling.SubscriptionsRegistry
+
+	client *http.Client
+	dialer *websocket.Dialer
+
+	// cancel contains functions for canceling the subscriptions of each recipient
+	cancel   map[crypto.Key]context.CancelFunc
+	cancelMu sync.Mutex
+
+	config BackendConfig
+
+	logger *log.Logger
+}
+
+func NewBackend(cfg *signaling.BackendConfig, logger *log.Logger) (signaling.Backend, error) {
+	b := &Backend{
+		SubscriptionsRegistry: signaling.NewSubscriptionsRegistry(),
+		cancel:                map[crypto.Key]context.CancelFunc{},
+		logger:                logger,
+	}
+
+	if err := b.config.Parse(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse backend configuration: %w", err)
+	}
+
+	// Both, the HTTP client and the WebSocket dialer honor the HTTP(S)_PROXY environment variables
+	// and tunnel through HTTP proxies using the CONNECT method.
+	b.client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			DialContext:     netx.Dialer().DialContext,
+			TLSClientConfig: b.config.Options.TLSConfig,
+		},
+	}
+
+	b.dialer = &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		NetDialContext:   netx.Dialer().DialContext,
+		TLSClientConfig:  b.config.Options.TLSConfig,
+		HandshakeTimeout: handshakeTimeout,
+	}
+
+	for _, h := range cfg.OnReady {
+		h.OnSignalingBackendReady(b)
+	}
+
+	return b, nil
+}
+
+func (b *Backend) Type() signalingproto.BackendType {
+	return signalingproto.BackendType_WEBSOCKET
+}
+
+func (b *Backend) Subscribe(ctx context.Context, kp *crypto.KeyPair, h signaling.MessageHandler) (bool, error) {
+	first, err := b.SubscriptionsRegistry.Subscribe(kp, h)
+	if err != nil {
+		return false, err
+	} else if first {
+		pk := kp.Ours.PublicKey()
+
+		return first, b.subscribeFromServer(ctx, pk)
+	}
+
+	return first, nil
+}
+
+// Unsubscribe from messages send by a specific peer.
+func (b *Backend) Unsubscribe(_ context.Context, kp *crypto.KeyPair, h signaling.MessageHandler) (bool, error) {
+	last, err := b.SubscriptionsRegistry.Unsubscribe(kp, h)
+	if err != nil {
+		return false, err
+	} else if last {
+		pk := kp.Ours.PublicKey()
+
+		b.cancelMu.Lock()
+		if cancel, ok := b.cancel[pk]; ok {
+			cancel()
+			delete(b.cancel, pk)
+		}
+		b.cancelMu.Unlock()
+	}
+
+	return last, nil
+}
+
+func (b *Backend) Publish(ctx context.Context, kp *crypto.KeyPair, msg *signaling.Message) error {
+	env, err := msg.Encrypt(kp)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt message: %w", err)
+	}
+
+	buf, err := proto.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to marshal protobuf: %w", err)
+	}
+
+	u := b.config.Options.HTTPURL
+	u.Path += pathPublish
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(buf))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", buildinfo.UserAgent())
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("%w: %s", errUnexpectedStatus, resp.Status)
+	}
+
+	return nil
+}
+
+func (b *Backend) Close() error {
+	b.cancelMu.Lock()
+	defer b.cancelMu.Unlock()
+
+	for pk, cancel := range b.cancel {
+		cancel()
+		delete(b.cancel, pk)
+	}
+
+	b.client.CloseIdleConnections()
+
+	return nil
+}
+
+func (b *Backend) subscribeFromServer(ctx context.Context, pk crypto.Key) error {
+	// The subscription outlives the context of the Subscribe() call
+	subCtx, cancel := context.WithCancel(context.Background())
+
+	st, err := b.open(ctx, subCtx, pk)
+	if err != nil {
+		cancel()
+
+		return err
+	}
+
+	b.cancelMu.Lock()
+	b.cancel[pk] = cancel
+	b.cancelMu.Unlock()
+
+	b.logger.Debug("Created new subscription", zap.Any("pk", pk), zap.String("transport", st.Transport()))
+
+	go b.receive(subCtx, pk, st)
+
+	return nil
+}
+
+// open establishes a new stream of envelopes for the recipient pk.
+// It waits until the subscription has been created by the server.
+func (b *Backend) open(ctx, subCtx context.Context, pk crypto.Key) (stream, error) {
+	if b.config.Options.Transport != TransportPoll {
+		st, err := b.openWebSocket(ctx, subCtx, pk)
+		if err == nil {
+			return st, nil
+		} else if b.config.Options.Transport == TransportWebSocket || !errors.Is(err, websocket.ErrBadHandshake) {
+			return nil, err
+		}
+
+		b.logger.Info("WebSocket handshake failed. Falling back to long-polling", zap.Error(err))
+	}
+
+	return b.openPoll(ctx, subCtx, pk)
+}
+
+// receive passes envelopes from the stream to the subscriptions and re-opens the stream on errors.
+func (b *Backend) receive(ctx context.Context, pk crypto.Key, st stream) {
+	interval := minReconnectInterval
+
+	for {
+		envs, err := st.Recv()
+		if err != nil {
+			st.Close() //nolint:errcheck
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			b.logger.Error("Subscription stream closed. Re-subscribing..", zap.Error(err))
+
+			for {
+				select {
+				case <-time.After(interval):
+				case <-ctx.Done():
+					return
+				}
+
+				if st, err = b.open(ctx, ctx, pk); err == nil {
+					break
+				}
+
+				interval = min(2*interval, maxReconnectInterval)
+
+				b.logger.Error("Failed to resubscribe", zap.Error(err))
+			}
+
+			interval = minReconnectInterval
+
+			continue
+		}
+
+		for _, env := range envs {
+			if err := b.SubscriptionsRegistry.NewMessage(env); err != nil {
+				b.logger.Error("Failed to decrypt message", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package ws_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"cunicu.li/cunicu/pkg/signaling/grpc"
+	"cunicu.li/cunicu/pkg/signaling/ws"
+	"cunicu.li/cunicu/test"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSuite(t *testing.T) {
+	test.SetupLogging()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "WebSocket Backend Suite")
+}
+
+// noUpgrade mimics a proxy which does not support WebSockets.
+func noUpgrade(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			http.Error(w, "upgrade not supported", http.StatusForbidden)
+
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+var _ = Describe("WebSocket backend", func() {
+	var (
+		svr     *grpc.Server
+		handler *ws.Handler
+		httpSvr *httptest.Server
+	)
+
+	u := &url.URL{
+		Scheme: "ws",
+	}
+
+	setup := func(wrap func(http.Handler) http.Handler, transport string) {
+		BeforeEach(func() {
+			var err error
+
+			svr, err = grpc.NewSignalingServer(&grpc.ServerConfig{})
+			Expect(err).To(Succeed())
+
+			handler = ws.NewHandler(svr)
+			httpSvr = httptest.NewServer(wrap(handler))
+
+			su, err := url.Parse(httpSvr.URL)
+			Expect(err).To(Succeed())
+
+			u.Host = su.Host
+			u.RawQuery = url.Values{"transport": {transport}}.Encode()
+
+			// Runs after the backends have been closed which cancels pending long-polling requests
+			DeferCleanup(func() {
+				Expect(handler.Close()).To(Succeed())
+				httpSvr.Close()
+				Expect(svr.Close()).To(Succeed())
+			})
+		})
+	}
+
+	identity := func(h http.Handler) http.Handler { return h }
+
+	Context("websocket", func() {
+		setup(identity, "websocket")
+
+		test.BackendTest(u, 10)
+	})
+
+	Context("long-polling", func() {
+		setup(identity, "poll")
+
+		test.BackendTest(u, 10)
+	})
+
+	Context("fallback", func() {
+		setup(noUpgrade, "auto")
+
+		test.BackendTest(u, 4)
+	})
+})
+
+var _ = DescribeTable("parses URLs",
+	func(urlStr, httpURL, wsURL string, transport ws.Transport) {
+		o, err := ws.ParseURL(urlStr)
+		Expect(err).To(Succeed())
+		Expect(o.HTTPURL.String()).To(Equal(httpURL))
+		Expect(o.WebSocketURL.String()).To(Equal(wsURL))
+		Expect(o.Transport).To(Equal(transport))
+	},
+	Entry("plain", "ws://server:8081", "http://server:8081", "ws://server:8081", ws.TransportAuto),
+	Entry("tls", "wss://server/signaling/?transport=poll", "https://server/signaling", "wss://server/signaling", ws.TransportPoll),
+)
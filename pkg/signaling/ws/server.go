@@ -0,0 +1,348 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+
+	"cunicu.li/cunicu/pkg/crypto"
+	"cunicu.li/cunicu/pkg/log"
+	signalingproto "cunicu.li/cunicu/pkg/proto/signaling"
+	"cunicu.li/cunicu/pkg/signaling"
+	"cunicu.li/cunicu/pkg/signaling/grpc"
+)
+
+const (
+	pingInterval = 30 * time.Second
+	writeTimeout = 10 * time.Second
+
+	// maxPollEnvelopes limits the number of envelopes returned by a single long-polling request.
+	maxPollEnvelopes = 100
+)
+
+// pollSession keeps the subscription of a long-polling client between two requests.
+type pollSession struct {
+	pk    crypto.Key
+	topic *grpc.Topic
+	ch    chan *signaling.Envelope
+
+	lastSeen time.Time
+}
+
+// Handler serves the WebSocket and long-polling endpoints of the signaling server.
+// It shares its topics with the gRPC signaling server so that clients of both transports can exchange envelopes.
+type Handler struct {
+	*http.ServeMux
+
+	server   *grpc.Server
+	upgrader websocket.Upgrader
+
+	sessions   map[string]*pollSession
+	sessionsMu sync.Mutex
+
+	stop chan struct{}
+
+	logger *log.Logger
+}
+
+func NewHandler(svr *grpc.Server) *Handler {
+	h := &Handler{
+		ServeMux: http.NewServeMux(),
+		server:   svr,
+		upgrader: websocket.Upgrader{
+			// Clients are cunicu agents rather than browsers
+			CheckOrigin: func(*http.Request) bool { return true },
+		},
+		sessions: map[string]*pollSession{},
+		stop:     make(chan struct{}),
+		logger:   log.Global.Named("ws.server"),
+	}
+
+	h.HandleFunc("POST "+pathPublish, h.handlePublish)
+	h.HandleFunc("GET "+pathSubscribe+"{key}", h.handleSubscribe)
+	h.HandleFunc("GET "+pathPoll+"{key}", h.handlePoll)
+
+	go h.expireSessions()
+
+	return h
+}
+
+func (h *Handler) Close() error {
+	close(h.stop)
+
+	h.sessionsMu.Lock()
+	defer h.sessionsMu.Unlock()
+
+	for id, s := range h.sessions {
+		s.topic.Unsubscribe(s.ch)
+		delete(h.sessions, id)
+	}
+
+	return nil
+}
+
+func (h *Handler) handlePublish(w http.ResponseWriter, r *http.Request) {
+	buf, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEnvelopeSize))
+	if err != nil {
+		http.Error(w, "failed to read envelope", http.StatusBadRequest)
+
+		return
+	}
+
+	env := &signalingproto.Envelope{}
+	if err := proto.Unmarshal(buf, env); err != nil {
+		http.Error(w, "failed to unmarshal envelope", http.StatusBadRequest)
+
+		return
+	}
+
+	if _, err := h.server.Publish(r.Context(), env); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleSubscribe(w http.ResponseWriter, r *http.Request) {
+	pk, err := signaling.DecodeKey(r.PathValue("key"))
+	if err != nil {
+		http.Error(w, "invalid key", http.StatusBadRequest)
+
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade() has already responded to the client
+		return
+	}
+	defer conn.Close()
+
+	top := h.server.Topic(&pk)
+
+	ch, retained := top.Subscribe()
+	defer top.Unsubscribe(ch)
+
+	// Detect closed connections by reading until an error occurs.
+	// This also processes control frames such as pongs.
+	closed := make(chan struct{})
+
+	go func() {
+		defer close(closed)
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// We send an empty envelope to signal the subscriber that the subscription
+	// has been created. This avoids a race between Subscribe() & Publish() from the
+	// clients view-point.
+	if err := h.send(conn, &signalingproto.Envelope{}); err != nil {
+		return
+	}
+
+	for _, env := range retained {
+		if err := h.send(conn, env); err != nil {
+			return
+		}
+	}
+
+	h.logger.Debug("WebSocket subscription opened",
+		zap.Any("recipient", pk),
+		zap.String("remote", r.RemoteAddr))
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case env, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			if err := h.send(conn, env); err != nil {
+				h.logger.Error("Failed to send envelope", zap.Error(err))
+
+				return
+			}
+
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+
+		case <-closed:
+			h.logger.Debug("WebSocket subscription closed", zap.Any("recipient", pk))
+
+			return
+
+		case <-h.stop:
+			return
+		}
+	}
+}
+
+func (h *Handler) send(conn *websocket.Conn, env *signaling.Envelope) error {
+	buf, err := proto.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+
+	return conn.WriteMessage(websocket.BinaryMessage, buf)
+}
+
+// handlePoll serves long-polling requests.
+// The first request of a client creates a new session and immediately returns the retained envelopes.
+// Subsequent requests of the session are held until new envelopes are available or pollTimeout has passed.
+func (h *Handler) handlePoll(w http.ResponseWriter, r *http.Request) {
+	pk, err := signaling.DecodeKey(r.PathValue("key"))
+	if err != nil {
+		http.Error(w, "invalid key", http.StatusBadRequest)
+
+		return
+	}
+
+	var envs []*signaling.Envelope
+
+	id := r.URL.Query().Get("session")
+	if id == "" {
+		if id, envs, err = h.newSession(pk); err != nil {
+			http.Error(w, "failed to create session", http.StatusInternalServerError)
+
+			return
+		}
+	} else {
+		h.sessionsMu.Lock()
+		s, ok := h.sessions[id]
+		if ok {
+			s.lastSeen = time.Now()
+		}
+		h.sessionsMu.Unlock()
+
+		if !ok || s.pk != pk {
+			http.Error(w, "unknown session", http.StatusNotFound)
+
+			return
+		}
+
+		envs = h.wait(r, s)
+	}
+
+	w.Header().Set(pollSessionHeader, id)
+	w.Header().Set("Content-Type", contentType)
+
+	for _, env := range envs {
+		if _, err := protodelim.MarshalTo(w, env); err != nil {
+			return
+		}
+	}
+}
+
+func (h *Handler) newSession(pk crypto.Key) (string, []*signaling.Envelope, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, err
+	}
+
+	id := hex.EncodeToString(buf)
+	top := h.server.Topic(&pk)
+	ch, retained := top.Subscribe()
+
+	h.sessionsMu.Lock()
+	h.sessions[id] = &pollSession{
+		pk:       pk,
+		topic:    top,
+		ch:       ch,
+		lastSeen: time.Now(),
+	}
+	h.sessionsMu.Unlock()
+
+	h.logger.Debug("Long-polling session created", zap.Any("recipient", pk))
+
+	return id, retained, nil
+}
+
+// wait blocks until at least one envelope is available or the request timed out.
+func (h *Handler) wait(r *http.Request, s *pollSession) []*signaling.Envelope {
+	envs := []*signaling.Envelope{}
+
+	timer := time.NewTimer(pollTimeout)
+	defer timer.Stop()
+
+	select {
+	case env, ok := <-s.ch:
+		if !ok {
+			return envs
+		}
+
+		envs = append(envs, env)
+
+	case <-timer.C:
+		return envs
+
+	case <-r.Context().Done():
+		return envs
+	}
+
+	for len(envs) < maxPollEnvelopes {
+		select {
+		case env, ok := <-s.ch:
+			if !ok {
+				return envs
+			}
+
+			envs = append(envs, env)
+
+		default:
+			return envs
+		}
+	}
+
+	return envs
+}
+
+// expireSessions periodically removes sessions of clients which stopped polling.
+func (h *Handler) expireSessions() {
+	ticker := time.NewTicker(pollTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-h.stop:
+			return
+		}
+
+		h.sessionsMu.Lock()
+		for id, s := range h.sessions {
+			if time.Since(s.lastSeen) > 2*pollTimeout {
+				s.topic.Unsubscribe(s.ch)
+				delete(h.sessions, id)
+
+				h.logger.Debug("Long-polling session expired", zap.Any("recipient", s.pk))
+			}
+		}
+		h.sessionsMu.Unlock()
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+
+	"cunicu.li/cunicu/pkg/buildinfo"
+	"cunicu.li/cunicu/pkg/crypto"
+	signalingproto "cunicu.li/cunicu/pkg/proto/signaling"
+	"cunicu.li/cunicu/pkg/signaling"
+)
+
+var (
+	errUnexpectedStatus  = errors.New("unexpected HTTP status")
+	errUnexpectedMessage = errors.New("unexpected WebSocket message type")
+)
+
+// stream receives envelopes for a single recipient from the signaling server.
+type stream interface {
+	io.Closer
+
+	Recv() ([]*signaling.Envelope, error)
+	Transport() string
+}
+
+type webSocketStream struct {
+	conn *websocket.Conn
+}
+
+// openWebSocket dials the WebSocket endpoint using ctx and closes the connection once subCtx is done.
+func (b *Backend) openWebSocket(ctx, subCtx context.Context, pk crypto.Key) (*webSocketStream, error) {
+	u := b.config.Options.WebSocketURL
+	u.Path += pathSubscribe + signaling.EncodeKey(pk)
+
+	hdr := http.Header{}
+	hdr.Set("User-Agent", buildinfo.UserAgent())
+
+	conn, resp, err := b.dialer.DialContext(ctx, u.String(), hdr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial: %w", err)
+	}
+
+	resp.Body.Close()
+
+	s := &webSocketStream{
+		conn: conn,
+	}
+
+	// Wait for synchronization envelope
+	if _, err := s.Recv(); err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("failed receive synchronization envelope: %w", err)
+	}
+
+	context.AfterFunc(subCtx, func() {
+		conn.Close()
+	})
+
+	return s, nil
+}
+
+func (s *webSocketStream) Recv() ([]*signaling.Envelope, error) {
+	typ, buf, err := s.conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	} else if typ != websocket.BinaryMessage {
+		return nil, fmt.Errorf("%w: %d", errUnexpectedMessage, typ)
+	}
+
+	env := &signalingproto.Envelope{}
+	if err := proto.Unmarshal(buf, env); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal envelope: %w", err)
+	}
+
+	return []*signaling.Envelope{env}, nil
+}
+
+func (s *webSocketStream) Close() error {
+	return s.conn.Close()
+}
+
+func (s *webSocketStream) Transport() string {
+	return string(TransportWebSocket)
+}
+
+type pollStream struct {
+	ctx     context.Context //nolint:containedctx
+	backend *Backend
+	url     url.URL
+	session string
+
+	// pending envelopes which have been received while creating the session
+	pending []*signaling.Envelope
+}
+
+// openPoll creates a new long-polling session using ctx.
+// Subsequent polls are canceled once subCtx is done.
+func (b *Backend) openPoll(ctx, subCtx context.Context, pk crypto.Key) (*pollStream, error) {
+	s := &pollStream{
+		ctx:     subCtx,
+		backend: b,
+		url:     b.config.Options.HTTPURL,
+	}
+
+	s.url.Path += pathPoll + signaling.EncodeKey(pk)
+
+	var err error
+	if s.pending, err = s.poll(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *pollStream) Recv() ([]*signaling.Envelope, error) {
+	if s.pending != nil {
+		envs := s.pending
+		s.pending = nil
+
+		return envs, nil
+	}
+
+	return s.poll(s.ctx)
+}
+
+func (s *pollStream) poll(ctx context.Context) ([]*signaling.Envelope, error) {
+	u := s.url
+	if s.session != "" {
+		u.RawQuery = url.Values{"session": {s.session}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", buildinfo.UserAgent())
+
+	resp, err := s.backend.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to poll: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", errUnexpectedStatus, resp.Status)
+	}
+
+	s.session = resp.Header.Get(pollSessionHeader)
+
+	envs := []*signaling.Envelope{}
+	rd := bufio.NewReader(resp.Body)
+
+	for {
+		env := &signalingproto.Envelope{}
+		if err := protodelim.UnmarshalFrom(rd, env); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal envelope: %w", err)
+		}
+
+		envs = append(envs, env)
+	}
+
+	return envs, nil
+}
+
+func (s *pollStream) Close() error {
+	return nil
+}
+
+func (s *pollStream) Transport() string {
+	return string(TransportPoll)
+}
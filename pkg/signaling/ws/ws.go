@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2023-2025 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ws implements a signaling backend using WebSockets with a fallback to HTTP long-polling
+package ws
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	pathPublish   = "/publish"
+	pathSubscribe = "/subscribe/"
+	pathPoll      = "/poll/"
+
+	contentType = "application/x-protobuf"
+
+	// pollSessionHeader carries the identifier of a long-polling session.
+	pollSessionHeader = "Cunicu-Poll-Session"
+
+	// pollTimeout is the maximum duration for which the server holds a long-polling request.
+	// It is chosen below the idle timeouts of common HTTP proxies.
+	pollTimeout = 25 * time.Second
+
+	// maxEnvelopeSize limits the size of published envelopes.
+	maxEnvelopeSize = 64 << 10
+)
+
+var (
+	errInvalidServerHostname = errors.New("missing signaling server url")
+	errInvalidTransport      = errors.New("invalid transport")
+)
+
+type Transport string
+
+const (
+	// TransportAuto uses WebSockets and falls back to long-polling if the WebSocket handshake fails.
+	TransportAuto      Transport = "auto"
+	TransportWebSocket Transport = "websocket"
+	TransportPoll      Transport = "poll"
+)
+
+type BackendOptions struct {
+	// URL of the HTTP endpoint of the signaling server for publishing and long-polling
+	HTTPURL url.URL
+
+	// URL of the WebSocket endpoint of the signaling server
+	WebSocketURL url.URL
+
+	Transport Transport
+	TLSConfig *tls.Config
+}
+
+func ParseURL(urlStr string) (BackendOptions, error) {
+	o := BackendOptions{
+		Transport: TransportAuto,
+		TLSConfig: &tls.Config{},
+	}
+
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return o, err
+	}
+
+	if u.Host == "" {
+		return o, errInvalidServerHostname
+	}
+
+	q := u.Query()
+
+	if q.Has("transport") {
+		switch t := Transport(q.Get("transport")); t {
+		case TransportAuto, TransportWebSocket, TransportPoll:
+			o.Transport = t
+		default:
+			return o, fmt.Errorf("%w: %s", errInvalidTransport, t)
+		}
+	}
+
+	if q.Has("skip_verify") {
+		if o.TLSConfig.InsecureSkipVerify, err = strconv.ParseBool(q.Get("skip_verify")); err != nil {
+			return o, fmt.Errorf("failed to parse 'skip_verify' option: %w", err)
+		}
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+
+	o.WebSocketURL = url.URL{
+		Scheme: u.Scheme,
+		User:   u.User,
+		Host:   u.Host,
+		Path:   path,
+	}
+
+	o.HTTPURL = o.WebSocketURL
+	if u.Scheme == "wss" {
+		o.HTTPURL.Scheme = "https"
+	} else {
+		o.HTTPURL.Scheme = "http"
+	}
+
+	return o, nil
+}